@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/comments"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/plan"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/threads"
+)
+
+func newApplyCommand() *cobra.Command {
+	opts := &applyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "apply <plan.json | ->",
+		Short: "Run a JSON plan of review operations against one pull request",
+		Long: "Run a JSON plan of review operations (start, add_comment, reply, resolve, unresolve, " +
+			"submit, edit, delete) against one pull request. Later steps can reference earlier " +
+			"results with $steps.<id>.<field>.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.PlanPath = args[0]
+			return runApply(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format (overrides the plan)")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number (overrides the plan)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Validate the plan without calling GitHub")
+	cmd.Flags().BoolVar(&opts.ContinueOnError, "continue-on-error", false, "Keep running independent steps after a failure (overrides the plan)")
+	bindRedactFlag(cmd)
+
+	return configure(cmd)
+}
+
+type applyOptions struct {
+	Repo            string
+	Pull            int
+	PlanPath        string
+	DryRun          bool
+	ContinueOnError bool
+}
+
+func runApply(cmd *cobra.Command, opts *applyOptions) error {
+	p, err := readPlan(cmd, opts.PlanPath)
+	if err != nil {
+		return err
+	}
+
+	repo := strings.TrimSpace(opts.Repo)
+	if repo == "" {
+		repo = p.Repo
+	}
+	number := opts.Pull
+	if number == 0 {
+		number = p.PR
+	}
+	selector, err := resolver.NormalizeSelector("", number)
+	if err != nil {
+		return err
+	}
+	identity, err := resolver.Resolve(selector, repo, defaultHost())
+	if err != nil {
+		return err
+	}
+
+	runner := &plan.Runner{Operations: applyOperations(apiClientFactory(identity.Host), identity)}
+	var result plan.Result
+	if opts.DryRun {
+		result = runner.Validate(p)
+	} else {
+		result = runner.Run(p, opts.ContinueOnError || p.ContinueOnError)
+	}
+	if err := encodeJSON(cmd, result); err != nil {
+		return err
+	}
+
+	switch {
+	case !result.Valid:
+		return errors.New("plan is invalid; no steps were executed")
+	case result.Failed > 0:
+		return fmt.Errorf("%d of %d plan steps failed", result.Failed, len(result.Steps))
+	case result.Skipped > 0:
+		return fmt.Errorf("%d of %d plan steps were skipped", result.Skipped, len(result.Steps))
+	}
+	return nil
+}
+
+func readPlan(cmd *cobra.Command, path string) (plan.Plan, error) {
+	if path == "-" {
+		return plan.Decode(cmd.InOrStdin())
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return plan.Plan{}, fmt.Errorf("open plan: %w", err)
+	}
+	defer file.Close()
+	return plan.Decode(file)
+}
+
+// applyOperations binds the plan operations to one pull request. Each Prepare
+// applies the same validation as the equivalent command flags.
+func applyOperations(api ghcli.API, pr resolver.Identity) map[string]plan.Operation {
+	reviews := newReviewService(api)
+	replies := newCommentsService(api)
+	threadService := threads.NewService(api)
+
+	return map[string]plan.Operation{
+		"start": {
+			Outputs: map[string]interface{}{"id": "PRR_example", "state": "PENDING"},
+			Prepare: func(raw json.RawMessage) (plan.Action, error) {
+				var args struct {
+					Commit string `json:"commit"`
+				}
+				if err := decodeStepArgs(raw, &args); err != nil {
+					return nil, err
+				}
+				return func() (interface{}, error) {
+					return reviews.Start(pr, strings.TrimSpace(args.Commit))
+				}, nil
+			},
+		},
+		"add_comment": {
+			Outputs: map[string]interface{}{"id": "PRRT_example", "path": "example.go", "line": 1},
+			Prepare: func(raw json.RawMessage) (plan.Action, error) {
+				args := struct {
+					ReviewID  string `json:"review_id"`
+					Path      string `json:"path"`
+					Line      int    `json:"line"`
+					Side      string `json:"side"`
+					StartLine int    `json:"start_line"`
+					StartSide string `json:"start_side"`
+					Body      string `json:"body"`
+				}{Side: "RIGHT"}
+				if err := decodeStepArgs(raw, &args); err != nil {
+					return nil, err
+				}
+				input, err := (&reviewOptions{
+					ReviewID:  args.ReviewID,
+					Path:      args.Path,
+					Line:      args.Line,
+					Side:      args.Side,
+					StartLine: args.StartLine,
+					StartSide: args.StartSide,
+					Body:      args.Body,
+				}).threadInput()
+				if err != nil {
+					return nil, err
+				}
+				return func() (interface{}, error) {
+					return reviews.AddThread(pr, input)
+				}, nil
+			},
+		},
+		"reply": {
+			Outputs: map[string]interface{}{"comment_node_id": "PRRC_example"},
+			Prepare: func(raw json.RawMessage) (plan.Action, error) {
+				var args struct {
+					ThreadID string `json:"thread_id"`
+					ReviewID string `json:"review_id"`
+					Body     string `json:"body"`
+				}
+				if err := decodeStepArgs(raw, &args); err != nil {
+					return nil, err
+				}
+				if strings.TrimSpace(args.ThreadID) == "" {
+					return nil, errors.New("thread_id is required")
+				}
+				if strings.TrimSpace(args.Body) == "" {
+					return nil, errors.New("body is required")
+				}
+				return func() (interface{}, error) {
+					reply, err := replies.Reply(pr, comments.ReplyOptions{ThreadID: args.ThreadID, ReviewID: args.ReviewID, Body: args.Body})
+					if err != nil {
+						return nil, err
+					}
+					if reply.CommentNodeID == "" {
+						return nil, errors.New("reply response missing comment node id")
+					}
+					return map[string]string{"comment_node_id": reply.CommentNodeID}, nil
+				}, nil
+			},
+		},
+		"resolve":   applyThreadOperation(threadService, pr, true),
+		"unresolve": applyThreadOperation(threadService, pr, false),
+		"submit": {
+			Outputs: map[string]interface{}{"status": "Review submitted successfully"},
+			Prepare: func(raw json.RawMessage) (plan.Action, error) {
+				args := struct {
+					ReviewID string `json:"review_id"`
+					Event    string `json:"event"`
+					Body     string `json:"body"`
+				}{Event: "COMMENT"}
+				if err := decodeStepArgs(raw, &args); err != nil {
+					return nil, err
+				}
+				input, err := (&reviewOptions{ReviewID: args.ReviewID, Event: args.Event, Body: args.Body}).submitInput()
+				if err != nil {
+					return nil, err
+				}
+				return func() (interface{}, error) {
+					status, err := reviews.Submit(pr, input)
+					if err != nil {
+						return nil, err
+					}
+					if !status.Success {
+						return nil, &ghcli.GraphQLError{Errors: status.Errors}
+					}
+					return map[string]string{"status": "Review submitted successfully"}, nil
+				}, nil
+			},
+		},
+		"edit": {
+			Outputs: map[string]interface{}{"comment_node_id": "PRRC_example", "body": "example", "updated_at": "2006-01-02T15:04:05Z"},
+			Prepare: func(raw json.RawMessage) (plan.Action, error) {
+				var args struct {
+					CommentID string `json:"comment_id"`
+					Body      string `json:"body"`
+				}
+				if err := decodeStepArgs(raw, &args); err != nil {
+					return nil, err
+				}
+				if strings.TrimSpace(args.CommentID) == "" {
+					return nil, errors.New("comment_id is required")
+				}
+				if strings.TrimSpace(args.Body) == "" {
+					return nil, errors.New("body is required")
+				}
+				return func() (interface{}, error) {
+					return replies.Edit(comments.EditOptions{CommentID: args.CommentID, Body: args.Body})
+				}, nil
+			},
+		},
+		"delete": {
+			Outputs: map[string]interface{}{"comment_node_id": "PRRC_example", "deleted": true},
+			Prepare: func(raw json.RawMessage) (plan.Action, error) {
+				var args struct {
+					CommentID string `json:"comment_id"`
+				}
+				if err := decodeStepArgs(raw, &args); err != nil {
+					return nil, err
+				}
+				if strings.TrimSpace(args.CommentID) == "" {
+					return nil, errors.New("comment_id is required")
+				}
+				return func() (interface{}, error) {
+					return replies.Delete(args.CommentID)
+				}, nil
+			},
+		},
+	}
+}
+
+func applyThreadOperation(service *threads.Service, pr resolver.Identity, resolve bool) plan.Operation {
+	return plan.Operation{
+		Outputs: map[string]interface{}{"thread_node_id": "PRRT_example", "is_resolved": resolve},
+		Prepare: func(raw json.RawMessage) (plan.Action, error) {
+			var args struct {
+				ThreadID string `json:"thread_id"`
+			}
+			if err := decodeStepArgs(raw, &args); err != nil {
+				return nil, err
+			}
+			threadID := strings.TrimSpace(args.ThreadID)
+			if threadID == "" {
+				return nil, errors.New("thread_id is required")
+			}
+			return func() (interface{}, error) {
+				action := threads.ActionOptions{ThreadID: threadID}
+				if resolve {
+					return service.Resolve(pr, action)
+				}
+				return service.Unresolve(pr, action)
+			}, nil
+		},
+	}
+}
+
+// decodeStepArgs strictly decodes step arguments so misspelled fields fail validation.
+func decodeStepArgs(raw json.RawMessage, dst interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/plan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const applyTestPlan = `{
+  "repo": "octo/demo",
+  "pr": 7,
+  "steps": [
+    {"id": "start", "op": "start"},
+    {"id": "note", "op": "add_comment", "review_id": "$steps.start.id", "path": "main.go", "line": 3, "side": "left", "body": "nit"},
+    {"op": "submit", "review_id": "$steps.start.id", "event": "approve"}
+  ]
+}`
+
+func TestApplyRunsPlanWithReferences(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var mutations []string
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "headRefOid"):
+			return assignJSON(result, map[string]interface{}{
+				"repository": map[string]interface{}{
+					"pullRequest": map[string]interface{}{"id": "PR_node", "headRefOid": "abc123"},
+				},
+			})
+		case strings.Contains(query, "addPullRequestReview("):
+			mutations = append(mutations, "start")
+			return assignJSON(result, map[string]interface{}{
+				"addPullRequestReview": map[string]interface{}{
+					"pullRequestReview": map[string]interface{}{"id": "PRR_review", "state": "PENDING"},
+				},
+			})
+		case strings.Contains(query, "addPullRequestReviewThread"):
+			input := variables["input"].(map[string]interface{})
+			assert.Equal(t, "PRR_review", input["pullRequestReviewId"])
+			assert.Equal(t, "LEFT", input["side"])
+			mutations = append(mutations, "add_comment")
+			return assignJSON(result, map[string]interface{}{
+				"addPullRequestReviewThread": map[string]interface{}{
+					"thread": map[string]interface{}{"id": "PRRT_1", "path": "main.go", "isOutdated": false, "line": 3},
+				},
+			})
+		case strings.Contains(query, "submitPullRequestReview"):
+			input := variables["input"].(map[string]interface{})
+			assert.Equal(t, "PRR_review", input["pullRequestReviewId"])
+			assert.Equal(t, "APPROVE", input["event"])
+			mutations = append(mutations, "submit")
+			return nil
+		default:
+			return errors.New("unexpected graphql invocation")
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetIn(strings.NewReader(applyTestPlan))
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"apply", "-"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, []string{"start", "add_comment", "submit"}, mutations)
+
+	var result plan.Result
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	assert.True(t, result.OK())
+	assert.Equal(t, 3, result.Succeeded)
+	assert.Equal(t, "PRRT_1", result.Steps[1].Result.(map[string]interface{})["id"])
+}
+
+func TestApplyDryRunValidatesWithoutCallingGitHub(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+	apiClientFactory = func(host string) ghcli.API { return &commandFakeAPI{} }
+
+	doc := strings.Replace(applyTestPlan, `"event": "approve"`, `"event": "merge"`, 1)
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetIn(strings.NewReader(doc))
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"apply", "--dry-run", "-"})
+
+	err := root.Execute()
+	require.EqualError(t, err, "plan is invalid; no steps were executed")
+
+	var result plan.Result
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	assert.True(t, result.DryRun)
+	assert.Equal(t, plan.StatusValid, result.Steps[0].Status)
+	assert.Equal(t, plan.StatusValid, result.Steps[1].Status)
+	assert.Equal(t, plan.StatusInvalid, result.Steps[2].Status)
+	assert.Contains(t, result.Steps[2].Error, "invalid event")
+}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/threads"
+)
+
+// bodyScissors separates the body from the context shown in the editor.
+// Everything from this line on is discarded.
+const bodyScissors = "# ------------------------ >8 ------------------------"
+
+// bindBodyFileFlag registers --<flag>-file next to a body flag.
+func bindBodyFileFlag(cmd *cobra.Command, flag string, file *string) {
+	cmd.Flags().StringVar(file, flag+"-file", "", fmt.Sprintf("Read the --%s text from a file (\"-\" for stdin)", flag))
+}
+
+// readBody returns the text given by --<flag> or --<flag>-file, and false when
+// neither provided one. Values set on the command line win over values set by
+// the config; giving both flags on the command line is an error.
+func readBody(cmd *cobra.Command, flag, value, file string) (string, bool, error) {
+	fileFlag := flag + "-file"
+	bodyChanged, fileChanged := cmd.Flags().Changed(flag), cmd.Flags().Changed(fileFlag)
+	if bodyChanged && fileChanged {
+		return "", false, fmt.Errorf("specify only one of --%s or --%s", flag, fileFlag)
+	}
+
+	var text string
+	switch {
+	case bodyChanged:
+		text = value
+	case fileChanged || file != "":
+		data, err := readBodyFile(cmd, file)
+		if err != nil {
+			return "", false, fmt.Errorf("read --%s: %w", fileFlag, err)
+		}
+		text = data
+	case value != "":
+		text = value
+	default:
+		return "", false, nil
+	}
+	if err := ghcli.CheckBodyLength(text); err != nil {
+		return "", false, err
+	}
+	return text, true, nil
+}
+
+func readBodyFile(cmd *cobra.Command, path string) (string, error) {
+	if path == "-" {
+		data, err := io.ReadAll(cmd.InOrStdin())
+		return string(data), err
+	}
+	data, err := os.ReadFile(path)
+	return string(data), err
+}
+
+// requireBody returns the body from the flags or, in an interactive terminal,
+// from the user's editor opened on a template showing context.
+func requireBody(cmd *cobra.Command, flag, value, file string, context func() []string) (string, error) {
+	text, ok, err := readBody(cmd, flag, value, file)
+	if err != nil || ok {
+		return text, err
+	}
+	if !interactiveTerminal() {
+		return "", fmt.Errorf("--%s or --%s is required", flag, flag+"-file")
+	}
+	return editBody(context())
+}
+
+// editBody opens the editor on a template and returns the text above the
+// scissors line.
+func editBody(context []string) (string, error) {
+	file, err := os.CreateTemp("", "gh-pr-review-*.md")
+	if err != nil {
+		return "", fmt.Errorf("create editor file: %w", err)
+	}
+	path := file.Name()
+	defer os.Remove(path)
+
+	if _, err := file.WriteString(bodyTemplate(context)); err != nil {
+		_ = file.Close()
+		return "", fmt.Errorf("write editor file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("write editor file: %w", err)
+	}
+
+	editor := editorCommand()
+	args, err := splitEditorCommand(editor)
+	if err != nil {
+		return "", err
+	}
+	run := exec.Command(args[0], append(args[1:], path)...)
+	run.Stdin, run.Stdout, run.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := run.Run(); err != nil {
+		return "", fmt.Errorf("run editor %q: %w", editor, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read editor file: %w", err)
+	}
+	body := stripBodyTemplate(string(data))
+	if strings.TrimSpace(body) == "" {
+		return "", errors.New("aborting: the body is empty")
+	}
+	if err := ghcli.CheckBodyLength(body); err != nil {
+		return "", err
+	}
+	return body, nil
+}
+
+func bodyTemplate(context []string) string {
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(bodyScissors + "\n")
+	b.WriteString("# Do not modify or remove the line above.\n")
+	b.WriteString("# Everything below it is ignored.\n")
+	for _, line := range context {
+		b.WriteString(strings.TrimRight("# "+line, " ") + "\n")
+	}
+	return b.String()
+}
+
+func stripBodyTemplate(text string) string {
+	if strings.HasPrefix(text, bodyScissors) {
+		return ""
+	}
+	if i := strings.Index(text, "\n"+bodyScissors); i >= 0 {
+		text = text[:i]
+	}
+	return strings.TrimRight(text, " \t\r\n")
+}
+
+// editorCommand returns the editor configured for gh, then the usual
+// environment variables, falling back to notepad on Windows and vi elsewhere.
+func editorCommand() string {
+	for _, key := range []string{"GH_EDITOR", "VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(key)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// splitEditorCommand splits an editor command into words the way a POSIX
+// shell would for plain words and quotes, so it can run without a shell.
+// Backslashes are literal on Windows, where they separate path elements.
+func splitEditorCommand(editor string) ([]string, error) {
+	escapes := runtime.GOOS != "windows"
+	var (
+		words   []string
+		word    strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range editor {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && escapes && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("invalid editor command %q: unterminated quote or escape", editor)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	if len(words) == 0 {
+		return nil, errors.New("editor command is empty")
+	}
+	return words, nil
+}
+
+// isTerminal reports whether both stdin and stdout are attached to a terminal.
+func isTerminal() bool {
+	for _, file := range []*os.File{os.Stdin, os.Stdout} {
+		info, err := file.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// inlineCommentContext describes a new inline comment for the editor template.
+func inlineCommentContext(pr resolver.Identity, path string, line, startLine int) []string {
+	location := fmt.Sprintf("%s:%d", path, line)
+	if startLine > 0 && startLine != line {
+		location = fmt.Sprintf("%s:%d-%d", path, startLine, line)
+	}
+	return []string{
+		"",
+		fmt.Sprintf("Comment on %s in %s/%s#%d", location, pr.Owner, pr.Repo, pr.Number),
+	}
+}
+
+// threadContext describes an existing thread and its comments for the editor
+// template. Lookup failures are shown instead of the comments.
+func threadContext(pr resolver.Identity, threadID string) []string {
+	lines := []string{""}
+	detail, err := threads.NewService(apiClientFactory(pr.Host)).Show(pr, threads.ShowOptions{ThreadID: threadID})
+	if err != nil {
+		return append(lines, fmt.Sprintf("Reply to thread %s (details unavailable: %v)", threadID, err))
+	}
+
+	location := detail.Path
+	if detail.Line != nil {
+		location = fmt.Sprintf("%s:%d", detail.Path, *detail.Line)
+	} else if detail.OriginalLine != nil {
+		location = fmt.Sprintf("%s:%d (outdated)", detail.Path, *detail.OriginalLine)
+	}
+	lines = append(lines, fmt.Sprintf("Reply to %s in %s/%s#%d", location, pr.Owner, pr.Repo, pr.Number))
+	for _, comment := range detail.Comments {
+		lines = append(lines, "", fmt.Sprintf("@%s (%s):", comment.AuthorLogin, comment.CreatedAt.Format("2006-01-02 15:04")))
+		for _, text := range strings.Split(strings.TrimRight(comment.Body, "\n"), "\n") {
+			lines = append(lines, "  "+text)
+		}
+	}
+	return lines
+}
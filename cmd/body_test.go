@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/draft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewDraftBodyFromFileAndStdin(t *testing.T) {
+	useTempDraftStore(t)
+	path := filepath.Join(t.TempDir(), "body.md")
+	require.NoError(t, os.WriteFile(path, []byte("```suggestion\nreturn nil\n```\n"), 0o600))
+
+	out, err := runDraftCommand(t, "add", "--pr", "7", "-R", "octo/demo", "--path", "main.go", "--line", "3", "--body-file", path)
+	require.NoError(t, err)
+	var added draft.Draft
+	require.NoError(t, json.Unmarshal([]byte(out), &added))
+	assert.Equal(t, "```suggestion\nreturn nil\n```\n", added.Body)
+
+	root := newRootCommand()
+	stdout := &strings.Builder{}
+	root.SetOut(stdout)
+	root.SetIn(strings.NewReader("from stdin"))
+	root.SetArgs([]string{"review", "draft", "edit", "1", "--pr", "7", "-R", "octo/demo", "--body-file", "-"})
+	require.NoError(t, root.Execute())
+	require.NoError(t, json.Unmarshal([]byte(stdout.String()), &added))
+	assert.Equal(t, "from stdin", added.Body)
+
+	_, err = runDraftCommand(t, "add", "--pr", "7", "-R", "octo/demo", "--path", "main.go", "--line", "3", "--body", "x", "--body-file", path)
+	require.EqualError(t, err, "specify only one of --body or --body-file")
+
+	_, err = runDraftCommand(t, "add", "--pr", "7", "-R", "octo/demo", "--path", "main.go", "--line", "3", "--body", strings.Repeat("x", 65537))
+	require.EqualError(t, err, "body is 65537 characters; GitHub allows at most 65536")
+
+	_, err = runDraftCommand(t, "add", "--pr", "7", "-R", "octo/demo", "--path", "main.go", "--line", "3")
+	require.EqualError(t, err, "--body or --body-file is required")
+}
+
+func TestCommentsReplyRequiresBodyWithoutTerminal(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&strings.Builder{})
+	root.SetErr(&strings.Builder{})
+	root.SetArgs([]string{"comments", "reply", "--thread-id", "PRRT_thread", "--repo", "octo/demo", "7"})
+	require.EqualError(t, root.Execute(), "--body or --body-file is required")
+}
+
+func TestReviewDraftBodyFromEditor(t *testing.T) {
+	useTempDraftStore(t)
+	original := interactiveTerminal
+	interactiveTerminal = func() bool { return true }
+	t.Cleanup(func() { interactiveTerminal = original })
+
+	dir := t.TempDir()
+	template := filepath.Join(dir, "template.md")
+	editor := filepath.Join(dir, "editor.sh")
+	script := "#!/bin/sh\ncp \"$1\" " + template + "\n{ printf 'Please handle nil.\\n\\n'; cat \"$1\"; } > \"$1.new\" && mv \"$1.new\" \"$1\"\n"
+	require.NoError(t, os.WriteFile(editor, []byte(script), 0o700))
+	t.Setenv("GH_EDITOR", editor)
+
+	out, err := runDraftCommand(t, "add", "--pr", "7", "-R", "octo/demo", "--path", "main.go", "--line", "5", "--start-line", "3")
+	require.NoError(t, err)
+	var added draft.Draft
+	require.NoError(t, json.Unmarshal([]byte(out), &added))
+	assert.Equal(t, "Please handle nil.", added.Body)
+
+	shown, err := os.ReadFile(template)
+	require.NoError(t, err)
+	assert.Equal(t, "\n"+bodyScissors+"\n"+
+		"# Do not modify or remove the line above.\n"+
+		"# Everything below it is ignored.\n"+
+		"#\n"+
+		"# Comment on main.go:3-5 in octo/demo#7\n", string(shown))
+
+	require.NoError(t, os.WriteFile(editor, []byte("#!/bin/sh\ntrue\n"), 0o700))
+	_, err = runDraftCommand(t, "add", "--pr", "7", "-R", "octo/demo", "--path", "main.go", "--line", "5")
+	require.EqualError(t, err, "aborting: the body is empty")
+}
+
+func TestStripBodyTemplate(t *testing.T) {
+	assert.Equal(t, "# Heading\n\nText", stripBodyTemplate("# Heading\n\nText\n\n"+bodyScissors+"\n# ignored\n"))
+	assert.Equal(t, "", stripBodyTemplate(bodyScissors+"\n# ignored\n"))
+	assert.Equal(t, "no template", stripBodyTemplate("no template\n"))
+}
+
+func TestSplitEditorCommand(t *testing.T) {
+	words, err := splitEditorCommand(`code --wait`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"code", "--wait"}, words)
+
+	words, err = splitEditorCommand(`"/opt/My Editor/bin/edit" -n 'a b' ""`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/opt/My Editor/bin/edit", "-n", "a b", ""}, words)
+
+	_, err = splitEditorCommand(`"unterminated`)
+	require.EqualError(t, err, `invalid editor command "\"unterminated": unterminated quote or escape`)
+}
@@ -2,12 +2,14 @@ package cmd
 
 import (
 	"errors"
-	"os"
+	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/Agyn-sandbox/gh-pr-review/internal/comments"
 	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/templates"
 )
 
 type commentsOptions struct {
@@ -20,7 +22,7 @@ func newCommentsCommand() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "comments",
-		Short: "Reply to pull request review threads",
+		Short: "List, post, reply to, react on, and hide pull request comments",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := cmd.Help(); err != nil {
@@ -33,9 +35,148 @@ func newCommentsCommand() *cobra.Command {
 	cmd.PersistentFlags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
 	cmd.PersistentFlags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
 
+	cmd.AddCommand(newCommentsListCommand(opts))
+	cmd.AddCommand(newCommentsAddCommand(opts))
 	cmd.AddCommand(newCommentsReplyCommand(opts))
+	cmd.AddCommand(newCommentsReactCommand(opts))
+	cmd.AddCommand(newCommentsHideCommand(opts, false))
+	cmd.AddCommand(newCommentsHideCommand(opts, true))
 
-	return cmd
+	return configure(cmd)
+}
+
+func newCommentsListCommand(parent *commentsOptions) *cobra.Command {
+	opts := &commentsListOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list [<number> | <url>]",
+		Short: "List review thread, review body, and conversation comments",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			if opts.Repo == "" {
+				opts.Repo = parent.Repo
+			}
+			if opts.Pull == 0 {
+				opts.Pull = parent.Pull
+			}
+			return runCommentsList(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().BoolVar(&opts.Conversation, "conversation", false, "List only top-level conversation comments")
+
+	return configure(cmd)
+}
+
+type commentsListOptions struct {
+	Repo         string
+	Pull         int
+	Selector     string
+	Conversation bool
+}
+
+func runCommentsList(cmd *cobra.Command, opts *commentsListOptions) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolver.Resolve(selector, opts.Repo, defaultHost())
+	if err != nil {
+		return err
+	}
+
+	service := newCommentsService(apiClientFactory(identity.Host))
+	list, err := service.List(identity, comments.ListOptions{Conversation: opts.Conversation})
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, list)
+}
+
+func newCommentsAddCommand(parent *commentsOptions) *cobra.Command {
+	opts := &commentsAddOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "add [<number> | <url>]",
+		Short: "Post a top-level conversation comment",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			if opts.Repo == "" {
+				opts.Repo = parent.Repo
+			}
+			if opts.Pull == 0 {
+				opts.Pull = parent.Pull
+			}
+			return runCommentsAdd(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().BoolVar(&opts.Conversation, "conversation", false, "Post to the pull request conversation (required)")
+	cmd.Flags().StringVar(&opts.Body, "body", "", "Comment text (opens $EDITOR in a terminal when omitted)")
+	bindBodyFileFlag(cmd, "body", &opts.BodyFile)
+	bindTemplateFlags(cmd, &opts.templateFlags)
+	bindRedactFlag(cmd)
+
+	return configure(cmd)
+}
+
+type commentsAddOptions struct {
+	Repo         string
+	Pull         int
+	Selector     string
+	Conversation bool
+	Body         string
+	BodyFile     string
+	templateFlags
+}
+
+func runCommentsAdd(cmd *cobra.Command, opts *commentsAddOptions) error {
+	if !opts.Conversation {
+		return errors.New("comments add requires --conversation; use 'gh pr-review review --add-comment' for inline comments")
+	}
+
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolver.Resolve(selector, opts.Repo, defaultHost())
+	if err != nil {
+		return err
+	}
+	api := apiClientFactory(identity.Host)
+
+	body, ok, err := opts.renderBody(cmd, func() (templates.Data, error) {
+		return pullTemplateData(newReviewService(api), identity), nil
+	})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		body, err = requireBody(cmd, "body", opts.Body, opts.BodyFile, func() []string {
+			return []string{"", fmt.Sprintf("Comment on %s/%s#%d", identity.Owner, identity.Repo, identity.Number)}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	comment, err := newCommentsService(api).AddConversation(identity, body)
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, comment)
 }
 
 func newCommentsReplyCommand(parent *commentsOptions) *cobra.Command {
@@ -62,12 +203,15 @@ func newCommentsReplyCommand(parent *commentsOptions) *cobra.Command {
 	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
 	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
 	cmd.Flags().StringVar(&opts.ThreadID, "thread-id", "", "Review thread identifier to reply to")
-	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "GraphQL review identifier when replying inside a pending review")
-	cmd.Flags().StringVar(&opts.Body, "body", "", "Reply text")
+	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "GraphQL review identifier when replying inside a pending review, or 'session' for the review opened by review --start")
+	cmd.Flags().StringVar(&opts.Body, "body", "", "Reply text (opens $EDITOR in a terminal when omitted)")
+	bindBodyFileFlag(cmd, "body", &opts.BodyFile)
+	bindTemplateFlags(cmd, &opts.templateFlags)
+	bindRedactFlag(cmd)
+	cmd.Flags().BoolVar(&opts.Resolve, "resolve", false, "Resolve the thread after posting the reply")
 	_ = cmd.MarkFlagRequired("thread-id")
-	_ = cmd.MarkFlagRequired("body")
 
-	return cmd
+	return configure(cmd)
 }
 
 type commentsReplyOptions struct {
@@ -77,27 +221,60 @@ type commentsReplyOptions struct {
 	ThreadID string
 	ReviewID string
 	Body     string
+	BodyFile string
+	Resolve  bool
+	templateFlags
 }
 
 func runCommentsReply(cmd *cobra.Command, opts *commentsReplyOptions) error {
+	if opts.Resolve && strings.TrimSpace(opts.ReviewID) != "" {
+		return errors.New(errResolvePendingReply)
+	}
 	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
 	if err != nil {
 		return err
 	}
 
-	hostEnv := os.Getenv("GH_HOST")
-	identity, err := resolver.Resolve(selector, opts.Repo, hostEnv)
+	host := defaultHost()
+	identity, err := resolver.Resolve(selector, opts.Repo, host)
+	if err != nil {
+		return err
+	}
+
+	body, ok, err := opts.renderBody(cmd, func() (templates.Data, error) {
+		return threadTemplateData(identity, opts.ThreadID)
+	})
 	if err != nil {
 		return err
 	}
+	if !ok {
+		body, err = requireBody(cmd, "body", opts.Body, opts.BodyFile, func() []string {
+			return threadContext(identity, opts.ThreadID)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	opts.Body = body
 
-	service := comments.NewService(apiClientFactory(identity.Host))
+	reviewID, err := expandSessionReviewID(newReviewService(apiClientFactory(identity.Host)), identity, opts.ReviewID)
+	if err != nil {
+		return err
+	}
+	opts.ReviewID = reviewID
 
-	reply, err := service.Reply(identity, comments.ReplyOptions{
+	replyOpts := comments.ReplyOptions{
 		ThreadID: opts.ThreadID,
 		ReviewID: opts.ReviewID,
 		Body:     opts.Body,
-	})
+	}
+	if opts.Resolve {
+		return runReplyAndResolve(cmd, identity, replyOpts)
+	}
+
+	service := newCommentsService(apiClientFactory(identity.Host))
+
+	reply, err := service.Reply(identity, replyOpts)
 	if err != nil {
 		return err
 	}
@@ -106,3 +283,133 @@ func runCommentsReply(cmd *cobra.Command, opts *commentsReplyOptions) error {
 	}
 	return encodeJSON(cmd, map[string]string{"comment_node_id": reply.CommentNodeID})
 }
+
+func newCommentsReactCommand(parent *commentsOptions) *cobra.Command {
+	opts := &commentsReactOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "react [<number> | <url>]",
+		Short: "Add or remove a reaction on a review comment",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			if opts.Repo == "" {
+				opts.Repo = parent.Repo
+			}
+			if opts.Pull == 0 {
+				opts.Pull = parent.Pull
+			}
+			return runCommentsReact(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVar(&opts.CommentID, "comment-id", "", "GraphQL node ID of the comment (PRRC_...)")
+	cmd.Flags().StringVar(&opts.Reaction, "reaction", "", "Reaction: "+strings.Join(comments.Reactions, ", "))
+	cmd.Flags().BoolVar(&opts.Remove, "remove", false, "Remove your reaction instead of adding it")
+	_ = cmd.MarkFlagRequired("comment-id")
+	_ = cmd.MarkFlagRequired("reaction")
+
+	return configure(cmd)
+}
+
+type commentsReactOptions struct {
+	Repo      string
+	Pull      int
+	Selector  string
+	CommentID string
+	Reaction  string
+	Remove    bool
+}
+
+func runCommentsReact(cmd *cobra.Command, opts *commentsReactOptions) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolver.Resolve(selector, opts.Repo, defaultHost())
+	if err != nil {
+		return err
+	}
+
+	service := newCommentsService(apiClientFactory(identity.Host))
+	result, err := service.React(comments.ReactOptions{CommentID: opts.CommentID, Reaction: opts.Reaction, Remove: opts.Remove})
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, result)
+}
+
+func newCommentsHideCommand(parent *commentsOptions, unhide bool) *cobra.Command {
+	opts := &commentsHideOptions{Unhide: unhide}
+
+	cmd := &cobra.Command{
+		Use:   "hide [<number> | <url>]",
+		Short: "Minimize a review comment",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			if opts.Repo == "" {
+				opts.Repo = parent.Repo
+			}
+			if opts.Pull == 0 {
+				opts.Pull = parent.Pull
+			}
+			return runCommentsHide(cmd, opts)
+		},
+	}
+	if unhide {
+		cmd.Use = "unhide [<number> | <url>]"
+		cmd.Short = "Restore a minimized review comment"
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVar(&opts.CommentID, "comment-id", "", "GraphQL node ID of the comment (PRRC_...)")
+	_ = cmd.MarkFlagRequired("comment-id")
+	if !unhide {
+		cmd.Flags().StringVar(&opts.Reason, "reason", "", "Reason: "+strings.Join(comments.HideReasons, ", "))
+		_ = cmd.MarkFlagRequired("reason")
+	}
+
+	return configure(cmd)
+}
+
+type commentsHideOptions struct {
+	Repo      string
+	Pull      int
+	Selector  string
+	CommentID string
+	Reason    string
+	Unhide    bool
+}
+
+func runCommentsHide(cmd *cobra.Command, opts *commentsHideOptions) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolver.Resolve(selector, opts.Repo, defaultHost())
+	if err != nil {
+		return err
+	}
+
+	service := newCommentsService(apiClientFactory(identity.Host))
+	var result comments.Visibility
+	if opts.Unhide {
+		result, err = service.Unhide(opts.CommentID)
+	} else {
+		result, err = service.Hide(comments.HideOptions{CommentID: opts.CommentID, Reason: opts.Reason})
+	}
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, result)
+}
@@ -8,7 +8,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/Agyn-sandbox/gh-pr-review/internal/draft"
 	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/session"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/stats"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -204,6 +207,63 @@ func TestCommentsReplyCommandWithoutReviewID(t *testing.T) {
 	assert.Equal(t, "PRRC_reply", payload["comment_node_id"])
 }
 
+func TestCommentsReactCommand(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Contains(t, query, "RemoveReaction")
+		input := variables["input"].(map[string]interface{})
+		require.Equal(t, "PRRC_comment", input["subjectId"])
+		require.Equal(t, "EYES", input["content"])
+		return assignJSON(result, obj{"removeReaction": obj{"subject": obj{"id": "PRRC_comment", "reactionGroups": []obj{}}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "react", "--comment-id", "PRRC_comment", "--reaction", "eyes", "--remove", "--repo", "octo/demo", "7"})
+	require.NoError(t, root.Execute())
+
+	assert.JSONEq(t, `{"comment_node_id":"PRRC_comment","reaction":"EYES","count":0,"viewer_has_reacted":false}`, stdout.String())
+}
+
+func TestCommentsHideCommand(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		input := variables["input"].(map[string]interface{})
+		require.Equal(t, "PRRC_comment", input["subjectId"])
+		if strings.Contains(query, "UnminimizeComment") {
+			return assignJSON(result, obj{"unminimizeComment": obj{"unminimizedComment": obj{"isMinimized": false}}})
+		}
+		require.Equal(t, "OUTDATED", input["classifier"])
+		return assignJSON(result, obj{"minimizeComment": obj{"minimizedComment": obj{"isMinimized": true, "minimizedReason": "outdated"}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	run := func(args ...string) string {
+		root := newRootCommand()
+		stdout := &bytes.Buffer{}
+		root.SetOut(stdout)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(args)
+		require.NoError(t, root.Execute())
+		return stdout.String()
+	}
+
+	hidden := run("comments", "hide", "--comment-id", "PRRC_comment", "--reason", "outdated", "--repo", "octo/demo", "7")
+	assert.JSONEq(t, `{"comment_node_id":"PRRC_comment","is_minimized":true,"minimized_reason":"OUTDATED"}`, hidden)
+
+	shown := run("comments", "unhide", "--comment-id", "PRRC_comment", "--repo", "octo/demo", "7")
+	assert.JSONEq(t, `{"comment_node_id":"PRRC_comment","is_minimized":false}`, shown)
+}
+
 func assignJSON(result interface{}, payload interface{}) error {
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -215,5 +275,84 @@ func assignJSON(result interface{}, payload interface{}) error {
 func TestMain(m *testing.M) {
 	// Ensure tests don't inherit GH_HOST requirements.
 	_ = os.Unsetenv("GH_HOST")
-	os.Exit(m.Run())
+	// Never open an editor for missing bodies.
+	interactiveTerminal = func() bool { return false }
+	// Ignore the user's config files.
+	configPathsFactory = func() ([]string, error) { return nil, nil }
+	// Keep review sessions out of the user's config directory.
+	sessionDir, err := os.MkdirTemp("", "gh-pr-review-sessions-")
+	if err != nil {
+		panic(err)
+	}
+	sessionStoreFactory = func() (*session.Store, error) { return session.NewStore(sessionDir), nil }
+	draftDir, err := os.MkdirTemp("", "gh-pr-review-drafts-")
+	if err != nil {
+		panic(err)
+	}
+	draftStoreFactory = func() (*draft.Store, error) { return draft.NewStore(draftDir), nil }
+	statsDir, err := os.MkdirTemp("", "gh-pr-review-stats-")
+	if err != nil {
+		panic(err)
+	}
+	statsCacheFactory = func() (*stats.Cache, error) { return stats.NewCache(statsDir), nil }
+	code := m.Run()
+	_ = os.RemoveAll(sessionDir)
+	_ = os.RemoveAll(draftDir)
+	_ = os.RemoveAll(statsDir)
+	os.Exit(code)
+}
+
+func TestCommentsAddAndListConversation(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var posted []string
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "PullRequestNode"):
+			return assignJSON(result, obj{"repository": obj{"pullRequest": obj{"id": "PR_7"}}})
+		case strings.Contains(query, "PullRequestAuthor"):
+			return assignJSON(result, obj{"repository": obj{"pullRequest": obj{"author": obj{"login": "alice"}}}})
+		case strings.Contains(query, "AddComment"):
+			input := variables["input"].(map[string]interface{})
+			posted = append(posted, input["body"].(string))
+			return assignJSON(result, obj{"addComment": obj{"commentEdge": obj{"node": obj{"id": "IC_9", "body": input["body"], "createdAt": "2025-12-03T11:00:00Z", "author": obj{"login": "octocat"}}}}})
+		case strings.Contains(query, "query ReportConversation"):
+			return assignJSON(result, obj{"repository": obj{"pullRequest": obj{"comments": obj{
+				"nodes":    []obj{{"id": "IC_9", "body": "LGTM", "createdAt": "2025-12-03T11:00:00Z", "author": obj{"login": "octocat"}}},
+				"pageInfo": obj{"hasNextPage": false},
+			}}}})
+		default:
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	run := func(args ...string) (string, error) {
+		root := newRootCommand()
+		stdout := &bytes.Buffer{}
+		root.SetOut(stdout)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(args)
+		err := root.Execute()
+		return stdout.String(), err
+	}
+
+	out, err := run("comments", "add", "--conversation", "--body", "LGTM", "--repo", "octo/demo", "7")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"kind":"conversation","comment_node_id":"IC_9","author_login":"octocat","body":"LGTM","created_at":"2025-12-03T11:00:00Z"}`, out)
+
+	useConfigFiles(t, "templates:\n  thanks: 'Thanks @{{.Author}}!'\n")
+	_, err = run("comments", "add", "--conversation", "--template-name", "thanks", "--repo", "octo/demo", "7")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"LGTM", "Thanks @alice!"}, posted)
+
+	_, err = run("comments", "add", "--body", "LGTM", "--repo", "octo/demo", "7")
+	require.EqualError(t, err, "comments add requires --conversation; use 'gh pr-review review --add-comment' for inline comments")
+
+	out, err = run("comments", "list", "--conversation", "--repo", "octo/demo", "7")
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"kind":"conversation","comment_node_id":"IC_9","author_login":"octocat","body":"LGTM","created_at":"2025-12-03T11:00:00Z"}]`, out)
 }
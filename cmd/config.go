@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/config"
+)
+
+// annotationNoRepoDefault marks commands whose --repo narrows a cross-repo
+// search, so the configured repo must not be applied to it.
+const annotationNoRepoDefault = "gh-pr-review/no-repo-default"
+
+// activeSettings are the effective config and environment settings of the
+// running command. They are replaced every time a configured command runs.
+var activeSettings config.Settings
+
+// configure loads the config files before cmd runs and applies them to the
+// flags the user did not set. Flags take precedence over the environment,
+// which takes precedence over the repo config and then the user config.
+func configure(cmd *cobra.Command) *cobra.Command {
+	next := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := applyConfig(cmd); err != nil {
+			return err
+		}
+		if next != nil {
+			return next(cmd, args)
+		}
+		return nil
+	}
+	return cmd
+}
+
+func applyConfig(cmd *cobra.Command) error {
+	settings, err := loadSettings(cmd)
+	if err != nil {
+		return err
+	}
+	activeSettings = settings
+	if activeScanner, err = bodyScanner(settings.Scan); err != nil {
+		return err
+	}
+
+	if settings.Repo != "" && cmd.Annotations[annotationNoRepoDefault] == "" {
+		if err := setFlagDefault(cmd, "repo", settings.Repo); err != nil {
+			return err
+		}
+	}
+
+	path := commandPath(cmd)
+	for name, value := range settings.Defaults[path] {
+		if cmd.Flags().Lookup(name) == nil {
+			return fmt.Errorf("config defaults for %q: unknown flag --%s", path, name)
+		}
+		if err := setFlagDefault(cmd, name, string(value)); err != nil {
+			return fmt.Errorf("config defaults for %q: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// loadSettings merges the user config, repo config, selected profile,
+// environment, and the --output flag.
+func loadSettings(cmd *cobra.Command) (config.Settings, error) {
+	profile := os.Getenv(config.EnvProfile)
+	if flag := cmd.Flags().Lookup("profile"); flag != nil && flag.Changed {
+		profile = flag.Value.String()
+	}
+
+	paths, err := configPathsFactory()
+	if err != nil {
+		return config.Settings{}, err
+	}
+	files := make([]*config.File, 0, len(paths))
+	for _, path := range paths {
+		file, err := config.ReadFile(path)
+		if err != nil {
+			return config.Settings{}, err
+		}
+		files = append(files, file)
+	}
+
+	settings, err := config.Resolve(files, strings.TrimSpace(profile))
+	if err != nil {
+		return config.Settings{}, err
+	}
+	settings = settings.Merge(config.Env(os.Getenv))
+	if flag := cmd.Flags().Lookup("output"); flag != nil && flag.Changed {
+		settings.Output = strings.ToLower(strings.TrimSpace(flag.Value.String()))
+	}
+	if flag := cmd.Flags().Lookup("redact"); flag != nil && flag.Changed {
+		redact := flag.Value.String() == "true"
+		settings.Scan.Redact = &redact
+		if redact {
+			settings.Scan.Enabled = &redact
+		}
+	}
+	if err := settings.Validate(); err != nil {
+		return config.Settings{}, err
+	}
+	return settings, nil
+}
+
+// configPaths returns the user config and, when present, the repo config found
+// from the working directory.
+func configPaths() ([]string, error) {
+	user, err := config.UserPath()
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{user}
+	if dir, err := os.Getwd(); err == nil {
+		if repo := config.RepoPath(dir); repo != "" {
+			paths = append(paths, repo)
+		}
+	}
+	return paths, nil
+}
+
+// setFlagDefault sets a flag the user left unset, here or on a parent command.
+func setFlagDefault(cmd *cobra.Command, name, value string) error {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil || flag.Changed {
+		return nil
+	}
+	for parent := cmd.Parent(); parent != nil; parent = parent.Parent() {
+		if inherited := parent.PersistentFlags().Lookup(name); inherited != nil && inherited.Changed {
+			return nil
+		}
+	}
+	if err := setFlag(flag, value); err != nil {
+		return fmt.Errorf("invalid value %q for --%s: %w", value, name, err)
+	}
+	return nil
+}
+
+// setFlag assigns value like the command line would without marking the flag
+// as changed, so later config layers and the user's flags still win.
+func setFlag(flag *pflag.Flag, value string) error {
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		return slice.Replace(strings.Split(value, ","))
+	}
+	return flag.Value.Set(value)
+}
+
+// commandPath is the command's path below the root, such as "review view".
+func commandPath(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+// defaultHost is the GitHub host for numeric selectors.
+func defaultHost() string {
+	if host := os.Getenv(config.EnvHost); host != "" {
+		return host
+	}
+	return activeSettings.Host
+}
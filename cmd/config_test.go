@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/config"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/draft"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useConfigFiles(t *testing.T, contents ...string) {
+	t.Helper()
+	dir := t.TempDir()
+	paths := make([]string, 0, len(contents))
+	for i, content := range contents {
+		path := filepath.Join(dir, "config"+string(rune('a'+i))+".yml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		paths = append(paths, path)
+	}
+	original := configPathsFactory
+	configPathsFactory = func() ([]string, error) { return paths, nil }
+	t.Cleanup(func() {
+		configPathsFactory = original
+		activeSettings = config.Settings{}
+	})
+}
+
+const configTestUser = `
+repo: octo/user
+defaults:
+  review draft add:
+    side: LEFT
+    body: from user config
+profiles:
+  work:
+    repo: acme/work
+`
+
+const configTestRepo = `
+defaults:
+  review draft add:
+    body: from repo config
+`
+
+func addConfiguredDraft(t *testing.T, args ...string) draft.Draft {
+	t.Helper()
+	out, err := runDraftCommand(t, append([]string{"add", "--pr", "7", "--path", "main.go", "--line", "3"}, args...)...)
+	require.NoError(t, err)
+	var added draft.Draft
+	require.NoError(t, json.Unmarshal([]byte(out), &added))
+	return added
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	store := useTempDraftStore(t)
+	useConfigFiles(t, configTestUser, configTestRepo)
+	t.Setenv(config.EnvRepo, "")
+	t.Setenv(config.EnvProfile, "")
+
+	added := addConfiguredDraft(t)
+	assert.Equal(t, "LEFT", added.Side)
+	assert.Equal(t, "from repo config", added.Body)
+	assertDraftCount(t, store, "octo", "user", 1)
+
+	added = addConfiguredDraft(t, "--side", "right", "--body", "from flag")
+	assert.Equal(t, "RIGHT", added.Side)
+	assert.Equal(t, "from flag", added.Body)
+
+	t.Setenv(config.EnvRepo, "env/repo")
+	addConfiguredDraft(t)
+	assertDraftCount(t, store, "env", "repo", 1)
+
+	addConfiguredDraft(t, "-R", "flag/repo")
+	assertDraftCount(t, store, "flag", "repo", 1)
+}
+
+func TestConfigProfiles(t *testing.T) {
+	store := useTempDraftStore(t)
+	useConfigFiles(t, configTestUser)
+	t.Setenv(config.EnvRepo, "")
+
+	addConfiguredDraft(t, "--profile", "work")
+	assertDraftCount(t, store, "acme", "work", 1)
+
+	t.Setenv(config.EnvProfile, "work")
+	addConfiguredDraft(t)
+	assertDraftCount(t, store, "acme", "work", 2)
+
+	_, err := runDraftCommand(t, "list", "--pr", "7", "--profile", "home")
+	require.EqualError(t, err, `unknown profile "home" (defined: work)`)
+}
+
+func TestConfigOutputAndInvalidDefaults(t *testing.T) {
+	useTempDraftStore(t)
+	useConfigFiles(t, "repo: octo/demo\noutput: pretty\ndefaults:\n  review draft list:\n    tail: 3\n")
+	t.Setenv(config.EnvOutput, "")
+
+	_, err := runDraftCommand(t, "list", "--pr", "7")
+	require.EqualError(t, err, `config defaults for "review draft list": unknown flag --tail`)
+
+	useConfigFiles(t, "repo: octo/demo\noutput: pretty\n")
+	_, err = runDraftCommand(t, "add", "--pr", "7", "--path", "main.go", "--line", "3", "--body", "x")
+	require.NoError(t, err)
+	out, err := runDraftCommand(t, "list", "--pr", "7")
+	require.NoError(t, err)
+	assert.Contains(t, out, "[\n  {\n    \"id\": \"1\",")
+
+	out, err = runDraftCommand(t, "list", "--pr", "7", "--output", "json")
+	require.NoError(t, err)
+	assert.Contains(t, out, `[{"id":"1",`)
+}
+
+func assertDraftCount(t *testing.T, store *draft.Store, owner, repo string, want int) {
+	t.Helper()
+	set, err := store.Load(resolver.Identity{Host: "github.com", Owner: owner, Repo: repo, Number: 7})
+	require.NoError(t, err)
+	assert.Len(t, set.Drafts, want)
+}
@@ -1,7 +1,45 @@
 package cmd
 
-import "github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+import (
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/draft"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/seen"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/session"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/stats"
+)
 
 var apiClientFactory = func(host string) ghcli.API {
-	return &ghcli.Client{Host: host}
+	return &ghcli.Client{
+		Host: host,
+		Retry: ghcli.RetryPolicy{
+			Attempts: activeSettings.Retry.Attempts,
+			Delay:    time.Duration(activeSettings.Retry.Delay),
+		},
+	}
+}
+
+var seenStoreFactory = func() (*seen.Store, error) {
+	return seen.DefaultStore()
+}
+
+var sessionStoreFactory = func() (*session.Store, error) {
+	return session.DefaultStore()
+}
+
+var draftStoreFactory = func() (*draft.Store, error) {
+	return draft.DefaultStore()
+}
+
+var statsCacheFactory = func() (*stats.Cache, error) {
+	return stats.DefaultCache()
+}
+
+var configPathsFactory = func() ([]string, error) {
+	return configPaths()
+}
+
+var interactiveTerminal = func() bool {
+	return isTerminal()
 }
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/inbox"
+)
+
+func newInboxCommand() *cobra.Command {
+	opts := &inboxOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "inbox",
+		Short: "List open pull requests that need your attention across repositories",
+		Args:  cobra.NoArgs,
+		Annotations: map[string]string{
+			annotationNoRepoDefault: "true",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInbox(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.Orgs, "org", nil, "Only search these organizations (comma-separated or repeatable)")
+	cmd.Flags().StringSliceVarP(&opts.Repos, "repo", "R", nil, "Only search these repositories in 'owner/repo' format (comma-separated or repeatable)")
+	cmd.Flags().IntVar(&opts.Limit, "limit", inbox.DefaultLimit, fmt.Sprintf("Maximum pull requests fetched per search (1-%d)", inbox.MaxLimit))
+	cmd.Flags().BoolVar(&opts.Table, "table", false, "Print a table instead of JSON")
+
+	return configure(cmd)
+}
+
+type inboxOptions struct {
+	Orgs  []string
+	Repos []string
+	Limit int
+	Table bool
+}
+
+func runInbox(cmd *cobra.Command, opts *inboxOptions) error {
+	service := inbox.NewService(apiClientFactory(defaultHost()))
+	items, err := service.Fetch(inbox.Options{Orgs: opts.Orgs, Repos: opts.Repos, Limit: opts.Limit})
+	if err != nil {
+		return err
+	}
+	if opts.Table {
+		return writeInboxTable(cmd, items)
+	}
+	return encodeJSON(cmd, items)
+}
+
+// writeInboxTable prints one row per pull request, stalest first.
+func writeInboxTable(cmd *cobra.Command, items []inbox.Item) error {
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "UPDATED\tPULL REQUEST\tREASONS\tUNRESOLVED\tNEW\tREVIEWERS\tTITLE")
+	for _, item := range items {
+		reviewers := make([]string, len(item.Reviewers))
+		for i, reviewer := range item.Reviewers {
+			reviewers[i] = reviewer.Login + ":" + string(reviewer.State)
+		}
+		fmt.Fprintf(w, "%s\t%s#%d\t%s\t%d\t%d\t%s\t%s\n",
+			item.UpdatedAt, item.Repo, item.Number, strings.Join(item.Reasons, ","),
+			item.UnresolvedThreads, item.NewReplies, strings.Join(reviewers, " "), item.Title)
+	}
+	return w.Flush()
+}
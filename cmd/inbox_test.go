@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+)
+
+func TestInboxCommandTable(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var queries []string
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		search := variables["query"].(string)
+		queries = append(queries, search)
+		nodes := []obj{}
+		if strings.Contains(search, "review-requested:@me") {
+			nodes = append(nodes, obj{
+				"number": 7, "title": "Add retries", "url": "https://github.com/octo/demo/pull/7",
+				"updatedAt": "2025-12-03T10:00:00Z", "headRefOid": "head",
+				"repository": obj{"nameWithOwner": "octo/demo"}, "author": obj{"login": "octocat"},
+				"reviews": obj{"nodes": []obj{
+					{"id": "PRR_1", "state": "CHANGES_REQUESTED", "submittedAt": "2025-12-02T10:00:00Z", "author": obj{"login": "alice"}},
+				}},
+				"reviewThreads": obj{"nodes": []obj{{"isResolved": false, "comments": obj{"nodes": []obj{}}}}},
+			})
+		}
+		return assignJSON(result, obj{"viewer": obj{"login": "me"}, "search": obj{"nodes": nodes}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	// A configured repo must not narrow the cross-repo search.
+	useConfigFiles(t, "repo: octo/other\n")
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"inbox", "--org", "octo", "--table"})
+	require.NoError(t, root.Execute())
+
+	for _, query := range queries {
+		assert.NotContains(t, query, "repo:")
+	}
+	assert.Equal(t, "UPDATED               PULL REQUEST  REASONS           UNRESOLVED  NEW  REVIEWERS                TITLE\n"+
+		"2025-12-03T10:00:00Z  octo/demo#7   review_requested  1           0    alice:CHANGES_REQUESTED  Add retries\n", stdout.String())
+}
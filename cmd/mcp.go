@@ -0,0 +1,555 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/comments"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/mcp"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/report"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/threads"
+)
+
+// mcpServerVersion is reported to MCP clients during initialization.
+var mcpServerVersion = "dev"
+
+func newMCPCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Serve review tools over the Model Context Protocol (stdio)",
+		Long: "Speak MCP JSON-RPC over stdin/stdout, exposing review view, thread listing, " +
+			"pending review, reply, and resolve operations as tools.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parent := cmd.Context()
+			if parent == nil {
+				parent = context.Background()
+			}
+			ctx, stop := signal.NotifyContext(parent, os.Interrupt)
+			defer stop()
+
+			err := newMCPServer().Serve(ctx, cmd.InOrStdin(), cmd.OutOrStdout())
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		},
+	}
+	bindRedactFlag(cmd)
+
+	return configure(cmd)
+}
+
+func newMCPServer() *mcp.Server {
+	server := mcp.NewServer("gh-pr-review", mcpServerVersion)
+
+	server.AddTool(mcpTool("review_view",
+		"Aggregate reviews, inline comments, and replies for a pull request (same output as `review view`).",
+		mcpObjectSchema(mcpPullProperties(map[string]interface{}{
+			"reviewer":                mcpString("Only include reviews by this login"),
+			"states":                  mcpStateList(),
+			"unresolved":              mcpBool("Only include unresolved threads"),
+			"not_outdated":            mcpBool("Exclude outdated threads"),
+			"tail":                    mcpNonNegativeInt("Keep only the last N replies per thread (0 = all)"),
+			"include_comment_node_id": mcpBool("Include GraphQL comment node ids"),
+			"include_pull_request":    mcpBool("Include pull request metadata"),
+			"include_conversation":    mcpBool("Include top-level pull request conversation comments"),
+			"include_reactions":       mcpBool("Include reaction counts on comments and replies"),
+			"hide_minimized":          mcpBool("Drop minimized replies and threads whose parent comment is minimized"),
+		})),
+		runMCPReviewView))
+
+	server.AddTool(mcpTool("threads_list",
+		"List review threads for a pull request (same output as `threads list`, wrapped in {\"threads\": [...]}).",
+		mcpObjectSchema(mcpPullProperties(map[string]interface{}{
+			"unresolved":  mcpBool("Only unresolved threads"),
+			"mine":        mcpBool("Only threads involving or resolvable by the viewer"),
+			"paths":       map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "Only threads whose file path matches one of these globs (** spans directories)"},
+			"author":      mcpString("Only threads started by this login"),
+			"participant": mcpString("Only threads where this login commented"),
+			"outdated":    mcpBool("true = only outdated threads, false = exclude outdated threads"),
+			"since":       mcpString("Only threads updated at or after this time (RFC3339, YYYY-MM-DD, or age like 7d)"),
+			"before":      mcpString("Only threads updated before this time (RFC3339, YYYY-MM-DD, or age like 7d)"),
+			"resolved_by": mcpString("Only threads resolved by this login"),
+		})),
+		runMCPThreadsList))
+
+	server.AddTool(mcpTool("review_start",
+		"Open a pending review (same output as `review --start`).",
+		mcpObjectSchema(mcpPullProperties(map[string]interface{}{
+			"commit": mcpString("Commit SHA to review (defaults to the current head)"),
+		})),
+		runMCPReviewStart))
+
+	server.AddTool(mcpTool("review_add_comment",
+		"Add an inline comment thread to a pending review (same output as `review --add-comment`).",
+		mcpObjectSchema(mcpPullProperties(map[string]interface{}{
+			"review_id":  mcpReviewID(),
+			"path":       mcpString("File path for the comment"),
+			"line":       map[string]interface{}{"type": "integer", "minimum": 1, "description": "Line number for the comment"},
+			"side":       mcpSide("Diff side for the comment", "RIGHT"),
+			"start_line": map[string]interface{}{"type": "integer", "minimum": 1, "description": "Start line for multi-line comments"},
+			"start_side": mcpSide("Start side for multi-line comments", ""),
+			"body":       mcpNonEmptyString("Comment body"),
+		}), "review_id", "path", "line", "body"),
+		runMCPReviewAddComment))
+
+	server.AddTool(mcpTool("review_submit",
+		"Submit a pending review (same output as `review --submit`).",
+		mcpObjectSchema(mcpPullProperties(map[string]interface{}{
+			"review_id": mcpReviewID(),
+			"event": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"APPROVE", "COMMENT", "REQUEST_CHANGES", "approve", "comment", "request_changes"},
+				"default":     "COMMENT",
+				"description": "Review submission event",
+			},
+			"body": mcpString("Review summary body"),
+		}), "review_id"),
+		runMCPReviewSubmit))
+
+	server.AddTool(mcpTool("comments_reply",
+		"Reply to a review thread, optionally resolving it (same output as `comments reply`).",
+		mcpObjectSchema(mcpPullProperties(map[string]interface{}{
+			"thread_id": mcpThreadID(),
+			"review_id": mcpReviewID(),
+			"body":      mcpNonEmptyString("Reply body"),
+			"resolve":   mcpBool("Resolve the thread after the reply is posted"),
+		}), "thread_id", "body"),
+		runMCPCommentsReply))
+
+	server.AddTool(mcpTool("threads_resolve",
+		"Resolve a review thread (same output as `threads resolve`).",
+		mcpObjectSchema(mcpPullProperties(map[string]interface{}{
+			"thread_id": mcpThreadID(),
+		}), "thread_id"),
+		runMCPThreadsMutation(true)))
+
+	server.AddTool(mcpTool("threads_unresolve",
+		"Unresolve a review thread (same output as `threads unresolve`).",
+		mcpObjectSchema(mcpPullProperties(map[string]interface{}{
+			"thread_id": mcpThreadID(),
+		}), "thread_id"),
+		runMCPThreadsMutation(false)))
+
+	return server
+}
+
+// mcpTool adapts a handler to mcp.Tool, converting gh api failures into tool
+// errors that carry the HTTP status or GraphQL error entries.
+func mcpTool(name, description string, schema map[string]interface{}, handler func(json.RawMessage) (interface{}, error)) mcp.Tool {
+	return mcp.Tool{
+		Name:        name,
+		Description: description,
+		InputSchema: schema,
+		Handler: func(_ context.Context, arguments json.RawMessage) (interface{}, error) {
+			result, err := handler(arguments)
+			if err != nil {
+				return nil, mcpToolError(err)
+			}
+			return result, nil
+		},
+	}
+}
+
+func mcpToolError(err error) error {
+	var toolErr *mcp.ToolError
+	if errors.As(err, &toolErr) {
+		return err
+	}
+	details := map[string]interface{}{}
+	var apiErr *ghcli.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode > 0 {
+		details["status_code"] = apiErr.StatusCode
+	}
+	var gqlErr *ghcli.GraphQLError
+	if errors.As(err, &gqlErr) && len(gqlErr.Errors) > 0 {
+		details["errors"] = gqlErr.Errors
+	}
+	return &mcp.ToolError{Message: err.Error(), Details: details}
+}
+
+// mcpPullArgs selects the pull request, mirroring --repo, --pr, and the positional selector.
+type mcpPullArgs struct {
+	Repo     string `json:"repo"`
+	PR       int    `json:"pr"`
+	Selector string `json:"selector"`
+}
+
+func (a mcpPullArgs) identity() (resolver.Identity, error) {
+	selector, err := resolver.NormalizeSelector(a.Selector, a.PR)
+	if err != nil {
+		return resolver.Identity{}, err
+	}
+	return resolver.Resolve(selector, a.Repo, defaultHost())
+}
+
+type mcpReviewViewArgs struct {
+	mcpPullArgs
+	Reviewer             string   `json:"reviewer"`
+	States               []string `json:"states"`
+	Unresolved           bool     `json:"unresolved"`
+	NotOutdated          bool     `json:"not_outdated"`
+	Tail                 int      `json:"tail"`
+	IncludeCommentNodeID bool     `json:"include_comment_node_id"`
+	IncludePullRequest   bool     `json:"include_pull_request"`
+	IncludeConversation  bool     `json:"include_conversation"`
+	IncludeReactions     bool     `json:"include_reactions"`
+	HideMinimized        bool     `json:"hide_minimized"`
+}
+
+func runMCPReviewView(arguments json.RawMessage) (interface{}, error) {
+	var args mcpReviewViewArgs
+	if err := mcp.DecodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	opts := &reviewViewOptions{
+		Reviewer:             args.Reviewer,
+		States:               args.States,
+		Unresolved:           args.Unresolved,
+		NotOutdated:          args.NotOutdated,
+		TailReplies:          args.Tail,
+		IncludeCommentNodeID: args.IncludeCommentNodeID,
+		IncludePullRequest:   args.IncludePullRequest,
+		IncludeConversation:  args.IncludeConversation,
+		IncludeReactions:     args.IncludeReactions,
+		HideMinimized:        args.HideMinimized,
+	}
+	reportOpts, err := opts.reportOptions()
+	if err != nil {
+		return nil, err
+	}
+	identity, err := args.identity()
+	if err != nil {
+		return nil, err
+	}
+	return report.NewService(apiClientFactory(identity.Host)).Fetch(identity, reportOpts)
+}
+
+type mcpThreadsListArgs struct {
+	mcpPullArgs
+	Unresolved  bool     `json:"unresolved"`
+	Mine        bool     `json:"mine"`
+	Paths       []string `json:"paths"`
+	Author      string   `json:"author"`
+	Participant string   `json:"participant"`
+	Outdated    *bool    `json:"outdated"`
+	Since       string   `json:"since"`
+	Before      string   `json:"before"`
+	ResolvedBy  string   `json:"resolved_by"`
+}
+
+func runMCPThreadsList(arguments json.RawMessage) (interface{}, error) {
+	var args mcpThreadsListArgs
+	if err := mcp.DecodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	filters := threadFilterFlags{
+		MineOnly:    args.Mine,
+		Paths:       args.Paths,
+		Author:      args.Author,
+		Participant: args.Participant,
+		Since:       args.Since,
+		Before:      args.Before,
+		ResolvedBy:  args.ResolvedBy,
+	}
+	if args.Outdated != nil {
+		filters.Outdated = *args.Outdated
+		filters.NotOutdated = !*args.Outdated
+	}
+	listOpts, err := filters.listOptions()
+	if err != nil {
+		return nil, err
+	}
+	listOpts.OnlyUnresolved = args.Unresolved
+
+	identity, err := args.identity()
+	if err != nil {
+		return nil, err
+	}
+	list, err := threads.NewService(apiClientFactory(identity.Host)).List(identity, listOpts)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = []threads.Thread{}
+	}
+	return map[string]interface{}{"threads": list}, nil
+}
+
+type mcpReviewStartArgs struct {
+	mcpPullArgs
+	Commit string `json:"commit"`
+}
+
+func runMCPReviewStart(arguments json.RawMessage) (interface{}, error) {
+	var args mcpReviewStartArgs
+	if err := mcp.DecodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	identity, err := args.identity()
+	if err != nil {
+		return nil, err
+	}
+	state, err := newReviewService(apiClientFactory(identity.Host)).Start(identity, strings.TrimSpace(args.Commit))
+	if err != nil {
+		return nil, err
+	}
+	if err := recordSession(identity, state); err != nil {
+		return nil, fmt.Errorf("review %s started but the local session was not recorded: %w", state.ID, err)
+	}
+	return state, nil
+}
+
+type mcpReviewAddCommentArgs struct {
+	mcpPullArgs
+	ReviewID  string `json:"review_id"`
+	Path      string `json:"path"`
+	Line      int    `json:"line"`
+	Side      string `json:"side"`
+	StartLine int    `json:"start_line"`
+	StartSide string `json:"start_side"`
+	Body      string `json:"body"`
+}
+
+func runMCPReviewAddComment(arguments json.RawMessage) (interface{}, error) {
+	args := mcpReviewAddCommentArgs{Side: "RIGHT"}
+	if err := mcp.DecodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	identity, err := args.identity()
+	if err != nil {
+		return nil, err
+	}
+	service := newReviewService(apiClientFactory(identity.Host))
+	reviewID, err := expandSessionReviewID(service, identity, args.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+	opts := &reviewOptions{
+		ReviewID:  reviewID,
+		Path:      args.Path,
+		Line:      args.Line,
+		Side:      args.Side,
+		StartLine: args.StartLine,
+		StartSide: args.StartSide,
+		Body:      args.Body,
+	}
+	input, err := opts.threadInput()
+	if err != nil {
+		return nil, err
+	}
+	return service.AddThread(identity, input)
+}
+
+type mcpReviewSubmitArgs struct {
+	mcpPullArgs
+	ReviewID string `json:"review_id"`
+	Event    string `json:"event"`
+	Body     string `json:"body"`
+}
+
+func runMCPReviewSubmit(arguments json.RawMessage) (interface{}, error) {
+	args := mcpReviewSubmitArgs{Event: "COMMENT"}
+	if err := mcp.DecodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	identity, err := args.identity()
+	if err != nil {
+		return nil, err
+	}
+	service := newReviewService(apiClientFactory(identity.Host))
+	reviewID, err := expandSessionReviewID(service, identity, args.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+	opts := &reviewOptions{ReviewID: reviewID, Event: args.Event, Body: args.Body}
+	input, err := opts.submitInput()
+	if err != nil {
+		return nil, err
+	}
+	status, err := service.Submit(identity, input)
+	if err != nil {
+		return nil, err
+	}
+	if status.Success {
+		if err := clearSession(service, identity, reviewID); err != nil {
+			return nil, fmt.Errorf("review %s submitted but the local session was not cleared: %w", reviewID, err)
+		}
+	}
+	if !status.Success {
+		details := map[string]interface{}{"status": "Review submission failed"}
+		if len(status.Errors) > 0 {
+			details["errors"] = status.Errors
+		}
+		return nil, &mcp.ToolError{Message: "review submission failed", Details: details}
+	}
+	return map[string]string{"status": "Review submitted successfully"}, nil
+}
+
+type mcpCommentsReplyArgs struct {
+	mcpPullArgs
+	ThreadID string `json:"thread_id"`
+	ReviewID string `json:"review_id"`
+	Body     string `json:"body"`
+	Resolve  bool   `json:"resolve"`
+}
+
+func runMCPCommentsReply(arguments json.RawMessage) (interface{}, error) {
+	var args mcpCommentsReplyArgs
+	if err := mcp.DecodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	if args.Resolve && strings.TrimSpace(args.ReviewID) != "" {
+		return nil, errors.New("resolve cannot be combined with review_id: the reply stays pending until the review is submitted, but the thread would be resolved now")
+	}
+	identity, err := args.identity()
+	if err != nil {
+		return nil, err
+	}
+	api := apiClientFactory(identity.Host)
+	reviewID, err := expandSessionReviewID(newReviewService(api), identity, args.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+	opts := comments.ReplyOptions{
+		ThreadID: args.ThreadID,
+		ReviewID: reviewID,
+		Body:     args.Body,
+	}
+	if !args.Resolve {
+		reply, err := newCommentsService(api).Reply(identity, opts)
+		if err != nil {
+			return nil, err
+		}
+		if reply.CommentNodeID == "" {
+			return nil, errors.New("reply response missing comment node id")
+		}
+		return map[string]string{"comment_node_id": reply.CommentNodeID}, nil
+	}
+
+	result, err := replyAndResolve(api, identity, opts)
+	if err != nil && result.ReplyPosted {
+		return nil, &mcp.ToolError{Message: err.Error(), Details: map[string]interface{}{
+			"status":          result.Status,
+			"comment_node_id": result.CommentNodeID,
+			"thread_node_id":  result.ThreadNodeID,
+			"reply_posted":    result.ReplyPosted,
+			"is_resolved":     result.IsResolved,
+		}}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type mcpThreadArgs struct {
+	mcpPullArgs
+	ThreadID string `json:"thread_id"`
+}
+
+func runMCPThreadsMutation(resolve bool) func(json.RawMessage) (interface{}, error) {
+	return func(arguments json.RawMessage) (interface{}, error) {
+		var args mcpThreadArgs
+		if err := mcp.DecodeArguments(arguments, &args); err != nil {
+			return nil, err
+		}
+		threadID := strings.TrimSpace(args.ThreadID)
+		if threadID == "" {
+			return nil, errors.New("thread_id is required")
+		}
+		identity, err := args.identity()
+		if err != nil {
+			return nil, err
+		}
+		service := threads.NewService(apiClientFactory(identity.Host))
+		action := threads.ActionOptions{ThreadID: threadID}
+		if resolve {
+			return service.Resolve(identity, action)
+		}
+		return service.Unresolve(identity, action)
+	}
+}
+
+// mcpObjectSchema builds a closed object schema; DecodeArguments enforces the
+// same additionalProperties rule.
+func mcpObjectSchema(properties map[string]interface{}, required ...string) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// mcpPullProperties adds the pull request selection arguments to properties.
+func mcpPullProperties(properties map[string]interface{}) map[string]interface{} {
+	properties["repo"] = mcpString("Repository in 'owner/repo' format (defaults to the current repository)")
+	properties["pr"] = map[string]interface{}{"type": "integer", "minimum": 1, "description": "Pull request number"}
+	properties["selector"] = mcpString("Pull request number or URL")
+	return properties
+}
+
+func mcpString(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "description": description}
+}
+
+func mcpNonEmptyString(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "minLength": 1, "description": description}
+}
+
+func mcpBool(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "boolean", "description": description}
+}
+
+func mcpNonNegativeInt(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "integer", "minimum": 0, "description": description}
+}
+
+func mcpReviewID() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"pattern":     "^\\s*(PRR_|session\\s*$)",
+		"description": "GraphQL review node id (PRR_...), or \"session\" for the review opened by review_start",
+	}
+}
+
+func mcpThreadID() map[string]interface{} {
+	return map[string]interface{}{"type": "string", "minLength": 1, "description": "GraphQL review thread node id (PRRT_...)"}
+}
+
+func mcpSide(description, defaultValue string) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type":        "string",
+		"enum":        []string{"LEFT", "RIGHT", "left", "right"},
+		"description": description,
+	}
+	if defaultValue != "" {
+		schema["default"] = defaultValue
+	}
+	return schema
+}
+
+func mcpStateList() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type": "string",
+			"enum": []string{"APPROVED", "CHANGES_REQUESTED", "COMMENTED", "DISMISSED", "PENDING"},
+		},
+		"description": "Only include reviews in these states",
+	}
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runMCPSession(t *testing.T, lines ...string) []map[string]interface{} {
+	t.Helper()
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetIn(strings.NewReader(strings.Join(lines, "\n")))
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"mcp"})
+	require.NoError(t, root.Execute())
+
+	var responses []map[string]interface{}
+	dec := json.NewDecoder(stdout)
+	for dec.More() {
+		var resp map[string]interface{}
+		require.NoError(t, dec.Decode(&resp))
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func TestMCPListsToolsWithSchemas(t *testing.T) {
+	responses := runMCPSession(t, `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	require.Len(t, responses, 1)
+
+	tools := responses[0]["result"].(map[string]interface{})["tools"].([]interface{})
+	schemas := map[string]map[string]interface{}{}
+	for _, raw := range tools {
+		tool := raw.(map[string]interface{})
+		schemas[tool["name"].(string)] = tool["inputSchema"].(map[string]interface{})
+	}
+	assert.ElementsMatch(t, []string{
+		"review_view", "threads_list", "review_start", "review_add_comment",
+		"review_submit", "comments_reply", "threads_resolve", "threads_unresolve",
+	}, keysOf(schemas))
+
+	addComment := schemas["review_add_comment"]["properties"].(map[string]interface{})
+	assert.Equal(t, "^\\s*(PRR_|session\\s*$)", addComment["review_id"].(map[string]interface{})["pattern"])
+	assert.Contains(t, addComment["side"].(map[string]interface{})["enum"], "RIGHT")
+	assert.Equal(t, false, schemas["review_add_comment"]["additionalProperties"])
+}
+
+func keysOf(m map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestMCPAddCommentNormalizesSide(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		input := variables["input"].(map[string]interface{})
+		require.Equal(t, "PRR_review", input["pullRequestReviewId"])
+		require.Equal(t, "LEFT", input["side"])
+		return assignJSON(result, map[string]interface{}{
+			"addPullRequestReviewThread": map[string]interface{}{
+				"thread": map[string]interface{}{"id": "PRRT_1", "path": "main.go", "isOutdated": false, "line": 3},
+			},
+		})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	responses := runMCPSession(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"review_add_comment","arguments":{"repo":"octo/demo","pr":7,"review_id":"PRR_review","path":"main.go","line":3,"side":"left","body":"nit"}}}`,
+	)
+	require.Len(t, responses, 1)
+	result := responses[0]["result"].(map[string]interface{})
+	assert.Nil(t, result["isError"])
+	assert.Equal(t, "PRRT_1", result["structuredContent"].(map[string]interface{})["id"])
+}
+
+func TestMCPValidationErrorsAreToolErrors(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+	apiClientFactory = func(host string) ghcli.API { return &commandFakeAPI{} }
+
+	responses := runMCPSession(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"review_add_comment","arguments":{"repo":"octo/demo","pr":7,"review_id":"123","path":"main.go","line":3,"body":"nit"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"review_submit","arguments":{"repo":"octo/demo","pr":7,"review_id":"PRR_review","event":"MERGE"}}}`,
+	)
+	require.Len(t, responses, 2)
+
+	first := responses[0]["result"].(map[string]interface{})
+	assert.Equal(t, true, first["isError"])
+	assert.Contains(t, first["structuredContent"].(map[string]interface{})["error"], "must be a GraphQL node id (PRR_...)")
+
+	second := responses[1]["result"].(map[string]interface{})
+	assert.Equal(t, true, second["isError"])
+	assert.Contains(t, second["structuredContent"].(map[string]interface{})["error"], "invalid event")
+}
+
+func TestMCPSubmitFailureCarriesGraphQLErrors(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		return &ghcli.GraphQLError{Errors: []ghcli.GraphQLErrorEntry{{Message: "review is not pending"}}}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	responses := runMCPSession(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"review_submit","arguments":{"repo":"octo/demo","pr":7,"review_id":"PRR_review","event":"approve"}}}`,
+	)
+	require.Len(t, responses, 1)
+
+	result := responses[0]["result"].(map[string]interface{})
+	assert.Equal(t, true, result["isError"])
+	structured := result["structuredContent"].(map[string]interface{})
+	assert.Equal(t, "review submission failed", structured["error"])
+	errs := structured["errors"].([]interface{})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "review is not pending", errs[0].(map[string]interface{})["message"])
+}
@@ -5,9 +5,26 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/config"
 )
 
+// encodeJSON writes payload in the configured output format.
 func encodeJSON(cmd *cobra.Command, payload interface{}) error {
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetEscapeHTML(false)
+	if activeSettings.Output == config.OutputPretty {
+		enc.SetIndent("", "  ")
+	}
+	if err := enc.Encode(payload); err != nil {
+		return fmt.Errorf("encode json: %w", err)
+	}
+	return nil
+}
+
+// encodeJSONLine writes payload as a single NDJSON line regardless of the
+// configured output format, for commands that stream events.
+func encodeJSONLine(cmd *cobra.Command, payload interface{}) error {
 	enc := json.NewEncoder(cmd.OutOrStdout())
 	enc.SetEscapeHTML(false)
 	if err := enc.Encode(payload); err != nil {
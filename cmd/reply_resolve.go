@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/comments"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/threads"
+)
+
+// replyResolveResult is the combined payload of posting a closing reply and resolving its thread.
+type replyResolveResult struct {
+	Status        string `json:"status"`
+	CommentNodeID string `json:"comment_node_id"`
+	ThreadNodeID  string `json:"thread_node_id"`
+	ReplyPosted   bool   `json:"reply_posted"`
+	IsResolved    bool   `json:"is_resolved"`
+	ResolveError  string `json:"resolve_error,omitempty"`
+}
+
+// errResolvePendingReply rejects resolving a thread whose reply would stay
+// unpublished in a pending review until the review is submitted.
+const errResolvePendingReply = "--resolve cannot be combined with --review-id: the reply stays pending until the review is submitted, but the thread would be resolved now"
+
+// runReplyAndResolve posts a reply to the thread and then resolves it. When the
+// reply succeeds but resolving fails, the partial outcome is still emitted
+// before returning an error so callers know the reply exists.
+func runReplyAndResolve(cmd *cobra.Command, pr resolver.Identity, opts comments.ReplyOptions) error {
+	result, err := replyAndResolve(apiClientFactory(pr.Host), pr, opts)
+	if err != nil && !result.ReplyPosted {
+		return err
+	}
+	if encodeErr := encodeJSON(cmd, result); encodeErr != nil {
+		return encodeErr
+	}
+	return err
+}
+
+// replyAndResolve performs the reply and resolve mutations. The returned result
+// is meaningful whenever ReplyPosted is true, even if an error is also returned.
+func replyAndResolve(api ghcli.API, pr resolver.Identity, opts comments.ReplyOptions) (replyResolveResult, error) {
+	reply, err := newCommentsService(api).Reply(pr, opts)
+	if err != nil {
+		return replyResolveResult{}, err
+	}
+	if reply.CommentNodeID == "" {
+		return replyResolveResult{}, errors.New("reply response missing comment node id")
+	}
+
+	result := replyResolveResult{
+		CommentNodeID: reply.CommentNodeID,
+		ThreadNodeID:  reply.ThreadID,
+		ReplyPosted:   true,
+		IsResolved:    reply.ThreadIsResolved,
+	}
+
+	action, err := threads.NewService(api).Resolve(pr, threads.ActionOptions{ThreadID: reply.ThreadID})
+	if err != nil {
+		result.Status = "Reply posted; thread not resolved"
+		result.ResolveError = err.Error()
+		return result, fmt.Errorf("reply %s was posted but thread %s was not resolved: %w", reply.CommentNodeID, reply.ThreadID, err)
+	}
+
+	result.Status = "Reply posted and thread resolved"
+	result.IsResolved = action.IsResolved
+	return result, nil
+}
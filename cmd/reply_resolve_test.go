@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func replyResolveFake(t *testing.T, canResolve bool, resolved *bool) *commandFakeAPI {
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "AddPullRequestReviewThreadReply"):
+			input := variables["input"].(map[string]interface{})
+			require.Equal(t, "PRRT_thread", input["pullRequestReviewThreadId"])
+			require.Equal(t, "Fixed in abc123", input["body"])
+			return assignJSON(result, map[string]interface{}{
+				"addPullRequestReviewThreadReply": map[string]interface{}{
+					"comment": map[string]interface{}{
+						"id":     "PRRC_reply",
+						"body":   "Fixed in abc123",
+						"author": map[string]interface{}{"login": "octocat"},
+					},
+				},
+			})
+		case strings.Contains(query, "PullRequestReviewCommentDetails"):
+			return assignJSON(result, map[string]interface{}{
+				"node": map[string]interface{}{
+					"id":     "PRRC_reply",
+					"body":   "Fixed in abc123",
+					"path":   "main.go",
+					"author": map[string]interface{}{"login": "octocat"},
+				},
+			})
+		case strings.Contains(query, "PullRequestReviewThreadDetails"):
+			return assignJSON(result, map[string]interface{}{
+				"node": map[string]interface{}{"id": "PRRT_thread", "isResolved": false},
+			})
+		case strings.Contains(query, "query ThreadDetails"):
+			return assignJSON(result, map[string]interface{}{
+				"node": map[string]interface{}{
+					"id":               "PRRT_thread",
+					"isResolved":       false,
+					"viewerCanResolve": canResolve,
+				},
+			})
+		case strings.Contains(query, "resolveReviewThread"):
+			*resolved = true
+			return assignJSON(result, map[string]interface{}{
+				"resolveReviewThread": map[string]interface{}{
+					"thread": map[string]interface{}{"id": "PRRT_thread", "isResolved": true},
+				},
+			})
+		default:
+			return errors.New("unexpected query: " + query)
+		}
+	}
+	return fake
+}
+
+func TestThreadsResolveWithReply(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	resolved := false
+	fake := replyResolveFake(t, true, &resolved)
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "resolve", "--thread-id", "PRRT_thread", "--reply", "Fixed in abc123", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assert.True(t, resolved)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+	assert.Equal(t, "PRRC_reply", payload["comment_node_id"])
+	assert.Equal(t, "PRRT_thread", payload["thread_node_id"])
+	assert.Equal(t, true, payload["reply_posted"])
+	assert.Equal(t, true, payload["is_resolved"])
+	assert.NotContains(t, payload, "resolve_error")
+}
+
+func TestCommentsReplyResolveReportsPartialFailure(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	resolved := false
+	fake := replyResolveFake(t, false, &resolved)
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "reply", "--thread-id", "PRRT_thread", "--body", "Fixed in abc123", "--resolve", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "was posted but thread PRRT_thread was not resolved")
+	assert.False(t, resolved)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+	assert.Equal(t, "Reply posted; thread not resolved", payload["status"])
+	assert.Equal(t, true, payload["reply_posted"])
+	assert.Equal(t, false, payload["is_resolved"])
+	assert.Equal(t, "viewer cannot resolve this thread", payload["resolve_error"])
+}
+
+func TestThreadsResolveRejectsReplyWithAll(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "resolve", "--all", "--reply", "done", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--reply cannot be combined with --all")
+}
+
+func TestCommentsReplyRejectsResolveWithPendingReview(t *testing.T) {
+	for _, reviewID := range []string{"PRR_pending", "session"} {
+		root := newRootCommand()
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs([]string{"comments", "reply", "--thread-id", "PRRT_thread", "--review-id", reviewID, "--body", "done", "--resolve", "--repo", "octo/demo", "7"})
+
+		err := root.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--resolve cannot be combined with --review-id")
+	}
+}
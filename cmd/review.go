@@ -3,13 +3,13 @@ package cmd
 import (
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
 	reviewsvc "github.com/Agyn-sandbox/gh-pr-review/internal/review"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/templates"
 )
 
 func newReviewCommand() *cobra.Command {
@@ -33,20 +33,27 @@ func newReviewCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&opts.Start, "start", false, "Open a pending review")
 	cmd.Flags().BoolVar(&opts.AddComment, "add-comment", false, "Add an inline comment to a pending review")
 	cmd.Flags().BoolVar(&opts.Submit, "submit", false, "Submit a pending review")
+	cmd.Flags().BoolVar(&opts.Discard, "discard", false, "Delete a pending review and its comments")
 
 	cmd.Flags().StringVar(&opts.Commit, "commit", "", "Commit SHA for review start (defaults to current head)")
-	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "Review identifier (GraphQL review node ID)")
+	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "Review identifier (GraphQL review node ID; defaults to the review opened by --start)")
 	cmd.Flags().StringVar(&opts.Path, "path", "", "File path for inline comment")
 	cmd.Flags().IntVar(&opts.Line, "line", 0, "Line number for inline comment")
 	cmd.Flags().StringVar(&opts.Side, "side", opts.Side, "Diff side for inline comment (LEFT or RIGHT)")
 	cmd.Flags().IntVar(&opts.StartLine, "start-line", 0, "Start line for multi-line comments")
 	cmd.Flags().StringVar(&opts.StartSide, "start-side", "", "Start side for multi-line comments")
-	cmd.Flags().StringVar(&opts.Body, "body", "", "Comment or review body")
+	cmd.Flags().StringVar(&opts.Body, "body", "", "Comment or review body (opens $EDITOR in a terminal when a comment or REQUEST_CHANGES body is omitted)")
+	bindBodyFileFlag(cmd, "body", &opts.BodyFile)
+	bindTemplateFlags(cmd, &opts.templateFlags)
+	bindRedactFlag(cmd)
 	cmd.Flags().StringVar(&opts.Event, "event", opts.Event, "Review submission event (APPROVE, COMMENT, REQUEST_CHANGES)")
 
 	cmd.AddCommand(newReviewViewCommand())
+	cmd.AddCommand(newReviewDraftCommand())
+	cmd.AddCommand(newReviewDismissCommand())
+	cmd.AddCommand(newReviewRequestCommand())
 
-	return cmd
+	return configure(cmd)
 }
 
 type reviewOptions struct {
@@ -57,6 +64,7 @@ type reviewOptions struct {
 	Start      bool
 	AddComment bool
 	Submit     bool
+	Discard    bool
 
 	Commit    string
 	ReviewID  string
@@ -66,11 +74,13 @@ type reviewOptions struct {
 	StartLine int
 	StartSide string
 	Body      string
+	BodyFile  string
 	Event     string
+	templateFlags
 }
 
 func runReview(cmd *cobra.Command, opts *reviewOptions) error {
-	actions := []bool{opts.Start, opts.AddComment, opts.Submit}
+	actions := []bool{opts.Start, opts.AddComment, opts.Submit, opts.Discard}
 	enabled := 0
 	for _, flag := range actions {
 		if flag {
@@ -78,7 +88,7 @@ func runReview(cmd *cobra.Command, opts *reviewOptions) error {
 		}
 	}
 	if enabled != 1 {
-		return errors.New("specify exactly one of --start, --add-comment, or --submit")
+		return errors.New("specify exactly one of --start, --add-comment, --submit, or --discard")
 	}
 
 	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
@@ -86,95 +96,178 @@ func runReview(cmd *cobra.Command, opts *reviewOptions) error {
 		return err
 	}
 
-	hostEnv := os.Getenv("GH_HOST")
-	identity, err := resolver.Resolve(selector, opts.Repo, hostEnv)
+	host := defaultHost()
+	identity, err := resolver.Resolve(selector, opts.Repo, host)
 	if err != nil {
 		return err
 	}
 
-	service := reviewsvc.NewService(apiClientFactory(identity.Host))
+	service := newReviewService(apiClientFactory(identity.Host))
 
 	switch {
 	case opts.Start:
 		return executeReviewStart(cmd, service, identity, opts)
 	case opts.AddComment:
 		return executeReviewAddComment(cmd, service, identity, opts)
+	case opts.Discard:
+		return executeReviewDiscard(cmd, service, identity, opts)
 	default: // Submit
 		return executeReviewSubmit(cmd, service, identity, opts)
 	}
 }
 
+// useSessionReviewID fills an omitted --review-id from the local review session.
+func useSessionReviewID(service *reviewsvc.Service, pr resolver.Identity, opts *reviewOptions) error {
+	if strings.TrimSpace(opts.ReviewID) != "" {
+		return nil
+	}
+	reviewID, err := sessionReviewID(service, pr)
+	if err != nil {
+		return err
+	}
+	opts.ReviewID = reviewID
+	return nil
+}
+
 func executeReviewStart(cmd *cobra.Command, service *reviewsvc.Service, pr resolver.Identity, opts *reviewOptions) error {
 	state, err := service.Start(pr, strings.TrimSpace(opts.Commit))
 	if err != nil {
 		return err
 	}
-	return encodeJSON(cmd, state)
+	if err := encodeJSON(cmd, state); err != nil {
+		return err
+	}
+	if err := recordSession(pr, state); err != nil {
+		return fmt.Errorf("review %s started but the local session was not recorded: %w", state.ID, err)
+	}
+	return nil
 }
 
 func executeReviewAddComment(cmd *cobra.Command, service *reviewsvc.Service, pr resolver.Identity, opts *reviewOptions) error {
-	reviewID := strings.TrimSpace(opts.ReviewID)
+	if err := useSessionReviewID(service, pr, opts); err != nil {
+		return err
+	}
+	input, err := opts.threadInput()
+	if err != nil {
+		return err
+	}
+	body, ok, err := opts.renderBody(cmd, func() (templates.Data, error) {
+		data := pullTemplateData(service, pr)
+		data.Path, data.Line, data.StartLine = input.Path, input.Line, opts.StartLine
+		return data, nil
+	})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		body, err = requireBody(cmd, "body", opts.Body, opts.BodyFile, func() []string {
+			return inlineCommentContext(pr, input.Path, input.Line, opts.StartLine)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	input.Body = body
+
+	thread, err := service.AddThread(pr, input)
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, thread)
+}
+
+// threadInput validates the --add-comment flags.
+func (o *reviewOptions) threadInput() (reviewsvc.ThreadInput, error) {
+	reviewID := strings.TrimSpace(o.ReviewID)
 	if reviewID == "" {
-		return errors.New("--review-id is required")
+		return reviewsvc.ThreadInput{}, errors.New("--review-id is required (or open a review with --start first)")
 	}
 	if !strings.HasPrefix(reviewID, "PRR_") {
-		return fmt.Errorf("invalid --review-id %q: must be a GraphQL node id (PRR_...)", opts.ReviewID)
+		return reviewsvc.ThreadInput{}, fmt.Errorf("invalid --review-id %q: must be a GraphQL node id (PRR_...)", o.ReviewID)
 	}
 
-	side, err := normalizeSide(opts.Side)
+	side, err := normalizeSide(o.Side)
 	if err != nil {
-		return err
+		return reviewsvc.ThreadInput{}, err
 	}
 	var startLine *int
-	if opts.StartLine > 0 {
-		startLine = &opts.StartLine
+	if o.StartLine > 0 {
+		value := o.StartLine
+		startLine = &value
 	}
 	var startSide *string
-	if opts.StartSide != "" {
-		normalized, err := normalizeSide(opts.StartSide)
+	if o.StartSide != "" {
+		normalized, err := normalizeSide(o.StartSide)
 		if err != nil {
-			return fmt.Errorf("invalid start-side: %w", err)
+			return reviewsvc.ThreadInput{}, fmt.Errorf("invalid start-side: %w", err)
 		}
 		startSide = &normalized
 	}
 
-	input := reviewsvc.ThreadInput{
+	return reviewsvc.ThreadInput{
 		ReviewID:  reviewID,
-		Path:      strings.TrimSpace(opts.Path),
-		Line:      opts.Line,
+		Path:      strings.TrimSpace(o.Path),
+		Line:      o.Line,
 		Side:      side,
 		StartLine: startLine,
 		StartSide: startSide,
-		Body:      opts.Body,
-	}
+		Body:      o.Body,
+	}, nil
+}
 
-	thread, err := service.AddThread(pr, input)
+// submitInput validates the --submit flags.
+func (o *reviewOptions) submitInput() (reviewsvc.SubmitInput, error) {
+	event, err := normalizeEvent(o.Event)
 	if err != nil {
-		return err
+		return reviewsvc.SubmitInput{}, err
 	}
-	return encodeJSON(cmd, thread)
+	reviewID, err := ensureGraphQLReviewID(o.ReviewID)
+	if err != nil {
+		return reviewsvc.SubmitInput{}, err
+	}
+	return reviewsvc.SubmitInput{
+		ReviewID: reviewID,
+		Event:    event,
+		Body:     o.Body,
+	}, nil
 }
 
 func executeReviewSubmit(cmd *cobra.Command, service *reviewsvc.Service, pr resolver.Identity, opts *reviewOptions) error {
-	event, err := normalizeEvent(opts.Event)
+	if err := useSessionReviewID(service, pr, opts); err != nil {
+		return err
+	}
+	input, err := opts.submitInput()
 	if err != nil {
 		return err
 	}
-	reviewID, err := ensureGraphQLReviewID(opts.ReviewID)
+	body, ok, err := opts.renderBody(cmd, func() (templates.Data, error) {
+		return pullTemplateData(service, pr), nil
+	})
 	if err != nil {
 		return err
 	}
-	input := reviewsvc.SubmitInput{
-		ReviewID: reviewID,
-		Event:    event,
-		Body:     opts.Body,
+	if !ok {
+		body, ok, err = readBody(cmd, "body", opts.Body, opts.BodyFile)
+		if err != nil {
+			return err
+		}
 	}
+	if !ok && input.Event == "REQUEST_CHANGES" && interactiveTerminal() {
+		body, err = editBody([]string{"", fmt.Sprintf("Review summary requesting changes on %s/%s#%d", pr.Owner, pr.Repo, pr.Number)})
+		if err != nil {
+			return err
+		}
+	}
+	input.Body = body
 	status, err := service.Submit(pr, input)
 	if err != nil {
 		return err
 	}
 	if status.Success {
-		return encodeJSON(cmd, map[string]string{"status": "Review submitted successfully"})
+		if err := encodeJSON(cmd, map[string]string{"status": "Review submitted successfully"}); err != nil {
+			return err
+		}
+		return clearSession(service, pr, input.ReviewID)
 	}
 	failure := map[string]interface{}{
 		"status": "Review submission failed",
@@ -188,6 +281,24 @@ func executeReviewSubmit(cmd *cobra.Command, service *reviewsvc.Service, pr reso
 	return errors.New("review submission failed")
 }
 
+func executeReviewDiscard(cmd *cobra.Command, service *reviewsvc.Service, pr resolver.Identity, opts *reviewOptions) error {
+	if err := useSessionReviewID(service, pr, opts); err != nil {
+		return err
+	}
+	reviewID, err := ensureGraphQLReviewID(opts.ReviewID)
+	if err != nil {
+		return err
+	}
+	state, err := service.Discard(reviewID)
+	if err != nil {
+		return err
+	}
+	if err := encodeJSON(cmd, state); err != nil {
+		return err
+	}
+	return clearSession(service, pr, reviewID)
+}
+
 func normalizeSide(side string) (string, error) {
 	s := strings.ToUpper(strings.TrimSpace(side))
 	switch s {
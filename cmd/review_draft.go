@@ -0,0 +1,622 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/comments"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/commits"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/draft"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	reviewsvc "github.com/Agyn-sandbox/gh-pr-review/internal/review"
+)
+
+func newReviewDraftCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "draft",
+		Short: "Compose review comments locally and push them as one review",
+	}
+
+	cmd.AddCommand(newReviewDraftAddCommand())
+	cmd.AddCommand(newReviewDraftListCommand())
+	cmd.AddCommand(newReviewDraftEditCommand())
+	cmd.AddCommand(newReviewDraftRemoveCommand())
+	cmd.AddCommand(newReviewDraftPushCommand())
+
+	return cmd
+}
+
+// draftTarget selects the pull request whose drafts a subcommand works on.
+type draftTarget struct {
+	Repo     string
+	Pull     int
+	Selector string
+}
+
+func bindDraftTargetFlags(cmd *cobra.Command, target *draftTarget) {
+	cmd.Flags().StringVarP(&target.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&target.Pull, "pr", 0, "Pull request number")
+}
+
+func (t draftTarget) identity() (resolver.Identity, error) {
+	selector, err := resolver.NormalizeSelector(t.Selector, t.Pull)
+	if err != nil {
+		return resolver.Identity{}, err
+	}
+	return resolver.Resolve(selector, t.Repo, defaultHost())
+}
+
+// loadDrafts resolves the pull request and loads its stored drafts.
+func (t draftTarget) loadDrafts() (*draft.Store, resolver.Identity, *draft.Set, error) {
+	identity, err := t.identity()
+	if err != nil {
+		return nil, resolver.Identity{}, nil, err
+	}
+	store, err := draftStoreFactory()
+	if err != nil {
+		return nil, resolver.Identity{}, nil, err
+	}
+	set, err := store.Load(identity)
+	if err != nil {
+		return nil, resolver.Identity{}, nil, err
+	}
+	return store, identity, set, nil
+}
+
+type draftFields struct {
+	ThreadID  string
+	Path      string
+	Line      int
+	Side      string
+	StartLine int
+	StartSide string
+	Body      string
+	BodyFile  string
+	Checkout  string
+}
+
+func bindDraftFieldFlags(cmd *cobra.Command, fields *draftFields) {
+	cmd.Flags().StringVar(&fields.ThreadID, "thread-id", "", "Draft a reply to this review thread (GraphQL node ID) instead of a new thread")
+	cmd.Flags().StringVar(&fields.Path, "path", "", "File path for the inline comment")
+	cmd.Flags().IntVar(&fields.Line, "line", 0, "Line number for the inline comment")
+	cmd.Flags().StringVar(&fields.Side, "side", fields.Side, "Diff side for the inline comment (LEFT or RIGHT)")
+	cmd.Flags().IntVar(&fields.StartLine, "start-line", 0, "Start line for multi-line comments")
+	cmd.Flags().StringVar(&fields.StartSide, "start-side", "", "Start side for multi-line comments")
+	cmd.Flags().StringVar(&fields.Body, "body", "", "Comment body")
+	bindBodyFileFlag(cmd, "body", &fields.BodyFile)
+	cmd.Flags().StringVar(&fields.Checkout, "checkout", ".", "Local checkout used to record the drafted line text for RIGHT-side comments")
+}
+
+// validateDraft normalizes a draft and records the drafted line text so push
+// can detect lines that moved.
+func validateDraft(d *draft.Draft, checkout string) error {
+	if strings.TrimSpace(d.Body) == "" {
+		return errors.New("--body is required")
+	}
+
+	if d.Kind == draft.KindReply {
+		d.ThreadID = strings.TrimSpace(d.ThreadID)
+		if d.ThreadID == "" {
+			return errors.New("--thread-id is required for reply drafts")
+		}
+		return nil
+	}
+
+	d.Path = strings.TrimSpace(d.Path)
+	if d.Path == "" {
+		return errors.New("--path is required for thread drafts")
+	}
+	if d.Line <= 0 {
+		return errors.New("--line must be a positive line number")
+	}
+	side, err := normalizeSide(d.Side)
+	if err != nil {
+		return err
+	}
+	d.Side = side
+	if d.StartLine < 0 || d.StartLine > d.Line {
+		return fmt.Errorf("invalid --start-line %d: must be between 1 and --line", d.StartLine)
+	}
+	if d.StartSide != "" {
+		startSide, err := normalizeSide(d.StartSide)
+		if err != nil {
+			return fmt.Errorf("invalid start-side: %w", err)
+		}
+		d.StartSide = startSide
+	}
+
+	d.LineText = nil
+	if d.Side == "RIGHT" {
+		d.LineText = checkoutLine(filepath.Join(checkout, filepath.FromSlash(d.Path)), d.Line)
+	}
+	return nil
+}
+
+// checkoutLine returns the text of a line in a local file, or nil when the
+// file cannot be read or is shorter than line.
+func checkoutLine(path string, line int) *string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for number := 1; scanner.Scan(); number++ {
+		if number == line {
+			text := strings.TrimSuffix(scanner.Text(), "\r")
+			return &text
+		}
+	}
+	return nil
+}
+
+func newReviewDraftAddCommand() *cobra.Command {
+	target := &draftTarget{}
+	fields := &draftFields{Side: "RIGHT"}
+
+	cmd := &cobra.Command{
+		Use:   "add [<number> | <url>]",
+		Short: "Store a draft thread or reply for a pull request",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				target.Selector = args[0]
+			}
+			return runReviewDraftAdd(cmd, target, fields)
+		},
+	}
+
+	bindDraftTargetFlags(cmd, target)
+	bindDraftFieldFlags(cmd, fields)
+
+	return configure(cmd)
+}
+
+func runReviewDraftAdd(cmd *cobra.Command, target *draftTarget, fields *draftFields) error {
+	now := time.Now().UTC()
+	entry := draft.Draft{Kind: draft.KindThread, CreatedAt: now, UpdatedAt: now}
+	if strings.TrimSpace(fields.ThreadID) != "" {
+		if cmd.Flags().Changed("path") || cmd.Flags().Changed("line") || cmd.Flags().Changed("start-line") {
+			return errors.New("--thread-id cannot be combined with --path, --line, or --start-line")
+		}
+		entry.Kind = draft.KindReply
+		entry.ThreadID = fields.ThreadID
+	} else {
+		entry.Path = fields.Path
+		entry.Line = fields.Line
+		entry.Side = fields.Side
+		entry.StartLine = fields.StartLine
+		entry.StartSide = fields.StartSide
+	}
+
+	store, identity, set, err := target.loadDrafts()
+	if err != nil {
+		return err
+	}
+	entry.Body, err = requireBody(cmd, "body", fields.Body, fields.BodyFile, func() []string {
+		if entry.Kind == draft.KindReply {
+			return threadContext(identity, entry.ThreadID)
+		}
+		return inlineCommentContext(identity, entry.Path, entry.Line, entry.StartLine)
+	})
+	if err != nil {
+		return err
+	}
+	if err := validateDraft(&entry, fields.Checkout); err != nil {
+		return err
+	}
+	entry = set.Add(entry)
+	if err := store.Save(identity, set); err != nil {
+		return err
+	}
+	return encodeJSON(cmd, entry)
+}
+
+func newReviewDraftListCommand() *cobra.Command {
+	target := &draftTarget{}
+
+	cmd := &cobra.Command{
+		Use:   "list [<number> | <url>]",
+		Short: "List the drafts stored for a pull request",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				target.Selector = args[0]
+			}
+			_, _, set, err := target.loadDrafts()
+			if err != nil {
+				return err
+			}
+			return encodeJSON(cmd, set.Drafts)
+		},
+	}
+
+	bindDraftTargetFlags(cmd, target)
+
+	return configure(cmd)
+}
+
+func newReviewDraftEditCommand() *cobra.Command {
+	target := &draftTarget{}
+	fields := &draftFields{Side: "RIGHT"}
+
+	cmd := &cobra.Command{
+		Use:   "edit <draft-id>",
+		Short: "Change fields of a stored draft",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReviewDraftEdit(cmd, target, fields, args[0])
+		},
+	}
+
+	bindDraftTargetFlags(cmd, target)
+	bindDraftFieldFlags(cmd, fields)
+
+	return configure(cmd)
+}
+
+func runReviewDraftEdit(cmd *cobra.Command, target *draftTarget, fields *draftFields, id string) error {
+	store, identity, set, err := target.loadDrafts()
+	if err != nil {
+		return err
+	}
+	existing, ok := set.Find(strings.TrimSpace(id))
+	if !ok {
+		return fmt.Errorf("draft %q not found", id)
+	}
+
+	edited := *existing
+	changed := cmd.Flags().Changed
+	if edited.Kind == draft.KindReply {
+		if changed("path") || changed("line") || changed("side") || changed("start-line") || changed("start-side") {
+			return fmt.Errorf("draft %s is a reply; only --body and --thread-id can be changed", edited.ID)
+		}
+		if changed("thread-id") {
+			edited.ThreadID = fields.ThreadID
+		}
+	} else {
+		if changed("thread-id") {
+			return fmt.Errorf("draft %s is a thread; --thread-id cannot be changed", edited.ID)
+		}
+		if changed("path") {
+			edited.Path = fields.Path
+		}
+		if changed("line") {
+			edited.Line = fields.Line
+		}
+		if changed("side") {
+			edited.Side = fields.Side
+		}
+		if changed("start-line") {
+			edited.StartLine = fields.StartLine
+		}
+		if changed("start-side") {
+			edited.StartSide = fields.StartSide
+		}
+	}
+	if changed("body") || changed("body-file") {
+		body, _, err := readBody(cmd, "body", fields.Body, fields.BodyFile)
+		if err != nil {
+			return err
+		}
+		edited.Body = body
+	}
+
+	relocated := changed("path") || changed("line") || changed("side") || changed("checkout")
+	lineText := edited.LineText
+	if err := validateDraft(&edited, fields.Checkout); err != nil {
+		return err
+	}
+	if !relocated {
+		edited.LineText = lineText
+	}
+	edited.UpdatedAt = time.Now().UTC()
+
+	*existing = edited
+	if err := store.Save(identity, set); err != nil {
+		return err
+	}
+	return encodeJSON(cmd, edited)
+}
+
+func newReviewDraftRemoveCommand() *cobra.Command {
+	target := &draftTarget{}
+
+	cmd := &cobra.Command{
+		Use:   "rm <draft-id>...",
+		Short: "Delete stored drafts",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, identity, set, err := target.loadDrafts()
+			if err != nil {
+				return err
+			}
+			ids := make([]string, 0, len(args))
+			for _, arg := range args {
+				ids = append(ids, strings.TrimSpace(arg))
+			}
+			if missing := set.Remove(ids...); len(missing) > 0 {
+				return fmt.Errorf("drafts not found: %s", strings.Join(missing, ", "))
+			}
+			if err := store.Save(identity, set); err != nil {
+				return err
+			}
+			return encodeJSON(cmd, map[string][]string{"removed": ids})
+		},
+	}
+
+	bindDraftTargetFlags(cmd, target)
+
+	return configure(cmd)
+}
+
+func newReviewDraftPushCommand() *cobra.Command {
+	target := &draftTarget{}
+	opts := &reviewDraftPushOptions{Event: "COMMENT"}
+
+	cmd := &cobra.Command{
+		Use:   "push [<number> | <url>]",
+		Short: "Add the stored drafts to a pending review, optionally submitting it",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				target.Selector = args[0]
+			}
+			return runReviewDraftPush(cmd, target, opts)
+		},
+	}
+
+	bindDraftTargetFlags(cmd, target)
+	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "Pending review to add the drafts to (GraphQL review node ID; defaults to the review session, otherwise a new review is started)")
+	cmd.Flags().BoolVar(&opts.Submit, "submit", false, "Submit the review once every draft was pushed")
+	cmd.Flags().StringVar(&opts.Event, "event", opts.Event, "Review submission event (APPROVE, COMMENT, REQUEST_CHANGES)")
+	cmd.Flags().StringVar(&opts.Body, "body", "", "Review body used with --submit")
+	bindBodyFileFlag(cmd, "body", &opts.BodyFile)
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Check the drafts against the current diff without pushing them")
+	bindRedactFlag(cmd)
+
+	return configure(cmd)
+}
+
+type reviewDraftPushOptions struct {
+	ReviewID string
+	Submit   bool
+	Event    string
+	Body     string
+	BodyFile string
+	DryRun   bool
+}
+
+// Draft push statuses.
+const (
+	draftStatusPlanned = "planned"
+	draftStatusPushed  = "pushed"
+	draftStatusInvalid = "invalid"
+	draftStatusFailed  = "failed"
+)
+
+type draftPushResult struct {
+	ReviewID  string           `json:"review_id,omitempty"`
+	DryRun    bool             `json:"dry_run"`
+	Submitted bool             `json:"submitted"`
+	Drafts    []draftPushEntry `json:"drafts"`
+}
+
+type draftPushEntry struct {
+	ID            string `json:"id"`
+	Kind          string `json:"kind"`
+	Status        string `json:"status"`
+	Path          string `json:"path,omitempty"`
+	Line          *int   `json:"line,omitempty"`
+	StartLine     *int   `json:"start_line,omitempty"`
+	MovedFrom     *int   `json:"moved_from,omitempty"`
+	ThreadID      string `json:"thread_id,omitempty"`
+	ThreadNodeID  string `json:"thread_node_id,omitempty"`
+	CommentNodeID string `json:"comment_node_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+func runReviewDraftPush(cmd *cobra.Command, target *draftTarget, opts *reviewDraftPushOptions) error {
+	event, err := normalizeEvent(opts.Event)
+	if err != nil {
+		return err
+	}
+	body, _, err := readBody(cmd, "body", opts.Body, opts.BodyFile)
+	if err != nil {
+		return err
+	}
+	reviewID := strings.TrimSpace(opts.ReviewID)
+	if reviewID != "" {
+		if reviewID, err = ensureGraphQLReviewID(reviewID); err != nil {
+			return err
+		}
+	}
+
+	store, identity, set, err := target.loadDrafts()
+	if err != nil {
+		return err
+	}
+	if len(set.Drafts) == 0 {
+		return errors.New("no drafts are stored for this pull request")
+	}
+
+	api := apiClientFactory(identity.Host)
+	entries, placements, err := planDraftPush(identity, commits.NewFetcher(api), set.Drafts)
+	if err != nil {
+		return err
+	}
+
+	result := draftPushResult{DryRun: opts.DryRun, Drafts: entries}
+	invalid := countDraftStatus(entries, draftStatusInvalid)
+	if opts.DryRun {
+		if err := encodeJSON(cmd, result); err != nil {
+			return err
+		}
+		if invalid > 0 {
+			return fmt.Errorf("%d of %d drafts are invalid against the current diff", invalid, len(entries))
+		}
+		return nil
+	}
+
+	service := newReviewService(api)
+	if invalid < len(entries) {
+		if reviewID == "" {
+			if reviewID, err = sessionReviewID(service, identity); err != nil {
+				return err
+			}
+		}
+		if reviewID == "" {
+			state, err := service.Start(identity, "")
+			if err != nil {
+				return err
+			}
+			reviewID = state.ID
+			if err := recordSession(identity, state); err != nil {
+				return fmt.Errorf("review %s started but the local session was not recorded: %w", reviewID, err)
+			}
+		}
+		result.ReviewID = reviewID
+
+		pushed := pushDrafts(identity, service, newCommentsService(api), reviewID, set.Drafts, placements, result.Drafts)
+		set.Remove(pushed...)
+		if err := store.Save(identity, set); err != nil {
+			return fmt.Errorf("drafts were pushed to review %s but the local drafts were not updated: %w", reviewID, err)
+		}
+	}
+
+	unpushed := len(entries) - countDraftStatus(result.Drafts, draftStatusPushed)
+	if opts.Submit && unpushed == 0 {
+		status, err := service.Submit(identity, reviewsvc.SubmitInput{ReviewID: reviewID, Event: event, Body: body})
+		if err != nil {
+			return err
+		}
+		if !status.Success {
+			if err := encodeJSON(cmd, result); err != nil {
+				return err
+			}
+			return errors.New("review submission failed")
+		}
+		result.Submitted = true
+		if err := clearSession(service, identity, reviewID); err != nil {
+			return err
+		}
+	}
+
+	if err := encodeJSON(cmd, result); err != nil {
+		return err
+	}
+	if unpushed > 0 {
+		return fmt.Errorf("%d of %d drafts were not pushed and remain stored locally", unpushed, len(entries))
+	}
+	return nil
+}
+
+// planDraftPush places every thread draft in the current pull request diff.
+// Reply drafts are not tied to the diff and are always planned.
+func planDraftPush(pr resolver.Identity, fetcher *commits.Fetcher, drafts []draft.Draft) ([]draftPushEntry, []draft.Placement, error) {
+	var files []commits.File
+	for _, d := range drafts {
+		if d.Kind == draft.KindThread {
+			var err error
+			if files, err = fetcher.PullFiles(pr); err != nil {
+				return nil, nil, err
+			}
+			break
+		}
+	}
+
+	entries := make([]draftPushEntry, len(drafts))
+	placements := make([]draft.Placement, len(drafts))
+	for i, d := range drafts {
+		entry := draftPushEntry{ID: d.ID, Kind: d.Kind, Status: draftStatusPlanned, ThreadID: d.ThreadID}
+		if d.Kind == draft.KindThread {
+			placement := draft.Place(d, files)
+			placements[i] = placement
+			entry.Path = d.Path
+			entry.Reason = placement.Reason
+			switch placement.Status {
+			case draft.PlacementInvalid:
+				entry.Status = draftStatusInvalid
+			default:
+				entry.Line = intPtr(placement.Line)
+				if placement.StartLine > 0 {
+					entry.StartLine = intPtr(placement.StartLine)
+				}
+				if placement.Status == draft.PlacementMoved {
+					entry.MovedFrom = intPtr(d.Line)
+				}
+			}
+		}
+		entries[i] = entry
+	}
+	return entries, placements, nil
+}
+
+// pushDrafts adds the planned drafts to the pending review, updating entries
+// in place, and returns the ids of the drafts that were pushed.
+func pushDrafts(pr resolver.Identity, reviews *reviewsvc.Service, replies *comments.Service, reviewID string, drafts []draft.Draft, placements []draft.Placement, entries []draftPushEntry) []string {
+	pushed := make([]string, 0, len(drafts))
+	for i, d := range drafts {
+		entry := &entries[i]
+		if entry.Status != draftStatusPlanned {
+			continue
+		}
+
+		if d.Kind == draft.KindReply {
+			reply, err := replies.Reply(pr, comments.ReplyOptions{ThreadID: d.ThreadID, ReviewID: reviewID, Body: d.Body})
+			if err != nil {
+				entry.Status, entry.Error = draftStatusFailed, err.Error()
+				continue
+			}
+			entry.CommentNodeID = reply.CommentNodeID
+		} else {
+			input := reviewsvc.ThreadInput{
+				ReviewID: reviewID,
+				Path:     d.Path,
+				Line:     placements[i].Line,
+				Side:     d.Side,
+				Body:     d.Body,
+			}
+			if placements[i].StartLine > 0 {
+				input.StartLine = intPtr(placements[i].StartLine)
+			}
+			if d.StartSide != "" {
+				startSide := d.StartSide
+				input.StartSide = &startSide
+			}
+			thread, err := reviews.AddThread(pr, input)
+			if err != nil {
+				entry.Status, entry.Error = draftStatusFailed, err.Error()
+				continue
+			}
+			entry.ThreadNodeID = thread.ID
+		}
+		entry.Status = draftStatusPushed
+		pushed = append(pushed, d.ID)
+	}
+	return pushed
+}
+
+func countDraftStatus(entries []draftPushEntry, status string) int {
+	count := 0
+	for _, entry := range entries {
+		if entry.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/draft"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useTempDraftStore(t *testing.T) *draft.Store {
+	t.Helper()
+	original := draftStoreFactory
+	store := draft.NewStore(t.TempDir())
+	draftStoreFactory = func() (*draft.Store, error) { return store, nil }
+	t.Cleanup(func() { draftStoreFactory = original })
+	return store
+}
+
+func runDraftCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(append([]string{"review", "draft"}, args...))
+	err := root.Execute()
+	return stdout.String(), err
+}
+
+func TestReviewDraftAddListEditRemove(t *testing.T) {
+	useTempDraftStore(t)
+	checkout := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(checkout, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o600))
+
+	out, err := runDraftCommand(t, "add", "--pr", "7", "-R", "octo/demo", "--checkout", checkout,
+		"--path", "main.go", "--line", "3", "--body", "tighten this")
+	require.NoError(t, err)
+	var added draft.Draft
+	require.NoError(t, json.Unmarshal([]byte(out), &added))
+	assert.Equal(t, "1", added.ID)
+	assert.Equal(t, draft.KindThread, added.Kind)
+	assert.Equal(t, "RIGHT", added.Side)
+	require.NotNil(t, added.LineText)
+	assert.Equal(t, "func main() {}", *added.LineText)
+
+	_, err = runDraftCommand(t, "add", "--pr", "7", "-R", "octo/demo", "--thread-id", "PRRT_1", "--body", "agreed")
+	require.NoError(t, err)
+
+	_, err = runDraftCommand(t, "add", "--pr", "7", "-R", "octo/demo", "--thread-id", "PRRT_1", "--line", "3", "--body", "x")
+	require.EqualError(t, err, "--thread-id cannot be combined with --path, --line, or --start-line")
+
+	out, err = runDraftCommand(t, "edit", "1", "--pr", "7", "-R", "octo/demo", "--body", "tighten this loop")
+	require.NoError(t, err)
+	var edited draft.Draft
+	require.NoError(t, json.Unmarshal([]byte(out), &edited))
+	assert.Equal(t, "tighten this loop", edited.Body)
+	require.NotNil(t, edited.LineText, "line text is kept when the location is unchanged")
+
+	_, err = runDraftCommand(t, "edit", "2", "--pr", "7", "-R", "octo/demo", "--line", "4")
+	require.EqualError(t, err, "draft 2 is a reply; only --body and --thread-id can be changed")
+
+	out, err = runDraftCommand(t, "list", "--pr", "7", "-R", "octo/demo")
+	require.NoError(t, err)
+	var listed []draft.Draft
+	require.NoError(t, json.Unmarshal([]byte(out), &listed))
+	require.Len(t, listed, 2)
+	assert.Equal(t, "tighten this loop", listed[0].Body)
+	assert.Equal(t, draft.KindReply, listed[1].Kind)
+	assert.Equal(t, "PRRT_1", listed[1].ThreadID)
+
+	_, err = runDraftCommand(t, "rm", "2", "9", "--pr", "7", "-R", "octo/demo")
+	require.EqualError(t, err, "drafts not found: 9")
+
+	out, err = runDraftCommand(t, "rm", "1", "2", "--pr", "7", "-R", "octo/demo")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"removed":["1","2"]}`, out)
+
+	out, err = runDraftCommand(t, "list", "--pr", "7", "-R", "octo/demo")
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, out)
+}
+
+const draftPushPatch = "@@ -10,4 +10,6 @@ package main\n import \"fmt\"\n-var x = 1\n+var x = 2\n+\n+// greet prints a greeting.\n func greet() {\n \tfmt.Println(\"hi\")"
+
+func TestReviewDraftPushMovesAndKeepsInvalidDrafts(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+	useTempSessionStore(t)
+	store := useTempDraftStore(t)
+
+	checkout := t.TempDir()
+	source := "package main\n\n" + strings.Repeat("\n", 7) + "import \"fmt\"\nfunc greet() {\n"
+	require.NoError(t, os.WriteFile(filepath.Join(checkout, "main.go"), []byte(source), 0o600))
+
+	_, err := runDraftCommand(t, "add", "--pr", "7", "-R", "octo/demo", "--checkout", checkout,
+		"--path", "main.go", "--line", "11", "--body", "document greet")
+	require.NoError(t, err)
+	_, err = runDraftCommand(t, "add", "--pr", "7", "-R", "octo/demo", "--checkout", checkout,
+		"--path", "main.go", "--line", "40", "--body", "stale")
+	require.NoError(t, err)
+
+	var threads []map[string]interface{}
+	fake := &commandFakeAPI{}
+	fake.restFunc = func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		assert.Equal(t, "repos/octo/demo/pulls/7/files", path)
+		return assignJSON(result, []map[string]interface{}{{"filename": "main.go", "status": "modified", "patch": draftPushPatch}})
+	}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "headRefOid"):
+			return assignJSON(result, map[string]interface{}{
+				"viewer": map[string]interface{}{"login": "octocat"},
+				"repository": map[string]interface{}{
+					"pullRequest": map[string]interface{}{"id": "PR_node", "headRefOid": "abc123"},
+				},
+			})
+		case strings.Contains(query, "addPullRequestReview("):
+			return assignJSON(result, map[string]interface{}{
+				"addPullRequestReview": map[string]interface{}{
+					"pullRequestReview": map[string]interface{}{"id": "PRR_draft", "state": "PENDING"},
+				},
+			})
+		case strings.Contains(query, "addPullRequestReviewThread"):
+			input := variables["input"].(map[string]interface{})
+			threads = append(threads, input)
+			return assignJSON(result, map[string]interface{}{
+				"addPullRequestReviewThread": map[string]interface{}{
+					"thread": map[string]interface{}{"id": "PRRT_new", "path": "main.go", "isOutdated": false, "line": 14},
+				},
+			})
+		case strings.Contains(query, "submitPullRequestReview"):
+			t.Fatal("review must not be submitted while drafts remain")
+			return nil
+		default:
+			return errors.New("unexpected graphql invocation")
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	out, err := runDraftCommand(t, "push", "--pr", "7", "-R", "octo/demo", "--submit")
+	require.EqualError(t, err, "1 of 2 drafts were not pushed and remain stored locally")
+
+	var result draftPushResult
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, "PRR_draft", result.ReviewID)
+	assert.False(t, result.Submitted)
+	require.Len(t, result.Drafts, 2)
+	assert.Equal(t, draftStatusPushed, result.Drafts[0].Status)
+	assert.Equal(t, 14, *result.Drafts[0].Line)
+	assert.Equal(t, 11, *result.Drafts[0].MovedFrom)
+	assert.Equal(t, "PRRT_new", result.Drafts[0].ThreadNodeID)
+	assert.Equal(t, draftStatusInvalid, result.Drafts[1].Status)
+	assert.Equal(t, "line 40 of main.go is outside the diff", result.Drafts[1].Reason)
+
+	require.Len(t, threads, 1)
+	assert.Equal(t, "PRR_draft", threads[0]["pullRequestReviewId"])
+	assert.EqualValues(t, 14, threads[0]["line"])
+
+	set, err := store.Load(resolver.Identity{Host: "github.com", Owner: "octo", Repo: "demo", Number: 7})
+	require.NoError(t, err)
+	require.Len(t, set.Drafts, 1)
+	assert.Equal(t, "2", set.Drafts[0].ID)
+}
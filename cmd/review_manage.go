@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	reviewsvc "github.com/Agyn-sandbox/gh-pr-review/internal/review"
+)
+
+func newReviewDismissCommand() *cobra.Command {
+	opts := &reviewDismissOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "dismiss [<number> | <url>]",
+		Short: "Dismiss an approving or change-requesting review",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runReviewDismiss(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "Review to dismiss (GraphQL review node ID)")
+	cmd.Flags().StringVar(&opts.Message, "message", "", "Reason shown on the dismissed review (opens $EDITOR in a terminal when omitted)")
+	bindBodyFileFlag(cmd, "message", &opts.MessageFile)
+	bindRedactFlag(cmd)
+	_ = cmd.MarkFlagRequired("review-id")
+
+	return configure(cmd)
+}
+
+type reviewDismissOptions struct {
+	Repo        string
+	Pull        int
+	Selector    string
+	ReviewID    string
+	Message     string
+	MessageFile string
+}
+
+func runReviewDismiss(cmd *cobra.Command, opts *reviewDismissOptions) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+	identity, err := resolver.Resolve(selector, opts.Repo, defaultHost())
+	if err != nil {
+		return err
+	}
+	reviewID, err := ensureGraphQLReviewID(opts.ReviewID)
+	if err != nil {
+		return err
+	}
+
+	message, err := requireBody(cmd, "message", opts.Message, opts.MessageFile, func() []string {
+		return []string{"", fmt.Sprintf("Dismiss review %s on %s/%s#%d", reviewID, identity.Owner, identity.Repo, identity.Number)}
+	})
+	if err != nil {
+		return err
+	}
+
+	service := newReviewService(apiClientFactory(identity.Host))
+	dismissal, err := service.Dismiss(identity, reviewsvc.DismissInput{ReviewID: reviewID, Message: message})
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, dismissal)
+}
+
+func newReviewRequestCommand() *cobra.Command {
+	opts := &reviewRequestOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "request [<number> | <url>]",
+		Short: "Request or re-request reviews from users and teams",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runReviewRequest(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringSliceVar(&opts.Reviewers, "reviewer", nil, "Logins or org/team slugs to request (comma-separated or repeatable)")
+	cmd.Flags().BoolVar(&opts.ReRequest, "re-request", false, "Also request everyone who already reviewed, except the author and bots")
+
+	return configure(cmd)
+}
+
+type reviewRequestOptions struct {
+	Repo      string
+	Pull      int
+	Selector  string
+	Reviewers []string
+	ReRequest bool
+}
+
+func runReviewRequest(cmd *cobra.Command, opts *reviewRequestOptions) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+	identity, err := resolver.Resolve(selector, opts.Repo, defaultHost())
+	if err != nil {
+		return err
+	}
+
+	service := newReviewService(apiClientFactory(identity.Host))
+	requests, err := service.RequestReviews(identity, reviewsvc.RequestInput{Reviewers: opts.Reviewers, ReRequest: opts.ReRequest})
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, requests)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
@@ -281,3 +282,50 @@ func TestReviewSubmitCommandHandlesGraphQLErrors(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, "mutation failed", first["message"])
 }
+
+func TestReviewDismissAndRequestCommands(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "DismissTarget"):
+			return assignJSON(result, obj{"node": obj{"id": "PRR_1", "state": "APPROVED", "pullRequest": obj{"number": 7, "repository": obj{
+				"name": "demo", "owner": obj{"login": "octo"}, "viewerPermission": "MAINTAIN",
+			}}}})
+		case strings.Contains(query, "DismissPullRequestReview"):
+			input := variables["input"].(map[string]interface{})
+			require.Equal(t, "Head changed", input["message"])
+			return assignJSON(result, obj{"dismissPullRequestReview": obj{"pullRequestReview": obj{"id": "PRR_1", "state": "DISMISSED", "author": obj{"login": "alice"}}}})
+		case strings.Contains(query, "ReviewRequestTarget"):
+			return assignJSON(result, obj{"repository": obj{"viewerPermission": "WRITE", "pullRequest": obj{"id": "PR_7", "reviews": obj{"nodes": []obj{}}}}})
+		case strings.Contains(query, "ReviewerUser"):
+			return assignJSON(result, obj{"user": obj{"id": "U_alice"}})
+		case strings.Contains(query, "RequestReviews"):
+			return assignJSON(result, obj{"requestReviews": obj{"pullRequest": obj{"reviewRequests": obj{"nodes": []obj{
+				{"requestedReviewer": obj{"__typename": "User", "login": "alice"}},
+			}}}}})
+		default:
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	run := func(args ...string) string {
+		root := newRootCommand()
+		stdout := &bytes.Buffer{}
+		root.SetOut(stdout)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(args)
+		require.NoError(t, root.Execute())
+		return stdout.String()
+	}
+
+	out := run("review", "dismiss", "--review-id", "PRR_1", "--message", "Head changed", "--repo", "octo/demo", "7")
+	assert.JSONEq(t, `{"id":"PRR_1","state":"DISMISSED","author_login":"alice","message":"Head changed"}`, out)
+
+	out = run("review", "request", "--reviewer", "alice", "--repo", "octo/demo", "7")
+	assert.JSONEq(t, `{"requested":["alice"],"review_requests":[{"reviewer":"alice","type":"user"}]}`, out)
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"sort"
 	"strings"
 
@@ -30,13 +29,18 @@ func newReviewViewCommand() *cobra.Command {
 	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
 	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
 	cmd.Flags().StringVar(&opts.Reviewer, "reviewer", "", "Filter to a specific reviewer (login)")
-	cmd.Flags().StringSliceVar(&opts.States, "states", nil, "Comma-separated review states (APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED)")
+	cmd.Flags().StringSliceVar(&opts.States, "states", nil, "Comma-separated review states (APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED, PENDING)")
 	cmd.Flags().BoolVar(&opts.Unresolved, "unresolved", false, "Only include unresolved threads")
 	cmd.Flags().BoolVar(&opts.NotOutdated, "not_outdated", false, "Exclude outdated threads")
 	cmd.Flags().IntVar(&opts.TailReplies, "tail", 0, "Limit to the last N replies per thread (0 = all)")
 	cmd.Flags().BoolVar(&opts.IncludeCommentNodeID, "include-comment-node-id", false, "Include comment_node_id fields for parent comments and replies")
+	cmd.Flags().BoolVar(&opts.IncludePullRequest, "include-pull-request", false, "Include a pull_request section with title, description, refs, head SHA, mergeability, and labels")
+	cmd.Flags().BoolVar(&opts.IncludeConversation, "include-conversation", false, "Include top-level conversation comments in a conversation section")
+	cmd.Flags().BoolVar(&opts.IncludeReactions, "include-reactions", false, "Include reaction counts and whether you reacted on parent comments and replies")
+	cmd.Flags().BoolVar(&opts.HideMinimized, "hide-minimized", false, "Drop minimized replies and threads whose parent comment is minimized")
+	bindSeenFlags(cmd, &opts.seenFlags)
 
-	return cmd
+	return configure(cmd)
 }
 
 type reviewViewOptions struct {
@@ -49,11 +53,17 @@ type reviewViewOptions struct {
 	NotOutdated          bool
 	TailReplies          int
 	IncludeCommentNodeID bool
+	IncludePullRequest   bool
+	IncludeConversation  bool
+	IncludeReactions     bool
+	HideMinimized        bool
+	seenFlags
 }
 
 func runReviewView(cmd *cobra.Command, opts *reviewViewOptions) error {
-	if opts.TailReplies < 0 {
-		return fmt.Errorf("invalid --tail value %d: must be non-negative", opts.TailReplies)
+	reportOpts, err := opts.reportOptions()
+	if err != nil {
+		return err
 	}
 
 	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
@@ -61,31 +71,53 @@ func runReviewView(cmd *cobra.Command, opts *reviewViewOptions) error {
 		return err
 	}
 
-	states, statesProvided, err := parseStateFilters(opts.States)
+	identity, err := resolver.Resolve(selector, opts.Repo, defaultHost())
 	if err != nil {
 		return err
 	}
 
-	identity, err := resolver.Resolve(selector, opts.Repo, os.Getenv("GH_HOST"))
+	store, markers, err := opts.lastSeen(identity)
 	if err != nil {
 		return err
 	}
+	reportOpts.LastSeen = markers
 
 	service := report.NewService(apiClientFactory(identity.Host))
-	output, err := service.Fetch(identity, report.Options{
-		Reviewer:             strings.TrimSpace(opts.Reviewer),
-		States:               states,
-		StatesProvided:       statesProvided,
-		RequireUnresolved:    opts.Unresolved,
-		RequireNotOutdated:   opts.NotOutdated,
-		TailReplies:          opts.TailReplies,
-		IncludeCommentNodeID: opts.IncludeCommentNodeID,
-	})
+	output, err := service.Fetch(identity, reportOpts)
 	if err != nil {
 		return err
 	}
 
-	return encodeJSON(cmd, output)
+	if err := encodeJSON(cmd, output); err != nil {
+		return err
+	}
+	return opts.markSeen(store, identity, output.ThreadActivity())
+}
+
+// reportOptions validates the filter flags and converts them into service options.
+func (o *reviewViewOptions) reportOptions() (report.Options, error) {
+	if o.TailReplies < 0 {
+		return report.Options{}, fmt.Errorf("invalid --tail value %d: must be non-negative", o.TailReplies)
+	}
+
+	states, statesProvided, err := parseStateFilters(o.States)
+	if err != nil {
+		return report.Options{}, err
+	}
+
+	return report.Options{
+		Reviewer:             strings.TrimSpace(o.Reviewer),
+		States:               states,
+		StatesProvided:       statesProvided,
+		RequireUnresolved:    o.Unresolved,
+		RequireNotOutdated:   o.NotOutdated,
+		TailReplies:          o.TailReplies,
+		IncludeCommentNodeID: o.IncludeCommentNodeID,
+		IncludePullRequest:   o.IncludePullRequest,
+		IncludeConversation:  o.IncludeConversation,
+		IncludeReactions:     o.IncludeReactions,
+		HideMinimized:        o.HideMinimized,
+	}, nil
 }
 
 func parseStateFilters(raw []string) ([]report.State, bool, error) {
@@ -98,6 +130,7 @@ func parseStateFilters(raw []string) ([]report.State, bool, error) {
 		"CHANGES_REQUESTED": report.StateChangesRequested,
 		"COMMENTED":         report.StateCommented,
 		"DISMISSED":         report.StateDismissed,
+		"PENDING":           report.StatePending,
 	}
 	allowed := make([]string, 0, len(valid))
 	for key := range valid {
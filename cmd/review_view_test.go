@@ -105,6 +105,27 @@ func TestReviewViewCommandInvalidState(t *testing.T) {
 	}
 }
 
+func TestReviewViewCommandAcceptsPendingState(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--states", "pending", "51"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+	rawStates, ok := fake.variables["states"].([]string)
+	if !ok || len(rawStates) != 1 || rawStates[0] != "PENDING" {
+		t.Fatalf("expected PENDING state propagated, got %#v", fake.variables["states"])
+	}
+}
+
 func TestReviewViewCommandIncludesCommentNodeID(t *testing.T) {
 	originalFactory := apiClientFactory
 	defer func() { apiClientFactory = originalFactory }()
@@ -149,6 +170,42 @@ func TestReviewViewCommandIncludesCommentNodeID(t *testing.T) {
 	}
 }
 
+func TestReviewViewCommandIncludesPullRequest(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--include-pull-request", "51"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+
+	if fake.variables["includePullRequest"] != true {
+		t.Fatalf("expected includePullRequest variable, got %#v", fake.variables)
+	}
+	if _, ok := fake.variables["includeConversation"]; ok {
+		t.Fatalf("expected includeConversation unset, got %#v", fake.variables)
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("parse json: %v", err)
+	}
+	if _, ok := payload["pull_request"]; !ok {
+		t.Fatalf("expected pull_request section, got keys %v", payload)
+	}
+	if _, ok := payload["conversation"]; ok {
+		t.Fatal("expected conversation section omitted")
+	}
+}
+
 type fakeViewAPI struct {
 	t         *testing.T
 	payload   []byte
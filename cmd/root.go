@@ -5,6 +5,8 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/config"
 )
 
 // Execute sets up the root command tree and executes it.
@@ -21,9 +23,20 @@ func newRootCommand() *cobra.Command {
 		SilenceErrors: true,
 	}
 
+	cmd.PersistentFlags().String("profile", "", "Config profile to apply (defaults to $"+config.EnvProfile+")")
+	cmd.PersistentFlags().String("output", "", "Output format: json (compact) or pretty (indented); defaults to the config file or $"+config.EnvOutput)
+
 	cmd.AddCommand(newCommentsCommand())
 	cmd.AddCommand(newReviewCommand())
 	cmd.AddCommand(newThreadsCommand())
+	cmd.AddCommand(newStatusCommand())
+	cmd.AddCommand(newInboxCommand())
+	cmd.AddCommand(newStatsCommand())
+	cmd.AddCommand(newWatchCommand())
+	cmd.AddCommand(newServeWebhooksCommand())
+	cmd.AddCommand(newMCPCommand())
+	cmd.AddCommand(newApplyCommand())
+	cmd.AddCommand(newTemplatesCommand())
 
 	return cmd
 }
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/comments"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/config"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	reviewsvc "github.com/Agyn-sandbox/gh-pr-review/internal/review"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/secrets"
+)
+
+// activeScanner checks bodies before the running command posts them. It is
+// built from activeSettings; nil disables scanning.
+var activeScanner *secrets.Scanner
+
+// bindRedactFlag registers --redact on commands that post bodies.
+func bindRedactFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("redact", false, "Replace secrets found in bodies with [REDACTED:<rule>] instead of refusing to post")
+}
+
+// bodyScanner returns the scanner for the scan settings, or nil when scanning
+// is disabled.
+func bodyScanner(scan config.Scan) (*secrets.Scanner, error) {
+	if scan.Enabled != nil && !*scan.Enabled {
+		return nil, nil
+	}
+	return secrets.New(scan.Patterns, scan.Redact != nil && *scan.Redact)
+}
+
+// newReviewService returns a review service that scans bodies before posting.
+func newReviewService(api ghcli.API) *reviewsvc.Service {
+	service := reviewsvc.NewService(api)
+	service.Secrets = activeScanner
+	return service
+}
+
+// newCommentsService returns a comments service that scans bodies before
+// posting.
+func newCommentsService(api ghcli.API) *comments.Service {
+	service := comments.NewService(api)
+	service.Secrets = activeScanner
+	return service
+}
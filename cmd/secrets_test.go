@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+)
+
+func TestCommentsReplyScansBodies(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	token := "ghp_" + strings.Repeat("x9Y8", 9)
+	var posted []string
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "AddPullRequestReviewThreadReply"):
+			input := variables["input"].(map[string]interface{})
+			posted = append(posted, input["body"].(string))
+			return assignJSON(result, obj{"addPullRequestReviewThreadReply": obj{"comment": obj{"id": "PRRC_reply", "author": obj{"login": "octocat"}}}})
+		case strings.Contains(query, "PullRequestReviewCommentDetails"):
+			return assignJSON(result, obj{"node": obj{"id": "PRRC_reply", "createdAt": "2025-12-03T10:00:00Z", "updatedAt": "2025-12-03T10:00:00Z", "author": obj{"login": "octocat"}}})
+		case strings.Contains(query, "PullRequestReviewThreadDetails"):
+			return assignJSON(result, obj{"node": obj{"id": "PRRT_thread"}})
+		default:
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	reply := func(extra ...string) error {
+		args := append([]string{"comments", "reply", "--thread-id", "PRRT_thread", "--body", "log:\n  token " + token + " ticket SEC-7", "--repo", "octo/demo", "7"}, extra...)
+		return runReviewArgs(args...)
+	}
+
+	useConfigFiles(t, "scan:\n  patterns:\n    ticket: 'SEC-[0-9]+'\n")
+	err := reply()
+	require.EqualError(t, err, "body contains 2 possible secret(s); remove them or pass --redact:\n"+
+		"  github-token at line 2, column 9 (ghp_…)\n"+
+		"  ticket at line 2, column 57 (SEC-…)")
+	assert.Empty(t, posted)
+
+	require.NoError(t, reply("--redact"))
+	require.Equal(t, []string{"log:\n  token [REDACTED:github-token] ticket [REDACTED:ticket]"}, posted)
+
+	useConfigFiles(t, "scan:\n  enabled: false\n")
+	require.NoError(t, reply())
+	assert.Contains(t, posted[1], token)
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/seen"
+)
+
+// seenFlags holds the read-marker flags shared by review view and threads list.
+type seenFlags struct {
+	NewSinceLastSeen bool
+	MarkSeen         bool
+}
+
+func bindSeenFlags(cmd *cobra.Command, f *seenFlags) {
+	cmd.Flags().BoolVar(&f.NewSinceLastSeen, "new-since-last-seen", false, "Only threads with comment activity since they were last marked seen")
+	cmd.Flags().BoolVar(&f.MarkSeen, "mark-seen", false, "Record the returned threads as seen in the local read-marker store")
+}
+
+// lastSeen opens the read-marker store when either flag is set. The returned
+// markers are nil unless --new-since-last-seen asks for filtering.
+func (f *seenFlags) lastSeen(pr resolver.Identity) (*seen.Store, seen.Markers, error) {
+	if !f.NewSinceLastSeen && !f.MarkSeen {
+		return nil, nil, nil
+	}
+	store, err := seenStoreFactory()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !f.NewSinceLastSeen {
+		return store, nil, nil
+	}
+	markers, err := store.Load(pr)
+	if err != nil {
+		return nil, nil, err
+	}
+	return store, markers, nil
+}
+
+// markSeen records thread activity when --mark-seen was requested.
+func (f *seenFlags) markSeen(store *seen.Store, pr resolver.Identity, activity map[string]time.Time) error {
+	if !f.MarkSeen || store == nil {
+		return nil
+	}
+	return store.Mark(pr, seen.Markers(activity))
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/seen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThreadsListNewSinceLastSeen(t *testing.T) {
+	originalFactory := apiClientFactory
+	originalStore := seenStoreFactory
+	defer func() {
+		apiClientFactory = originalFactory
+		seenStoreFactory = originalStore
+	}()
+
+	store := seen.NewStore(t.TempDir())
+	seenStoreFactory = func() (*seen.Store, error) { return store, nil }
+
+	updatedAt := time.Date(2025, 12, 2, 15, 0, 0, 0, time.UTC)
+	fake := &commandFakeAPI{}
+	fake.restFunc = func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		switch path {
+		case "repos/octo/demo":
+			return assignJSON(result, map[string]interface{}{"full_name": "octo/demo"})
+		case "repos/octo/demo/pulls/5":
+			return assignJSON(result, map[string]interface{}{"node_id": "PR_node"})
+		default:
+			return errors.New("unexpected path")
+		}
+	}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		if !strings.Contains(query, "reviewThreads") {
+			return errors.New("unexpected query")
+		}
+		return assignJSON(result, map[string]interface{}{
+			"node": map[string]interface{}{
+				"reviewThreads": map[string]interface{}{
+					"nodes": []map[string]interface{}{{
+						"id":       "T_node",
+						"path":     "main.go",
+						"comments": map[string]interface{}{"nodes": []map[string]interface{}{{"updatedAt": updatedAt}}},
+					}},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				},
+			},
+		})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	run := func() []map[string]interface{} {
+		root := newRootCommand()
+		stdout := &bytes.Buffer{}
+		root.SetOut(stdout)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs([]string{"threads", "list", "--new-since-last-seen", "--mark-seen", "--repo", "octo/demo", "5"})
+		require.NoError(t, root.Execute())
+
+		var payload []map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+		return payload
+	}
+
+	first := run()
+	require.Len(t, first, 1)
+	assert.Equal(t, "T_node", first[0]["threadId"])
+
+	assert.Empty(t, run())
+
+	updatedAt = updatedAt.Add(time.Minute)
+	assert.Len(t, run(), 1)
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/webhook"
+)
+
+const (
+	webhookSecretEnv    = "GH_PR_REVIEW_WEBHOOK_SECRET"
+	webhookHookQueue    = 256
+	webhookShutdownWait = 10 * time.Second
+)
+
+func newServeWebhooksCommand() *cobra.Command {
+	opts := &serveWebhooksOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "serve-webhooks",
+		Short: "Receive GitHub review webhooks and emit normalized events",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServeWebhooks(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Addr, "addr", ":8080", "Address to listen on")
+	cmd.Flags().StringVar(&opts.Path, "path", "/", "URL path that receives deliveries")
+	cmd.Flags().StringVar(&opts.Secret, "secret", "", "Webhook secret used to verify X-Hub-Signature-256 (default $"+webhookSecretEnv+")")
+	cmd.Flags().StringVar(&opts.Hook, "hook", "", "Shell command to run per event (event JSON on stdin) instead of printing NDJSON")
+	cmd.Flags().DurationVar(&opts.HookTimeout, "hook-timeout", 30*time.Second, "Maximum run time of a single hook invocation (0 = no limit)")
+
+	return configure(cmd)
+}
+
+type serveWebhooksOptions struct {
+	Addr        string
+	Path        string
+	Secret      string
+	Hook        string
+	HookTimeout time.Duration
+}
+
+func runServeWebhooks(cmd *cobra.Command, opts *serveWebhooksOptions) error {
+	secret := opts.Secret
+	if secret == "" {
+		secret = os.Getenv(webhookSecretEnv)
+	}
+	if secret == "" {
+		return fmt.Errorf("--secret (or $%s) is required", webhookSecretEnv)
+	}
+	if !strings.HasPrefix(opts.Path, "/") {
+		return fmt.Errorf("invalid --path value %q: must start with /", opts.Path)
+	}
+	if opts.HookTimeout < 0 {
+		return fmt.Errorf("invalid --hook-timeout value %s: must be non-negative", opts.HookTimeout)
+	}
+
+	stderr := cmd.ErrOrStderr()
+	var sink func(webhook.Event) error
+	if hook := strings.TrimSpace(opts.Hook); hook != "" {
+		runner := webhook.NewHookRunner(hook, opts.HookTimeout, webhookHookQueue, stderr, stderr)
+		defer runner.Close()
+		sink = runner.Enqueue
+	} else {
+		var mu sync.Mutex
+		sink = func(event webhook.Event) error {
+			mu.Lock()
+			defer mu.Unlock()
+			return encodeJSONLine(cmd, event)
+		}
+	}
+
+	handler := webhook.NewHandler([]byte(secret), sink)
+	handler.Logf = func(format string, args ...interface{}) {
+		fmt.Fprintf(stderr, format+"\n", args...)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(opts.Path, handler)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	listener, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "listening for webhooks on %s%s\n", listener.Addr(), opts.Path)
+
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- server.Serve(listener) }()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), webhookShutdownWait)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeWebhooksRequiresSecret(t *testing.T) {
+	t.Setenv(webhookSecretEnv, "")
+
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"serve-webhooks", "--addr", "127.0.0.1:0"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--secret (or $GH_PR_REVIEW_WEBHOOK_SECRET) is required")
+}
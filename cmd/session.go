@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	reviewsvc "github.com/Agyn-sandbox/gh-pr-review/internal/review"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/session"
+)
+
+// recordSession stores a freshly started review as the viewer's active pending
+// review. Reviews whose viewer could not be determined are not recorded.
+func recordSession(pr resolver.Identity, state *reviewsvc.ReviewState) error {
+	if state == nil || state.Viewer() == "" {
+		return nil
+	}
+	store, err := sessionStoreFactory()
+	if err != nil {
+		return err
+	}
+	return store.Save(pr, session.Session{
+		ReviewID:  state.ID,
+		Viewer:    state.Viewer(),
+		StartedAt: time.Now().UTC(),
+	})
+}
+
+// sessionReviewID returns the pending review recorded for the viewer, or ""
+// when there is none. A recorded review that was deleted or is no longer
+// PENDING is cleared and reported as an error.
+func sessionReviewID(service *reviewsvc.Service, pr resolver.Identity) (string, error) {
+	store, viewer, current, err := loadSession(service, pr)
+	if err != nil || current == nil {
+		return "", err
+	}
+
+	status, err := service.ReviewStatus(current.ReviewID)
+	if err != nil {
+		return "", fmt.Errorf("check pending review %s from the local session: %w", current.ReviewID, err)
+	}
+	if status == nil {
+		_ = store.Clear(pr, viewer)
+		return "", fmt.Errorf("pending review %s from the local session no longer exists; the session was cleared, run `review --start` to open a new review or pass --review-id", current.ReviewID)
+	}
+	if !strings.EqualFold(status.State, "PENDING") {
+		_ = store.Clear(pr, viewer)
+		return "", fmt.Errorf("review %s from the local session is %s, not PENDING; the session was cleared, run `review --start` to open a new review or pass --review-id", current.ReviewID, status.State)
+	}
+	return current.ReviewID, nil
+}
+
+// sessionReviewKeyword is the --review-id value that opts in to the pending
+// review recorded in the local session, for commands that otherwise post
+// immediately.
+const sessionReviewKeyword = "session"
+
+// expandSessionReviewID resolves --review-id session to the recorded pending
+// review; any other value is returned unchanged.
+func expandSessionReviewID(service *reviewsvc.Service, pr resolver.Identity, reviewID string) (string, error) {
+	if !strings.EqualFold(strings.TrimSpace(reviewID), sessionReviewKeyword) {
+		return reviewID, nil
+	}
+	recorded, err := sessionReviewID(service, pr)
+	if err != nil {
+		return "", err
+	}
+	if recorded == "" {
+		return "", errors.New("no pending review is recorded in the local session; run `review --start` first")
+	}
+	return recorded, nil
+}
+
+// clearSession forgets the viewer's session when it refers to reviewID.
+func clearSession(service *reviewsvc.Service, pr resolver.Identity, reviewID string) error {
+	store, viewer, current, err := loadSession(service, pr)
+	if err != nil || current == nil || current.ReviewID != reviewID {
+		return err
+	}
+	return store.Clear(pr, viewer)
+}
+
+// loadSession returns the viewer's session. The viewer login is only looked
+// up when some session exists for the pull request.
+func loadSession(service *reviewsvc.Service, pr resolver.Identity) (*session.Store, string, *session.Session, error) {
+	store, err := sessionStoreFactory()
+	if err != nil {
+		return nil, "", nil, err
+	}
+	empty, err := store.Empty(pr)
+	if err != nil || empty {
+		return store, "", nil, err
+	}
+	viewer, err := service.ViewerLogin()
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("resolve viewer for review session: %w", err)
+	}
+	current, err := store.Load(pr, viewer)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return store, viewer, current, nil
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useTempSessionStore(t *testing.T) *session.Store {
+	t.Helper()
+	original := sessionStoreFactory
+	store := session.NewStore(t.TempDir())
+	sessionStoreFactory = func() (*session.Store, error) { return store, nil }
+	t.Cleanup(func() { sessionStoreFactory = original })
+	return store
+}
+
+func sessionFakeAPI(t *testing.T, reviewState string, calls *[]string) *commandFakeAPI {
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "headRefOid"):
+			*calls = append(*calls, "pull")
+			return assignJSON(result, map[string]interface{}{
+				"viewer": map[string]interface{}{"login": "octocat"},
+				"repository": map[string]interface{}{
+					"pullRequest": map[string]interface{}{"id": "PR_node", "headRefOid": "abc123"},
+				},
+			})
+		case strings.Contains(query, "addPullRequestReview("):
+			*calls = append(*calls, "start")
+			return assignJSON(result, map[string]interface{}{
+				"addPullRequestReview": map[string]interface{}{
+					"pullRequestReview": map[string]interface{}{"id": "PRR_session", "state": "PENDING"},
+				},
+			})
+		case strings.Contains(query, "ViewerLogin"):
+			*calls = append(*calls, "viewer")
+			return assignJSON(result, map[string]interface{}{"viewer": map[string]interface{}{"login": "octocat"}})
+		case strings.Contains(query, "ReviewStatus"):
+			*calls = append(*calls, "status")
+			assert.Equal(t, "PRR_session", variables["id"])
+			return assignJSON(result, map[string]interface{}{
+				"node": map[string]interface{}{"id": "PRR_session", "state": reviewState},
+			})
+		case strings.Contains(query, "addPullRequestReviewThread"):
+			*calls = append(*calls, "comment")
+			input := variables["input"].(map[string]interface{})
+			assert.Equal(t, "PRR_session", input["pullRequestReviewId"])
+			return assignJSON(result, map[string]interface{}{
+				"addPullRequestReviewThread": map[string]interface{}{
+					"thread": map[string]interface{}{"id": "PRRT_1", "path": "main.go", "isOutdated": false, "line": 3},
+				},
+			})
+		case strings.Contains(query, "submitPullRequestReview"):
+			*calls = append(*calls, "submit")
+			input := variables["input"].(map[string]interface{})
+			assert.Equal(t, "PRR_session", input["pullRequestReviewId"])
+			return nil
+		default:
+			return errors.New("unexpected graphql invocation")
+		}
+	}
+	return fake
+}
+
+func runReviewArgs(args ...string) error {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(args)
+	return root.Execute()
+}
+
+func TestReviewSessionSuppliesReviewID(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+	store := useTempSessionStore(t)
+
+	var calls []string
+	fake := sessionFakeAPI(t, "PENDING", &calls)
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	require.NoError(t, runReviewArgs("review", "--start", "--repo", "octo/demo", "7"))
+	pr := resolver.Identity{Host: "github.com", Owner: "octo", Repo: "demo", Number: 7}
+	recorded, err := store.Load(pr, "octocat")
+	require.NoError(t, err)
+	require.NotNil(t, recorded)
+	assert.Equal(t, "PRR_session", recorded.ReviewID)
+
+	require.NoError(t, runReviewArgs("review", "--add-comment", "--path", "main.go", "--line", "3", "--body", "nit", "--repo", "octo/demo", "7"))
+	require.NoError(t, runReviewArgs("review", "--submit", "--repo", "octo/demo", "7"))
+	assert.Equal(t, []string{"pull", "start", "viewer", "status", "comment", "viewer", "status", "submit", "viewer"}, calls)
+
+	empty, err := store.Empty(pr)
+	require.NoError(t, err)
+	assert.True(t, empty)
+}
+
+func TestReviewSessionRejectsSubmittedReview(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+	store := useTempSessionStore(t)
+
+	pr := resolver.Identity{Host: "github.com", Owner: "octo", Repo: "demo", Number: 7}
+	require.NoError(t, store.Save(pr, session.Session{ReviewID: "PRR_session", Viewer: "octocat"}))
+
+	var calls []string
+	fake := sessionFakeAPI(t, "APPROVED", &calls)
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	err := runReviewArgs("review", "--add-comment", "--path", "main.go", "--line", "3", "--body", "nit", "--repo", "octo/demo", "7")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "review PRR_session from the local session is APPROVED, not PENDING")
+	assert.NotContains(t, calls, "comment")
+
+	empty, err := store.Empty(pr)
+	require.NoError(t, err)
+	assert.True(t, empty)
+}
+
+func TestCommentsReplyUsesSessionOnlyWhenRequested(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+	store := useTempSessionStore(t)
+
+	pr := resolver.Identity{Host: "github.com", Owner: "octo", Repo: "demo", Number: 7}
+	require.NoError(t, store.Save(pr, session.Session{ReviewID: "PRR_session", Viewer: "octocat"}))
+
+	var calls []string
+	var reviewIDs []interface{}
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "ViewerLogin"):
+			calls = append(calls, "viewer")
+			return assignJSON(result, map[string]interface{}{"viewer": map[string]interface{}{"login": "octocat"}})
+		case strings.Contains(query, "ReviewStatus"):
+			calls = append(calls, "status")
+			return assignJSON(result, map[string]interface{}{"node": map[string]interface{}{"id": "PRR_session", "state": "PENDING"}})
+		case strings.Contains(query, "AddPullRequestReviewThreadReply"):
+			calls = append(calls, "reply")
+			reviewIDs = append(reviewIDs, variables["input"].(map[string]interface{})["pullRequestReviewId"])
+			return assignJSON(result, map[string]interface{}{
+				"addPullRequestReviewThreadReply": map[string]interface{}{
+					"comment": map[string]interface{}{"id": "PRRC_reply", "body": "ack", "author": map[string]interface{}{"login": "octocat"}},
+				},
+			})
+		case strings.Contains(query, "PullRequestReviewCommentDetails"):
+			return assignJSON(result, map[string]interface{}{"node": map[string]interface{}{
+				"id": "PRRC_reply", "databaseId": 101, "body": "ack", "path": "main.go",
+				"createdAt": "2025-12-03T10:00:00Z", "author": map[string]interface{}{"login": "octocat"},
+			}})
+		case strings.Contains(query, "PullRequestReviewThreadDetails"):
+			return assignJSON(result, map[string]interface{}{"node": map[string]interface{}{"id": "PRRT_thread"}})
+		default:
+			return errors.New("unexpected graphql invocation")
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	// Without --review-id the reply is published immediately, session or not.
+	require.NoError(t, runReviewArgs("comments", "reply", "--thread-id", "PRRT_thread", "--body", "ack", "--repo", "octo/demo", "7"))
+	assert.Equal(t, []string{"reply"}, calls)
+	assert.Equal(t, []interface{}{nil}, reviewIDs)
+
+	calls, reviewIDs = nil, nil
+	require.NoError(t, runReviewArgs("comments", "reply", "--thread-id", "PRRT_thread", "--review-id", "session", "--body", "ack", "--repo", "octo/demo", "7"))
+	assert.Equal(t, []string{"viewer", "status", "reply"}, calls)
+	assert.Equal(t, []interface{}{"PRR_session"}, reviewIDs)
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/stats"
+)
+
+// CSV row sets accepted by stats --csv.
+const (
+	statsCSVPulls     = "pulls"
+	statsCSVReviewers = "reviewers"
+)
+
+func newStatsCommand() *cobra.Command {
+	opts := &statsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Compute review metrics for a repository's recent pull requests",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStats(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().StringVar(&opts.Since, "since", "30d", "Include pull requests created since (RFC3339, YYYY-MM-DD, or age like 30d)")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", stats.DefaultConcurrency, "Maximum number of pull requests fetched concurrently")
+	cmd.Flags().BoolVar(&opts.NoCache, "no-cache", false, "Refetch merged pull requests instead of reusing cached reviews and threads")
+	cmd.Flags().StringVar(&opts.CSV, "csv", "", "Print CSV rows instead of JSON: pulls or reviewers")
+
+	return configure(cmd)
+}
+
+type statsOptions struct {
+	Repo        string
+	Since       string
+	Concurrency int
+	NoCache     bool
+	CSV         string
+}
+
+func runStats(cmd *cobra.Command, opts *statsOptions) error {
+	owner, name, ok := strings.Cut(strings.TrimSpace(opts.Repo), "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return errors.New("--repo must be owner/repo")
+	}
+	since, err := parseTimeFlag("since", opts.Since)
+	if err != nil {
+		return err
+	}
+	if since == nil {
+		return errors.New("--since is required")
+	}
+	if opts.Concurrency <= 0 {
+		return fmt.Errorf("invalid --concurrency value %d: must be positive", opts.Concurrency)
+	}
+	switch opts.CSV {
+	case "", statsCSVPulls, statsCSVReviewers:
+	default:
+		return fmt.Errorf("invalid --csv value %q: must be %s or %s", opts.CSV, statsCSVPulls, statsCSVReviewers)
+	}
+
+	options := stats.Options{Since: *since, Concurrency: opts.Concurrency}
+	if !opts.NoCache {
+		cache, err := statsCacheFactory()
+		if err != nil {
+			return err
+		}
+		options.Cache = cache
+	}
+
+	repo := resolver.Identity{Owner: owner, Repo: name, Host: defaultHost()}
+	service := stats.NewService(apiClientFactory(repo.Host))
+	service.Now = timeNow
+	result, err := service.Compute(repo, options)
+	if err != nil {
+		return err
+	}
+
+	switch opts.CSV {
+	case statsCSVPulls:
+		return writeStatsPullsCSV(cmd, result.Pulls)
+	case statsCSVReviewers:
+		return writeStatsReviewersCSV(cmd, result.Reviewers)
+	}
+	return encodeJSON(cmd, result)
+}
+
+func writeStatsPullsCSV(cmd *cobra.Command, pulls []stats.PullStats) error {
+	w := csv.NewWriter(cmd.OutOrStdout())
+	_ = w.Write([]string{
+		"number", "title", "state", "author_login", "created_at", "merged_at",
+		"time_to_first_review_hours", "time_to_approval_hours", "review_rounds",
+		"threads", "resolved_threads", "outdated_threads", "unresolved_threads",
+	})
+	for _, pull := range pulls {
+		_ = w.Write([]string{
+			strconv.Itoa(pull.Number), pull.Title, pull.State, pull.AuthorLogin, pull.CreatedAt, pull.MergedAt,
+			csvFloat(pull.TimeToFirstReviewHours), csvFloat(pull.TimeToApprovalHours), strconv.Itoa(pull.ReviewRounds),
+			strconv.Itoa(pull.Threads), strconv.Itoa(pull.ResolvedThreads), strconv.Itoa(pull.OutdatedThreads), strconv.Itoa(pull.UnresolvedThreads),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func writeStatsReviewersCSV(cmd *cobra.Command, reviewers []stats.ReviewerStats) error {
+	w := csv.NewWriter(cmd.OutOrStdout())
+	_ = w.Write([]string{
+		"login", "pull_requests", "reviews", "approvals", "changes_requested",
+		"threads", "resolved_threads", "outdated_threads", "resolution_rate",
+	})
+	for _, reviewer := range reviewers {
+		_ = w.Write([]string{
+			reviewer.Login, strconv.Itoa(reviewer.PullRequests), strconv.Itoa(reviewer.Reviews),
+			strconv.Itoa(reviewer.Approvals), strconv.Itoa(reviewer.ChangesRequested),
+			strconv.Itoa(reviewer.Threads), strconv.Itoa(reviewer.ResolvedThreads), strconv.Itoa(reviewer.OutdatedThreads),
+			csvFloat(reviewer.ResolutionRate),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// csvFloat renders a missing value as an empty cell.
+func csvFloat(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+)
+
+func TestStatsCommandCSV(t *testing.T) {
+	originalFactory := apiClientFactory
+	originalNow := timeNow
+	defer func() {
+		apiClientFactory = originalFactory
+		timeNow = originalNow
+	}()
+	timeNow = func() time.Time { return time.Date(2025, 12, 10, 0, 0, 0, 0, time.UTC) }
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		assert.Equal(t, "octo", variables["owner"])
+		assert.Equal(t, "demo", variables["name"])
+		if strings.Contains(query, "RepositoryPullRequests") {
+			return assignJSON(result, obj{"repository": obj{"pullRequests": obj{
+				"nodes": []obj{
+					{"number": 7, "title": "Add retries, backoff", "state": "OPEN", "createdAt": "2025-12-05T00:00:00Z", "updatedAt": "2025-12-06T00:00:00Z", "author": obj{"login": "octocat"}},
+					{"number": 6, "title": "Old", "state": "MERGED", "createdAt": "2025-11-01T00:00:00Z", "updatedAt": "2025-11-02T00:00:00Z", "author": obj{"login": "octocat"}},
+				},
+				"pageInfo": obj{"hasNextPage": false},
+			}}})
+		}
+		assert.Equal(t, 7, variables["number"])
+		return assignJSON(result, obj{"repository": obj{"pullRequest": obj{
+			"author": obj{"login": "octocat"},
+			"reviews": obj{"nodes": []obj{
+				{"id": "PRR_1", "state": "APPROVED", "submittedAt": "2025-12-05T06:30:00Z", "databaseId": 1, "author": obj{"login": "alice"}, "commit": obj{"oid": "head"}},
+			}},
+			"reviewThreads": obj{"nodes": []obj{}},
+		}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	// The configured repo applies, as for other single-repository commands.
+	useConfigFiles(t, "repo: octo/demo\n")
+
+	run := func(args ...string) string {
+		root := newRootCommand()
+		stdout := &bytes.Buffer{}
+		root.SetOut(stdout)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append([]string{"stats", "--since", "7d"}, args...))
+		require.NoError(t, root.Execute())
+		return stdout.String()
+	}
+
+	assert.Equal(t, "number,title,state,author_login,created_at,merged_at,time_to_first_review_hours,time_to_approval_hours,review_rounds,threads,resolved_threads,outdated_threads,unresolved_threads\n"+
+		"7,\"Add retries, backoff\",OPEN,octocat,2025-12-05T00:00:00Z,,6.5,6.5,1,0,0,0,0\n", run("--csv", "pulls"))
+	assert.Equal(t, "login,pull_requests,reviews,approvals,changes_requested,threads,resolved_threads,outdated_threads,resolution_rate\n"+
+		"alice,1,1,1,0,0,0,0,\n", run("--csv", "reviewers"))
+
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"stats", "--csv", "threads"})
+	require.EqualError(t, root.Execute(), `invalid --csv value "threads": must be pulls or reviewers`)
+}
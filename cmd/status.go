@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/report"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+func newStatusCommand() *cobra.Command {
+	opts := &statusOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "status [<number> | <url>]",
+		Short: "Summarize review readiness; exits non-zero when the pull request is not ready",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runStatus(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+
+	return configure(cmd)
+}
+
+type statusOptions struct {
+	Repo     string
+	Pull     int
+	Selector string
+}
+
+func runStatus(cmd *cobra.Command, opts *statusOptions) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+	identity, err := resolver.Resolve(selector, opts.Repo, defaultHost())
+	if err != nil {
+		return err
+	}
+
+	status, err := report.NewService(apiClientFactory(identity.Host)).Status(identity)
+	if err != nil {
+		return err
+	}
+	if err := encodeJSON(cmd, status); err != nil {
+		return err
+	}
+	if !status.Ready {
+		return errors.New("pull request is not ready")
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+)
+
+func TestStatusCommandExitsNonZeroWhenNotReady(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	threadResolved := false
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Equal(t, true, variables["includeStatus"])
+		return assignJSON(result, obj{"repository": obj{"pullRequest": obj{
+			"headRefOid":     "head",
+			"reviewDecision": "APPROVED",
+			"author":         obj{"login": "octocat"},
+			"reviewRequests": obj{"nodes": []obj{}},
+			"reviews": obj{"nodes": []obj{
+				{"id": "PRR_1", "state": "APPROVED", "submittedAt": "2025-12-03T10:00:00Z", "databaseId": 1, "author": obj{"login": "alice"}, "commit": obj{"oid": "head"}},
+			}},
+			"reviewThreads": obj{"nodes": []obj{
+				{"id": "PRRT_1", "path": "main.go", "isResolved": threadResolved, "isOutdated": false, "comments": obj{"nodes": []obj{}}},
+			}},
+		}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	run := func() (map[string]interface{}, error) {
+		root := newRootCommand()
+		stdout := &bytes.Buffer{}
+		root.SetOut(stdout)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs([]string{"status", "--repo", "octo/demo", "7"})
+		err := root.Execute()
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+		return payload, err
+	}
+
+	payload, err := run()
+	require.EqualError(t, err, "pull request is not ready")
+	assert.Equal(t, false, payload["ready"])
+	assert.Equal(t, []interface{}{"1 unresolved thread(s) that are not outdated"}, payload["reasons"])
+	assert.Equal(t, obj{"total": float64(1), "unresolved": float64(1), "unresolved_current": float64(1)}, payload["threads"])
+
+	threadResolved = true
+	payload, err = run()
+	require.NoError(t, err)
+	assert.Equal(t, true, payload["ready"])
+	assert.Equal(t, "APPROVED", payload["decision"])
+	reviewer := payload["reviewers"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, true, reviewer["on_head"])
+}
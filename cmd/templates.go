@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	reviewsvc "github.com/Agyn-sandbox/gh-pr-review/internal/review"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/templates"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/threads"
+)
+
+func newTemplatesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "templates",
+		Short: "Inspect the comment templates defined in the config",
+	}
+
+	cmd.AddCommand(newTemplatesListCommand())
+	cmd.AddCommand(newTemplatesShowCommand())
+
+	return cmd
+}
+
+func newTemplatesListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List configured comment templates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return encodeJSON(cmd, templateLibrary().List())
+		},
+	}
+
+	return configure(cmd)
+}
+
+func newTemplatesShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show <name>",
+		Short: "Show one comment template",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			template, err := templateLibrary().Get(args[0])
+			if err != nil {
+				return err
+			}
+			return encodeJSON(cmd, template)
+		},
+	}
+
+	return configure(cmd)
+}
+
+func templateLibrary() templates.Library {
+	return templates.Library(activeSettings.Templates)
+}
+
+// templateFlags select a configured template as the body.
+type templateFlags struct {
+	TemplateName string
+	Vars         []string
+}
+
+func bindTemplateFlags(cmd *cobra.Command, flags *templateFlags) {
+	cmd.Flags().StringVar(&flags.TemplateName, "template-name", "", "Render the body from this config template")
+	cmd.Flags().StringArrayVar(&flags.Vars, "var", nil, "Template variable as key=value, available as {{.Vars.key}} (repeatable)")
+}
+
+// renderBody renders the selected template, reporting false when no template
+// was selected. data supplies everything except the --var values.
+func (f templateFlags) renderBody(cmd *cobra.Command, data func() (templates.Data, error)) (string, bool, error) {
+	if f.TemplateName == "" {
+		if len(f.Vars) > 0 {
+			return "", false, errors.New("--var requires --template-name")
+		}
+		return "", false, nil
+	}
+	if cmd.Flags().Changed("body") || cmd.Flags().Changed("body-file") {
+		return "", false, errors.New("specify only one of --body, --body-file, or --template-name")
+	}
+
+	template, err := templateLibrary().Get(f.TemplateName)
+	if err != nil {
+		return "", false, err
+	}
+	vars, err := templates.ParseVars(f.Vars)
+	if err != nil {
+		return "", false, err
+	}
+	values, err := data()
+	if err != nil {
+		return "", false, err
+	}
+	values.Vars = vars
+
+	body, err := template.Render(values)
+	if err != nil {
+		return "", false, err
+	}
+	if err := ghcli.CheckBodyLength(body); err != nil {
+		return "", false, err
+	}
+	return body, true, nil
+}
+
+// pullTemplateData describes the pull request, looking up its author only
+// when a template references it.
+func pullTemplateData(service *reviewsvc.Service, pr resolver.Identity) templates.Data {
+	return templates.Data{
+		Repo:   pr.Owner + "/" + pr.Repo,
+		Number: pr.Number,
+		AuthorFunc: func() (string, error) {
+			author, err := service.PullRequestAuthor(pr)
+			if err != nil {
+				return "", fmt.Errorf("look up pull request author: %w", err)
+			}
+			return author, nil
+		},
+	}
+}
+
+// threadTemplateData describes an existing thread: its location and the
+// logins that commented on it.
+func threadTemplateData(pr resolver.Identity, threadID string) (templates.Data, error) {
+	api := apiClientFactory(pr.Host)
+	detail, err := threads.NewService(api).Show(pr, threads.ShowOptions{ThreadID: threadID})
+	if err != nil {
+		return templates.Data{}, err
+	}
+
+	data := pullTemplateData(newReviewService(api), pr)
+	data.Path = detail.Path
+	if detail.Line != nil {
+		data.Line = *detail.Line
+	} else if detail.OriginalLine != nil {
+		data.Line = *detail.OriginalLine
+	}
+	if detail.StartLine != nil {
+		data.StartLine = *detail.StartLine
+	}
+
+	participants := make([]string, 0, len(detail.Comments))
+	seen := make(map[string]bool)
+	for _, comment := range detail.Comments {
+		if comment.AuthorLogin != "" && !seen[comment.AuthorLogin] {
+			seen[comment.AuthorLogin] = true
+			participants = append(participants, comment.AuthorLogin)
+		}
+	}
+	data.ParticipantsFunc = func() ([]string, error) { return participants, nil }
+	return data, nil
+}
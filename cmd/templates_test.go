@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/templates"
+)
+
+const templatesTestConfig = `
+templates:
+  nit: "nit ({{.Path}}:{{.Line}}): {{.Vars.what}}"
+  thanks: "Thanks {{mention .Participants}}, fixed in {{.Vars.sha}}."
+  approve: "LGTM @{{.Author}}"
+`
+
+func runTemplatesCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(args)
+	err := root.Execute()
+	return stdout.String(), err
+}
+
+func TestTemplatesListAndShow(t *testing.T) {
+	useConfigFiles(t, templatesTestConfig)
+
+	out, err := runTemplatesCommand(t, "templates", "list")
+	require.NoError(t, err)
+	var list []templates.Template
+	require.NoError(t, json.Unmarshal([]byte(out), &list))
+	require.Len(t, list, 3)
+	assert.Equal(t, []string{"approve", "nit", "thanks"}, []string{list[0].Name, list[1].Name, list[2].Name})
+
+	out, err = runTemplatesCommand(t, "templates", "show", "nit")
+	require.NoError(t, err)
+	var shown templates.Template
+	require.NoError(t, json.Unmarshal([]byte(out), &shown))
+	assert.Equal(t, "nit ({{.Path}}:{{.Line}}): {{.Vars.what}}", shown.Body)
+
+	_, err = runTemplatesCommand(t, "templates", "show", "missing")
+	require.EqualError(t, err, `unknown template "missing" (defined: approve, nit, thanks)`)
+}
+
+func TestReviewAddCommentWithTemplate(t *testing.T) {
+	useConfigFiles(t, templatesTestConfig)
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		if strings.Contains(query, "PullRequestAuthor") {
+			t.Fatalf("author looked up although the template does not use it")
+		}
+		input := variables["input"].(map[string]interface{})
+		assert.Equal(t, "nit (main.go:12): rename x", input["body"])
+		return assignJSON(result, obj{"addPullRequestReviewThread": obj{"thread": obj{"id": "PRRT_1", "path": "main.go", "line": 12}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	_, err := runTemplatesCommand(t, "review", "--add-comment", "--review-id", "PRR_review", "--path", "main.go", "--line", "12",
+		"--template-name", "nit", "--var", "what=rename x", "--repo", "octo/demo", "7")
+	require.NoError(t, err)
+}
+
+func TestReviewSubmitWithAuthorTemplate(t *testing.T) {
+	useConfigFiles(t, templatesTestConfig)
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "PullRequestAuthor"):
+			return assignJSON(result, obj{"repository": obj{"pullRequest": obj{"author": obj{"login": "octocat"}}}})
+		case strings.Contains(query, "SubmitPullRequestReview"):
+			input := variables["input"].(map[string]interface{})
+			assert.Equal(t, "LGTM @octocat", input["body"])
+			return assignJSON(result, obj{})
+		default:
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	_, err := runTemplatesCommand(t, "review", "--submit", "--review-id", "PRR_review", "--event", "APPROVE",
+		"--template-name", "approve", "--repo", "octo/demo", "7")
+	require.NoError(t, err)
+}
+
+func TestCommentsReplyWithParticipantsTemplate(t *testing.T) {
+	useConfigFiles(t, templatesTestConfig)
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "ThreadShow"):
+			comment := func(login string) obj {
+				return obj{"id": "PRRC_" + login, "body": "hi", "createdAt": "2025-12-03T10:00:00Z", "updatedAt": "2025-12-03T10:00:00Z", "author": obj{"login": login}}
+			}
+			return assignJSON(result, obj{"node": obj{
+				"id":   "PRRT_thread",
+				"path": "main.go",
+				"line": 4,
+				"comments": obj{
+					"nodes": []obj{comment("alice"), comment("bob"), comment("alice")},
+				},
+			}})
+		case strings.Contains(query, "AddPullRequestReviewThreadReply"):
+			input := variables["input"].(map[string]interface{})
+			assert.Equal(t, "Thanks @alice @bob, fixed in abc123.", input["body"])
+			return assignJSON(result, obj{"addPullRequestReviewThreadReply": obj{"comment": obj{"id": "PRRC_reply", "author": obj{"login": "octocat"}}}})
+		case strings.Contains(query, "PullRequestReviewCommentDetails"):
+			return assignJSON(result, obj{"node": obj{"id": "PRRC_reply", "createdAt": "2025-12-03T10:00:00Z", "updatedAt": "2025-12-03T10:00:00Z", "author": obj{"login": "octocat"}}})
+		case strings.Contains(query, "PullRequestReviewThreadDetails"):
+			return assignJSON(result, obj{"node": obj{"id": "PRRT_thread"}})
+		default:
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	out, err := runTemplatesCommand(t, "comments", "reply", "--thread-id", "PRRT_thread",
+		"--template-name", "thanks", "--var", "sha=abc123", "--repo", "octo/demo", "7")
+	require.NoError(t, err)
+	assert.Contains(t, out, "PRRC_reply")
+}
+
+func TestTemplateFlagErrors(t *testing.T) {
+	useConfigFiles(t, templatesTestConfig)
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+	apiClientFactory = func(host string) ghcli.API {
+		return &commandFakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}}
+	}
+
+	base := []string{"review", "--add-comment", "--review-id", "PRR_review", "--path", "main.go", "--line", "12", "--repo", "octo/demo", "7"}
+	cases := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"--template-name", "nit", "--body", "x"}, "specify only one of --body, --body-file, or --template-name"},
+		{[]string{"--var", "what=x", "--body", "x"}, "--var requires --template-name"},
+		{[]string{"--template-name", "nit"}, `render template "nit": template: nit:1:34: executing "nit" at <.Vars.what>: map has no entry for key "what"`},
+		{[]string{"--template-name", "nit", "--var", "what"}, `invalid --var "what": expected key=value`},
+		{[]string{"--template-name", "other"}, `unknown template "other" (defined: approve, nit, thanks)`},
+	}
+	for _, tc := range cases {
+		_, err := runTemplatesCommand(t, append(append([]string{}, base...), tc.args...)...)
+		assert.EqualError(t, err, tc.err, tc.args)
+	}
+}
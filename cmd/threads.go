@@ -2,11 +2,13 @@ package cmd
 
 import (
 	"errors"
-	"os"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/Agyn-sandbox/gh-pr-review/internal/comments"
 	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
 	"github.com/Agyn-sandbox/gh-pr-review/internal/threads"
 )
@@ -18,8 +20,10 @@ func newThreadsCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(newThreadsListCommand())
+	cmd.AddCommand(newThreadsShowCommand())
 	cmd.AddCommand(newThreadsResolveCommand())
 	cmd.AddCommand(newThreadsUnresolveCommand())
+	cmd.AddCommand(newThreadsAddressedCommand())
 
 	return cmd
 }
@@ -40,11 +44,12 @@ func newThreadsListCommand() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&opts.UnresolvedOnly, "unresolved", false, "Filter to unresolved threads only")
-	cmd.Flags().BoolVar(&opts.MineOnly, "mine", false, "Show only threads involving or resolvable by the viewer")
+	bindThreadFilterFlags(cmd, &opts.threadFilterFlags, "Show only threads involving or resolvable by the viewer")
+	bindSeenFlags(cmd, &opts.seenFlags)
 	cmd.PersistentFlags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
 	cmd.PersistentFlags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
 
-	return cmd
+	return configure(cmd)
 }
 
 type threadsListOptions struct {
@@ -52,31 +57,170 @@ type threadsListOptions struct {
 	Pull           int
 	Selector       string
 	UnresolvedOnly bool
-	MineOnly       bool
+	threadFilterFlags
+	seenFlags
+}
+
+// threadFilterFlags holds the thread selection flags shared by threads list and
+// the bulk resolve/unresolve modes.
+type threadFilterFlags struct {
+	MineOnly    bool
+	Paths       []string
+	Author      string
+	Participant string
+	Outdated    bool
+	NotOutdated bool
+	Since       string
+	Before      string
+	ResolvedBy  string
+}
+
+// bindThreadFilterFlags registers the filter flags; mineUsage describes --mine
+// for the command at hand.
+func bindThreadFilterFlags(cmd *cobra.Command, f *threadFilterFlags, mineUsage string) {
+	cmd.Flags().BoolVar(&f.MineOnly, "mine", false, mineUsage)
+	cmd.Flags().StringSliceVar(&f.Paths, "path", nil, "Only threads whose file path matches a glob (repeatable; ** spans directories)")
+	cmd.Flags().StringVar(&f.Author, "author", "", "Only threads started by this login")
+	cmd.Flags().StringVar(&f.Participant, "participant", "", "Only threads where this login commented")
+	cmd.Flags().BoolVar(&f.Outdated, "outdated", false, "Only outdated threads")
+	cmd.Flags().BoolVar(&f.NotOutdated, "not-outdated", false, "Exclude outdated threads")
+	cmd.Flags().StringVar(&f.Since, "since", "", "Only threads updated at or after this time (RFC3339, YYYY-MM-DD, or age like 7d)")
+	cmd.Flags().StringVar(&f.Before, "before", "", "Only threads updated before this time (RFC3339, YYYY-MM-DD, or age like 7d)")
+	cmd.Flags().StringVar(&f.ResolvedBy, "resolved-by", "", "Only threads resolved by this login")
+}
+
+// provided reports whether any selection flag was set.
+func (f *threadFilterFlags) provided() bool {
+	return f.MineOnly || len(f.Paths) > 0 || f.Author != "" || f.Participant != "" ||
+		f.Outdated || f.NotOutdated || f.Since != "" || f.Before != "" || f.ResolvedBy != ""
+}
+
+// listOptions converts the flag values into service filters.
+func (o *threadFilterFlags) listOptions() (threads.ListOptions, error) {
+	if o.Outdated && o.NotOutdated {
+		return threads.ListOptions{}, errors.New("--outdated and --not-outdated are mutually exclusive")
+	}
+	since, err := parseTimeFlag("since", o.Since)
+	if err != nil {
+		return threads.ListOptions{}, err
+	}
+	before, err := parseTimeFlag("before", o.Before)
+	if err != nil {
+		return threads.ListOptions{}, err
+	}
+
+	list := threads.ListOptions{
+		MineOnly:    o.MineOnly,
+		Paths:       o.Paths,
+		Author:      strings.TrimSpace(o.Author),
+		Participant: strings.TrimSpace(o.Participant),
+		Since:       since,
+		Before:      before,
+		ResolvedBy:  strings.TrimSpace(o.ResolvedBy),
+	}
+	if o.Outdated || o.NotOutdated {
+		outdated := o.Outdated
+		list.Outdated = &outdated
+	}
+	return list, nil
 }
 
 func runThreadsList(cmd *cobra.Command, opts *threadsListOptions) error {
+	listOpts, err := opts.listOptions()
+	if err != nil {
+		return err
+	}
+	listOpts.OnlyUnresolved = opts.UnresolvedOnly
+
 	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
 	if err != nil {
 		return err
 	}
 
-	hostEnv := os.Getenv("GH_HOST")
-	identity, err := resolver.Resolve(selector, opts.Repo, hostEnv)
+	host := defaultHost()
+	identity, err := resolver.Resolve(selector, opts.Repo, host)
 	if err != nil {
 		return err
 	}
 
+	store, markers, err := opts.lastSeen(identity)
+	if err != nil {
+		return err
+	}
+	listOpts.LastSeen = markers
+
 	service := threads.NewService(apiClientFactory(identity.Host))
-	payload, err := service.List(identity, threads.ListOptions{
-		OnlyUnresolved: opts.UnresolvedOnly,
-		MineOnly:       opts.MineOnly,
-	})
+	payload, err := service.List(identity, listOpts)
 	if err != nil {
 		return err
 	}
 
-	return encodeJSON(cmd, payload)
+	if err := encodeJSON(cmd, payload); err != nil {
+		return err
+	}
+
+	activity := make(map[string]time.Time, len(payload))
+	for _, thread := range payload {
+		if thread.UpdatedAt != nil {
+			activity[thread.ThreadID] = *thread.UpdatedAt
+		}
+	}
+	return opts.markSeen(store, identity, activity)
+}
+
+func newThreadsShowCommand() *cobra.Command {
+	opts := &threadsShowOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "show [<number> | <url>]",
+		Short: "Show the full conversation of a review thread",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			if strings.TrimSpace(opts.ThreadID) == "" {
+				return errors.New("--thread-id is required")
+			}
+			return runThreadsShow(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.ThreadID, "thread-id", "", "GraphQL node ID for the review thread")
+	cmd.Flags().BoolVar(&opts.IncludeReactions, "include-reactions", false, "Include reaction counts and whether you reacted on every comment")
+	cmd.PersistentFlags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.PersistentFlags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+
+	return configure(cmd)
+}
+
+type threadsShowOptions struct {
+	Repo             string
+	Pull             int
+	Selector         string
+	ThreadID         string
+	IncludeReactions bool
+}
+
+func runThreadsShow(cmd *cobra.Command, opts *threadsShowOptions) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	host := defaultHost()
+	identity, err := resolver.Resolve(selector, opts.Repo, host)
+	if err != nil {
+		return err
+	}
+
+	service := threads.NewService(apiClientFactory(identity.Host))
+	detail, err := service.Show(identity, threads.ShowOptions{ThreadID: strings.TrimSpace(opts.ThreadID), IncludeReactions: opts.IncludeReactions})
+	if err != nil {
+		return err
+	}
+
+	return encodeJSON(cmd, detail)
 }
 
 func newThreadsResolveCommand() *cobra.Command {
@@ -91,10 +235,10 @@ func newThreadsMutationCommand(resolve bool) *cobra.Command {
 	opts := &threadsMutationOptions{}
 
 	use := "resolve"
-	short := "Resolve a review thread"
+	short := "Resolve a review thread, or every thread matching filters with --all"
 	if !resolve {
 		use = "unresolve"
-		short = "Reopen a review thread"
+		short = "Reopen a review thread, or every thread matching filters with --all"
 	}
 
 	cmd := &cobra.Command{
@@ -105,6 +249,13 @@ func newThreadsMutationCommand(resolve bool) *cobra.Command {
 			if len(args) > 0 {
 				opts.Selector = args[0]
 			}
+			if resolve {
+				reply, _, err := readBody(cmd, "reply", opts.Reply, opts.ReplyFile)
+				if err != nil {
+					return err
+				}
+				opts.Reply = reply
+			}
 			if err := opts.Validate(); err != nil {
 				return err
 			}
@@ -116,22 +267,56 @@ func newThreadsMutationCommand(resolve bool) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&opts.ThreadID, "thread-id", "", "GraphQL node ID for the review thread")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Target every thread matching the filter flags instead of a single --thread-id")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "With --all, print the planned changes without mutating threads")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", 4, "With --all, maximum number of concurrent mutations")
+	bindThreadFilterFlags(cmd, &opts.threadFilterFlags, "With --all, only change threads involving or resolvable by the viewer")
+	if resolve {
+		cmd.Flags().StringVar(&opts.Reply, "reply", "", "Post this reply to the thread before resolving it")
+		bindBodyFileFlag(cmd, "reply", &opts.ReplyFile)
+		bindRedactFlag(cmd)
+	}
 	cmd.PersistentFlags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
 	cmd.PersistentFlags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
 
-	return cmd
+	return configure(cmd)
 }
 
 type threadsMutationOptions struct {
-	Repo     string
-	Pull     int
-	Selector string
-	ThreadID string
+	Repo        string
+	Pull        int
+	Selector    string
+	ThreadID    string
+	All         bool
+	DryRun      bool
+	Concurrency int
+	Reply       string
+	ReplyFile   string
+	threadFilterFlags
 }
 
 func (o *threadsMutationOptions) Validate() error {
-	if strings.TrimSpace(o.ThreadID) == "" {
-		return errors.New("--thread-id is required")
+	threadID := strings.TrimSpace(o.ThreadID)
+	if o.All {
+		if threadID != "" {
+			return errors.New("--thread-id and --all are mutually exclusive")
+		}
+		if o.Reply != "" {
+			return errors.New("--reply cannot be combined with --all")
+		}
+		if o.Concurrency <= 0 {
+			return fmt.Errorf("invalid --concurrency value %d: must be positive", o.Concurrency)
+		}
+		if !o.provided() {
+			return errors.New("--all requires at least one filter flag")
+		}
+		return nil
+	}
+	if threadID == "" {
+		return errors.New("--thread-id is required (or use --all with filter flags)")
+	}
+	if o.DryRun || o.provided() {
+		return errors.New("--dry-run and filter flags require --all")
 	}
 	return nil
 }
@@ -150,13 +335,22 @@ func runThreadsMutation(cmd *cobra.Command, opts *threadsMutationOptions, resolv
 		return err
 	}
 
-	hostEnv := os.Getenv("GH_HOST")
-	identity, err := resolver.Resolve(selector, opts.Repo, hostEnv)
+	host := defaultHost()
+	identity, err := resolver.Resolve(selector, opts.Repo, host)
 	if err != nil {
 		return err
 	}
 
 	service := threads.NewService(apiClientFactory(identity.Host))
+	if opts.All {
+		return runThreadsBulkMutation(cmd, service, identity, opts, resolve)
+	}
+	if resolve && opts.Reply != "" {
+		return runReplyAndResolve(cmd, identity, comments.ReplyOptions{
+			ThreadID: strings.TrimSpace(opts.ThreadID),
+			Body:     opts.Reply,
+		})
+	}
 	action := threads.ActionOptions{ThreadID: strings.TrimSpace(opts.ThreadID)}
 
 	var result threads.ActionResult
@@ -170,3 +364,172 @@ func runThreadsMutation(cmd *cobra.Command, opts *threadsMutationOptions, resolv
 	}
 	return encodeJSON(cmd, result)
 }
+
+func runThreadsBulkMutation(cmd *cobra.Command, service *threads.Service, identity resolver.Identity, opts *threadsMutationOptions, resolve bool) error {
+	filter, err := opts.listOptions()
+	if err != nil {
+		return err
+	}
+	bulk := threads.BulkOptions{
+		Filter:      filter,
+		DryRun:      opts.DryRun,
+		Concurrency: opts.Concurrency,
+	}
+
+	var result threads.BulkResult
+	if resolve {
+		result, err = service.ResolveMatching(identity, bulk)
+	} else {
+		result, err = service.UnresolveMatching(identity, bulk)
+	}
+	if err != nil {
+		return err
+	}
+	if err := encodeJSON(cmd, result); err != nil {
+		return err
+	}
+	if failed := result.Failed(); failed > 0 {
+		return fmt.Errorf("%d of %d thread mutations failed", failed, len(result.Results))
+	}
+	return nil
+}
+
+func newThreadsAddressedCommand() *cobra.Command {
+	opts := &threadsAddressedOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "addressed [<number> | <url>]",
+		Short: "List unresolved threads whose lines were changed by later commits",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			if strings.TrimSpace(opts.SinceReview) != "" && strings.TrimSpace(opts.SinceCommit) != "" {
+				return errors.New("--since-review and --since-commit are mutually exclusive")
+			}
+			reply, _, err := readBody(cmd, "reply", opts.Reply, opts.ReplyFile)
+			if err != nil {
+				return err
+			}
+			opts.Reply = reply
+			if opts.Reply != "" && !opts.Resolve {
+				return errors.New("--reply requires --resolve")
+			}
+			return runThreadsAddressed(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.SinceReview, "since-review", "", "Only consider commits pushed after the commit this review (PRR_…) was submitted against")
+	cmd.Flags().StringVar(&opts.SinceCommit, "since-commit", "", "Only consider commits pushed after this pull request commit SHA")
+	cmd.Flags().BoolVar(&opts.Resolve, "resolve", false, "Reply to and resolve every addressed thread")
+	cmd.Flags().StringVar(&opts.Reply, "reply", "", "With --resolve, reply body to post (default: \"Addressed in <sha>.\")")
+	bindBodyFileFlag(cmd, "reply", &opts.ReplyFile)
+	bindRedactFlag(cmd)
+	cmd.PersistentFlags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.PersistentFlags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+
+	return configure(cmd)
+}
+
+type threadsAddressedOptions struct {
+	Repo        string
+	Pull        int
+	Selector    string
+	SinceReview string
+	SinceCommit string
+	Resolve     bool
+	Reply       string
+	ReplyFile   string
+}
+
+// addressedResult pairs an addressed thread with the outcome of --resolve.
+type addressedResult struct {
+	threads.AddressedThread
+	Resolution *addressedResolution `json:"resolution,omitempty"`
+}
+
+type addressedResolution struct {
+	Status        string `json:"status"`
+	CommentNodeID string `json:"commentNodeId,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+const (
+	addressedResolved    = "resolved"
+	addressedReplyPosted = "reply_posted"
+	addressedSkipped     = "skipped"
+	addressedFailed      = "failed"
+)
+
+func runThreadsAddressed(cmd *cobra.Command, opts *threadsAddressedOptions) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	host := defaultHost()
+	identity, err := resolver.Resolve(selector, opts.Repo, host)
+	if err != nil {
+		return err
+	}
+
+	api := apiClientFactory(identity.Host)
+	addressed, err := threads.NewService(api).Addressed(identity, threads.AddressedOptions{
+		SinceReview: opts.SinceReview,
+		SinceCommit: opts.SinceCommit,
+	})
+	if err != nil {
+		return err
+	}
+	if !opts.Resolve {
+		return encodeJSON(cmd, addressed)
+	}
+
+	results := make([]addressedResult, len(addressed))
+	failed := 0
+	for i, thread := range addressed {
+		results[i] = addressedResult{AddressedThread: thread}
+		if !thread.ViewerCanResolve {
+			results[i].Resolution = &addressedResolution{Status: addressedSkipped, Error: "viewer cannot resolve this thread"}
+			continue
+		}
+
+		body := opts.Reply
+		if body == "" {
+			body = addressedReply(thread.Commits)
+		}
+		outcome, err := replyAndResolve(api, identity, comments.ReplyOptions{ThreadID: thread.ThreadID, Body: body})
+		resolution := &addressedResolution{Status: addressedResolved, CommentNodeID: outcome.CommentNodeID}
+		if err != nil {
+			failed++
+			resolution.Status = addressedFailed
+			if outcome.ReplyPosted {
+				resolution.Status = addressedReplyPosted
+			}
+			resolution.Error = err.Error()
+		}
+		results[i].Resolution = resolution
+	}
+
+	if err := encodeJSON(cmd, results); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d addressed threads were not resolved", failed, len(results))
+	}
+	return nil
+}
+
+// addressedReply builds the default closing reply referencing the commits that touched the thread.
+func addressedReply(commits []threads.AddressedCommit) string {
+	shas := make([]string, len(commits))
+	for i, commit := range commits {
+		sha := commit.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		shas[i] = sha
+	}
+	return "Addressed in " + strings.Join(shas, ", ") + "."
+}
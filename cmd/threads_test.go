@@ -228,3 +228,290 @@ func TestThreadsResolveRequiresThreadID(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "--thread-id is required")
 }
+
+func TestThreadsShowCommandOutputsThread(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		if !strings.Contains(query, "ThreadShow") {
+			return errors.New("unexpected query")
+		}
+		assert.Equal(t, true, variables["includeReactions"])
+		payload := map[string]interface{}{
+			"node": map[string]interface{}{
+				"id":               "PRRT_thread",
+				"path":             "main.go",
+				"line":             3,
+				"isResolved":       false,
+				"viewerCanResolve": true,
+				"comments": map[string]interface{}{
+					"nodes": []map[string]interface{}{
+						{
+							"id":        "PRRC_a",
+							"body":      "Parent",
+							"createdAt": "2025-12-01T10:00:00Z",
+							"updatedAt": "2025-12-01T10:00:00Z",
+							"author":    map[string]interface{}{"login": "alice"},
+							"reactionGroups": []map[string]interface{}{
+								{"content": "ROCKET", "viewerHasReacted": true, "users": map[string]interface{}{"totalCount": 1}},
+							},
+						},
+					},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				},
+			},
+		}
+		return assignJSON(result, payload)
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "show", "--thread-id", "PRRT_thread", "--include-reactions", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+	assert.Equal(t, "PRRT_thread", payload["threadId"])
+	assert.Equal(t, true, payload["viewerCanResolve"])
+	comments, ok := payload["comments"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "alice", comments[0].(map[string]interface{})["authorLogin"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"content": "ROCKET", "count": float64(1), "viewerHasReacted": true}}, comments[0].(map[string]interface{})["reactions"])
+}
+
+func TestThreadsShowRequiresThreadID(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "show", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--thread-id is required")
+}
+
+func TestThreadsListRejectsConflictingOutdatedFlags(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "list", "--outdated", "--not-outdated", "--repo", "octo/demo", "5"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mutually exclusive")
+}
+
+func TestThreadsListRejectsInvalidSince(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "list", "--since", "yesterday", "--repo", "octo/demo", "5"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --since value")
+}
+
+func TestThreadsResolveAllDryRun(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.restFunc = func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		switch path {
+		case "repos/octo/demo":
+			return assignJSON(result, map[string]interface{}{"full_name": "octo/demo"})
+		case "repos/octo/demo/pulls/5":
+			return assignJSON(result, map[string]interface{}{"node_id": "PR_node"})
+		default:
+			return errors.New("unexpected path")
+		}
+	}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		if !strings.Contains(query, "reviewThreads") {
+			return errors.New("unexpected mutation during dry run")
+		}
+		payload := map[string]interface{}{
+			"node": map[string]interface{}{
+				"reviewThreads": map[string]interface{}{
+					"nodes": []map[string]interface{}{
+						{"id": "T_old", "isResolved": false, "isOutdated": true, "path": "internal/a.go", "viewerCanResolve": true, "comments": map[string]interface{}{"nodes": []interface{}{}}},
+						{"id": "T_new", "isResolved": false, "isOutdated": false, "path": "internal/b.go", "viewerCanResolve": true, "comments": map[string]interface{}{"nodes": []interface{}{}}},
+					},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				},
+			},
+		}
+		return assignJSON(result, payload)
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "resolve", "--all", "--outdated", "--path", "internal/**", "--dry-run", "--repo", "octo/demo", "5"})
+
+	require.NoError(t, root.Execute())
+
+	var payload struct {
+		DryRun  bool `json:"dry_run"`
+		Results []struct {
+			ThreadNodeID string `json:"thread_node_id"`
+			Status       string `json:"status"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+	assert.True(t, payload.DryRun)
+	require.Len(t, payload.Results, 1)
+	assert.Equal(t, "T_old", payload.Results[0].ThreadNodeID)
+	assert.Equal(t, "planned", payload.Results[0].Status)
+}
+
+func TestThreadsResolveRejectsFiltersWithoutAll(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "resolve", "--thread-id", "T1", "--outdated", "--repo", "octo/demo", "5"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "require --all")
+}
+
+func TestThreadsResolveRejectsThreadIDWithAll(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "resolve", "--thread-id", "T1", "--all", "--repo", "octo/demo", "5"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mutually exclusive")
+}
+
+func TestThreadsResolveAllRequiresFilter(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "unresolve", "--all", "--repo", "octo/demo", "5"})
+
+	err := root.Execute()
+	require.EqualError(t, err, "--all requires at least one filter flag")
+}
+
+func TestThreadsAddressedResolvesWithCommitReply(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	lastComment := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	fake := &commandFakeAPI{}
+	fake.restFunc = func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		switch path {
+		case "repos/octo/demo":
+			return assignJSON(result, map[string]interface{}{"full_name": "octo/demo"})
+		case "repos/octo/demo/pulls/5":
+			return assignJSON(result, map[string]interface{}{"node_id": "PR_node"})
+		case "repos/octo/demo/pulls/5/commits":
+			return assignJSON(result, []map[string]interface{}{{
+				"sha": "0123456789abcdef",
+				"commit": map[string]interface{}{
+					"message":   "Fix nil check",
+					"committer": map[string]interface{}{"date": lastComment.Add(time.Hour)},
+				},
+			}})
+		case "repos/octo/demo/commits/0123456789abcdef":
+			return assignJSON(result, map[string]interface{}{
+				"files": []map[string]interface{}{
+					{"filename": "main.go", "status": "modified", "patch": "@@ -3,1 +3,1 @@\n-old\n+new"},
+				},
+			})
+		default:
+			return errors.New("unexpected path: " + path)
+		}
+	}
+	resolved := false
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "reviewThreads"):
+			return assignJSON(result, map[string]interface{}{
+				"node": map[string]interface{}{
+					"reviewThreads": map[string]interface{}{
+						"nodes": []map[string]interface{}{{
+							"id": "PRRT_thread", "isResolved": false, "path": "main.go", "line": 3, "viewerCanResolve": true,
+							"comments": map[string]interface{}{"nodes": []map[string]interface{}{{"createdAt": lastComment}}},
+						}},
+						"pageInfo": map[string]interface{}{"hasNextPage": false},
+					},
+				},
+			})
+		case strings.Contains(query, "AddPullRequestReviewThreadReply"):
+			input := variables["input"].(map[string]interface{})
+			require.Equal(t, "Addressed in 0123456.", input["body"])
+			return assignJSON(result, map[string]interface{}{
+				"addPullRequestReviewThreadReply": map[string]interface{}{
+					"comment": map[string]interface{}{"id": "PRRC_reply", "body": input["body"], "author": map[string]interface{}{"login": "octocat"}},
+				},
+			})
+		case strings.Contains(query, "PullRequestReviewCommentDetails"):
+			return assignJSON(result, map[string]interface{}{"node": map[string]interface{}{"id": "PRRC_reply", "author": map[string]interface{}{"login": "octocat"}}})
+		case strings.Contains(query, "PullRequestReviewThreadDetails"):
+			return assignJSON(result, map[string]interface{}{"node": map[string]interface{}{"id": "PRRT_thread", "isResolved": false}})
+		case strings.Contains(query, "query ThreadDetails"):
+			return assignJSON(result, map[string]interface{}{"node": map[string]interface{}{"id": "PRRT_thread", "isResolved": false, "viewerCanResolve": true}})
+		case strings.Contains(query, "resolveReviewThread"):
+			resolved = true
+			return assignJSON(result, map[string]interface{}{
+				"resolveReviewThread": map[string]interface{}{"thread": map[string]interface{}{"id": "PRRT_thread", "isResolved": true}},
+			})
+		default:
+			return errors.New("unexpected query: " + query)
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "addressed", "--resolve", "--repo", "octo/demo", "5"})
+
+	require.NoError(t, root.Execute())
+	assert.True(t, resolved)
+
+	var payload []struct {
+		ThreadID string `json:"threadId"`
+		Commits  []struct {
+			SHA string `json:"sha"`
+		} `json:"commits"`
+		Resolution struct {
+			Status        string `json:"status"`
+			CommentNodeID string `json:"commentNodeId"`
+		} `json:"resolution"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+	require.Len(t, payload, 1)
+	assert.Equal(t, "PRRT_thread", payload[0].ThreadID)
+	require.Len(t, payload[0].Commits, 1)
+	assert.Equal(t, "0123456789abcdef", payload[0].Commits[0].SHA)
+	assert.Equal(t, "resolved", payload[0].Resolution.Status)
+	assert.Equal(t, "PRRC_reply", payload[0].Resolution.CommentNodeID)
+}
+
+func TestThreadsAddressedRejectsBothBaselines(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "addressed", "--since-review", "PRR_1", "--since-commit", "abc", "--repo", "octo/demo", "5"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--since-review and --since-commit are mutually exclusive")
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeNow = time.Now
+
+// parseTimeFlag interprets a time filter flag. It accepts RFC3339 timestamps,
+// YYYY-MM-DD dates (UTC midnight), and relative ages such as 36h, 7d, or 2w,
+// which are measured back from the current time. Empty values yield nil.
+func parseTimeFlag(name, raw string) (*time.Time, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return nil, nil
+	}
+
+	if ts, err := time.Parse(time.RFC3339, value); err == nil {
+		return &ts, nil
+	}
+	if ts, err := time.Parse("2006-01-02", value); err == nil {
+		return &ts, nil
+	}
+	if age, ok := parseAge(value); ok {
+		ts := timeNow().Add(-age).UTC()
+		return &ts, nil
+	}
+
+	return nil, fmt.Errorf("invalid --%s value %q: use RFC3339, YYYY-MM-DD, or a relative age like 48h, 7d, 2w", name, raw)
+}
+
+func parseAge(value string) (time.Duration, bool) {
+	if len(value) < 2 {
+		return 0, false
+	}
+	unit := value[len(value)-1]
+	var multiplier time.Duration
+	switch unit {
+	case 'd':
+		multiplier = 24 * time.Hour
+	case 'w':
+		multiplier = 7 * 24 * time.Hour
+	default:
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return 0, false
+		}
+		return d, true
+	}
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return time.Duration(n) * multiplier, true
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeFlag(t *testing.T) {
+	originalNow := timeNow
+	defer func() { timeNow = originalNow }()
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+
+	cases := []struct {
+		raw  string
+		want time.Time
+	}{
+		{"2025-12-01T08:30:00Z", time.Date(2025, 12, 1, 8, 30, 0, 0, time.UTC)},
+		{"2025-12-01", time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{"36h", now.Add(-36 * time.Hour)},
+		{"7d", now.AddDate(0, 0, -7)},
+		{"2w", now.AddDate(0, 0, -14)},
+	}
+	for _, tc := range cases {
+		got, err := parseTimeFlag("since", tc.raw)
+		require.NoError(t, err, tc.raw)
+		require.NotNil(t, got, tc.raw)
+		assert.True(t, tc.want.Equal(*got), "%s: want %s, got %s", tc.raw, tc.want, got)
+	}
+
+	empty, err := parseTimeFlag("since", " ")
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+
+	_, err = parseTimeFlag("before", "-3d")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --before value")
+}
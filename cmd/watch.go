@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/watch"
+)
+
+func newWatchCommand() *cobra.Command {
+	opts := &watchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "watch [<number> | <url>]",
+		Short: "Poll a pull request and stream new review activity as NDJSON",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runWatch(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().DurationVar(&opts.Interval, "interval", 30*time.Second, "Delay between polls")
+	cmd.Flags().IntVar(&opts.FullRefreshEvery, "full-refresh-every", 10, "Force a full snapshot after this many unchanged conditional polls (0 = snapshot every poll)")
+
+	return configure(cmd)
+}
+
+type watchOptions struct {
+	Repo             string
+	Pull             int
+	Selector         string
+	Interval         time.Duration
+	FullRefreshEvery int
+}
+
+func runWatch(cmd *cobra.Command, opts *watchOptions) error {
+	if opts.Interval <= 0 {
+		return fmt.Errorf("invalid --interval value %s: must be positive", opts.Interval)
+	}
+	if opts.FullRefreshEvery < 0 {
+		return fmt.Errorf("invalid --full-refresh-every value %d: must be non-negative", opts.FullRefreshEvery)
+	}
+
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolver.Resolve(selector, opts.Repo, defaultHost())
+	if err != nil {
+		return err
+	}
+
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt)
+	defer stop()
+
+	watcher := watch.NewWatcher(apiClientFactory(identity.Host))
+	err = watcher.Run(ctx, identity, watch.Options{
+		Interval:         opts.Interval,
+		FullRefreshEvery: opts.FullRefreshEvery,
+	}, func(event watch.Event) error {
+		return encodeJSONLine(cmd, event)
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
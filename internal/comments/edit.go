@@ -0,0 +1,103 @@
+package comments
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+)
+
+const updateCommentMutation = `mutation UpdatePullRequestReviewComment($input: UpdatePullRequestReviewCommentInput!) {
+  updatePullRequestReviewComment(input: $input) {
+    pullRequestReviewComment {
+      id
+      body
+      updatedAt
+    }
+  }
+}`
+
+const deleteCommentMutation = `mutation DeletePullRequestReviewComment($input: DeletePullRequestReviewCommentInput!) {
+  deletePullRequestReviewComment(input: $input) {
+    pullRequestReview { id }
+  }
+}`
+
+// EditOptions identifies a review comment and its replacement body.
+type EditOptions struct {
+	CommentID string
+	Body      string
+}
+
+// Edit is the normalized response after updating a review comment.
+type Edit struct {
+	CommentNodeID string `json:"comment_node_id"`
+	Body          string `json:"body"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+// Deletion is the normalized response after deleting a review comment.
+type Deletion struct {
+	CommentNodeID string `json:"comment_node_id"`
+	Deleted       bool   `json:"deleted"`
+}
+
+// Edit replaces the body of a review comment authored by the viewer.
+func (s *Service) Edit(opts EditOptions) (Edit, error) {
+	commentID := strings.TrimSpace(opts.CommentID)
+	if commentID == "" {
+		return Edit{}, errors.New("comment id is required")
+	}
+	if strings.TrimSpace(opts.Body) == "" {
+		return Edit{}, errors.New("comment body is required")
+	}
+	body, err := s.Secrets.Check(opts.Body)
+	if err != nil {
+		return Edit{}, err
+	}
+	if err := ghcli.CheckBodyLength(body); err != nil {
+		return Edit{}, err
+	}
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"pullRequestReviewCommentId": commentID,
+			"body":                       body,
+		},
+	}
+	var response struct {
+		UpdatePullRequestReviewComment struct {
+			Comment *struct {
+				ID        string `json:"id"`
+				Body      string `json:"body"`
+				UpdatedAt string `json:"updatedAt"`
+			} `json:"pullRequestReviewComment"`
+		} `json:"updatePullRequestReviewComment"`
+	}
+	if err := s.API.GraphQL(updateCommentMutation, variables, &response); err != nil {
+		return Edit{}, err
+	}
+
+	comment := response.UpdatePullRequestReviewComment.Comment
+	if comment == nil || strings.TrimSpace(comment.ID) == "" {
+		return Edit{}, errors.New("mutation response missing comment")
+	}
+	return Edit{CommentNodeID: comment.ID, Body: comment.Body, UpdatedAt: comment.UpdatedAt}, nil
+}
+
+// Delete removes a review comment authored by the viewer.
+func (s *Service) Delete(commentID string) (Deletion, error) {
+	id := strings.TrimSpace(commentID)
+	if id == "" {
+		return Deletion{}, errors.New("comment id is required")
+	}
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{"id": id},
+	}
+	var response struct{}
+	if err := s.API.GraphQL(deleteCommentMutation, variables, &response); err != nil {
+		return Deletion{}, err
+	}
+	return Deletion{CommentNodeID: id, Deleted: true}, nil
+}
@@ -0,0 +1,214 @@
+package comments
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/report"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+const pullRequestNodeQuery = `query PullRequestNode($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) { id }
+  }
+}`
+
+const addCommentMutation = `mutation AddComment($input: AddCommentInput!) {
+  addComment(input: $input) {
+    commentEdge {
+      node {
+        id
+        body
+        createdAt
+        author { login }
+      }
+    }
+  }
+}`
+
+// Comment kinds distinguish where a comment lives on the pull request.
+const (
+	KindReviewThread = "review_thread"
+	KindConversation = "conversation"
+	KindReviewBody   = "review_body"
+)
+
+// Comment is one comment of any kind on a pull request.
+type Comment struct {
+	Kind          string `json:"kind"`
+	CommentNodeID string `json:"comment_node_id"`
+	AuthorLogin   string `json:"author_login"`
+	Body          string `json:"body"`
+	CreatedAt     string `json:"created_at"`
+	ThreadID      string `json:"thread_id,omitempty"`
+	Path          string `json:"path,omitempty"`
+	Line          *int   `json:"line,omitempty"`
+	ReviewID      string `json:"review_id,omitempty"`
+	ReviewState   string `json:"review_state,omitempty"`
+}
+
+// ListOptions selects which comments List returns.
+type ListOptions struct {
+	// Conversation limits the result to top-level conversation comments.
+	Conversation bool
+}
+
+// List returns the pull request's comments sorted by created_at ascending:
+// review thread comments, non-empty review bodies, and conversation comments.
+// Conversation comments, reviews, and review threads are paginated until
+// exhausted.
+func (s *Service) List(pr resolver.Identity, opts ListOptions) ([]Comment, error) {
+	reports := report.NewService(s.API)
+	if opts.Conversation {
+		conversation, err := reports.Conversation(pr)
+		if err != nil {
+			return nil, err
+		}
+		return conversationComments(conversation), nil
+	}
+
+	result, err := reports.Fetch(pr, report.Options{IncludeConversation: true, IncludeCommentNodeID: true})
+	if err != nil {
+		return nil, err
+	}
+
+	comments := conversationComments(*result.Conversation)
+	for _, review := range result.Reviews {
+		if review.Body != nil && strings.TrimSpace(*review.Body) != "" && review.SubmittedAt != nil {
+			comments = append(comments, Comment{
+				Kind:          KindReviewBody,
+				CommentNodeID: review.ID,
+				AuthorLogin:   review.AuthorLogin,
+				Body:          *review.Body,
+				CreatedAt:     *review.SubmittedAt,
+				ReviewID:      review.ID,
+				ReviewState:   string(review.State),
+			})
+		}
+		for _, thread := range review.Comments {
+			parent := Comment{
+				Kind:        KindReviewThread,
+				AuthorLogin: thread.AuthorLogin,
+				Body:        thread.Body,
+				CreatedAt:   thread.CreatedAt,
+				ThreadID:    thread.ThreadID,
+				Path:        thread.Path,
+				Line:        thread.Line,
+				ReviewID:    review.ID,
+				ReviewState: string(review.State),
+			}
+			if thread.CommentNodeID != nil {
+				parent.CommentNodeID = *thread.CommentNodeID
+			}
+			comments = append(comments, parent)
+			for _, reply := range thread.ThreadComments {
+				// The report groups replies under the parent's review, so the
+				// reply's own review is unknown here.
+				comment := parent
+				comment.ReviewID, comment.ReviewState = "", ""
+				comment.AuthorLogin = reply.AuthorLogin
+				comment.Body = reply.Body
+				comment.CreatedAt = reply.CreatedAt
+				comment.CommentNodeID = ""
+				if reply.CommentNodeID != nil {
+					comment.CommentNodeID = *reply.CommentNodeID
+				}
+				comments = append(comments, comment)
+			}
+		}
+	}
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		return createdAt(comments[i]).Before(createdAt(comments[j]))
+	})
+	return comments, nil
+}
+
+// AddConversation posts a top-level conversation comment on the pull request.
+func (s *Service) AddConversation(pr resolver.Identity, body string) (Comment, error) {
+	if strings.TrimSpace(body) == "" {
+		return Comment{}, errors.New("comment body is required")
+	}
+	body, err := s.Secrets.Check(body)
+	if err != nil {
+		return Comment{}, err
+	}
+	if err := ghcli.CheckBodyLength(body); err != nil {
+		return Comment{}, err
+	}
+
+	var pull struct {
+		Repository *struct {
+			PullRequest *struct {
+				ID string `json:"id"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	variables := map[string]interface{}{"owner": pr.Owner, "name": pr.Repo, "number": pr.Number}
+	if err := s.API.GraphQL(pullRequestNodeQuery, variables, &pull); err != nil {
+		return Comment{}, err
+	}
+	if pull.Repository == nil || pull.Repository.PullRequest == nil || pull.Repository.PullRequest.ID == "" {
+		return Comment{}, fmt.Errorf("pull request %s/%s#%d not found", pr.Owner, pr.Repo, pr.Number)
+	}
+
+	variables = map[string]interface{}{
+		"input": map[string]interface{}{"subjectId": pull.Repository.PullRequest.ID, "body": body},
+	}
+	var response struct {
+		AddComment struct {
+			CommentEdge *struct {
+				Node *struct {
+					ID        string `json:"id"`
+					Body      string `json:"body"`
+					CreatedAt string `json:"createdAt"`
+					Author    *struct {
+						Login string `json:"login"`
+					} `json:"author"`
+				} `json:"node"`
+			} `json:"commentEdge"`
+		} `json:"addComment"`
+	}
+	if err := s.API.GraphQL(addCommentMutation, variables, &response); err != nil {
+		return Comment{}, err
+	}
+
+	edge := response.AddComment.CommentEdge
+	if edge == nil || edge.Node == nil || strings.TrimSpace(edge.Node.ID) == "" {
+		return Comment{}, errors.New("addComment response missing comment")
+	}
+	comment := Comment{
+		Kind:          KindConversation,
+		CommentNodeID: edge.Node.ID,
+		Body:          edge.Node.Body,
+		CreatedAt:     edge.Node.CreatedAt,
+	}
+	if edge.Node.Author != nil {
+		comment.AuthorLogin = edge.Node.Author.Login
+	}
+	return comment, nil
+}
+
+func conversationComments(conversation []report.ReportConversation) []Comment {
+	comments := make([]Comment, len(conversation))
+	for i, entry := range conversation {
+		comments[i] = Comment{
+			Kind:          KindConversation,
+			CommentNodeID: entry.CommentNodeID,
+			AuthorLogin:   entry.AuthorLogin,
+			Body:          entry.Body,
+			CreatedAt:     entry.CreatedAt,
+		}
+	}
+	return comments
+}
+
+func createdAt(comment Comment) time.Time {
+	at, _ := time.Parse(time.RFC3339, comment.CreatedAt)
+	return at
+}
@@ -0,0 +1,117 @@
+package comments
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const minimizeCommentMutation = `mutation MinimizeComment($input: MinimizeCommentInput!) {
+  minimizeComment(input: $input) {
+    minimizedComment {
+      isMinimized
+      minimizedReason
+    }
+  }
+}`
+
+const unminimizeCommentMutation = `mutation UnminimizeComment($input: UnminimizeCommentInput!) {
+  unminimizeComment(input: $input) {
+    unminimizedComment {
+      isMinimized
+    }
+  }
+}`
+
+// HideReasons are the ReportedContentClassifiers accepted by comments hide.
+var HideReasons = []string{"OUTDATED", "RESOLVED", "OFF_TOPIC", "SPAM", "DUPLICATE"}
+
+// HideOptions identifies a comment and the reason for minimizing it.
+type HideOptions struct {
+	CommentID string
+	Reason    string
+}
+
+// Visibility is the minimized state of a comment after hide or unhide.
+type Visibility struct {
+	CommentNodeID   string `json:"comment_node_id"`
+	IsMinimized     bool   `json:"is_minimized"`
+	MinimizedReason string `json:"minimized_reason,omitempty"`
+}
+
+// ParseHideReason normalizes a reason such as "off-topic" to its classifier
+// value.
+func ParseHideReason(raw string) (string, error) {
+	reason := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(raw), "-", "_"))
+	for _, known := range HideReasons {
+		if reason == known {
+			return reason, nil
+		}
+	}
+	return "", fmt.Errorf("invalid reason %q: must be one of %s", raw, strings.Join(HideReasons, ", "))
+}
+
+// Hide minimizes a comment so it collapses in the GitHub UI.
+func (s *Service) Hide(opts HideOptions) (Visibility, error) {
+	commentID := strings.TrimSpace(opts.CommentID)
+	if commentID == "" {
+		return Visibility{}, errors.New("comment id is required")
+	}
+	reason, err := ParseHideReason(opts.Reason)
+	if err != nil {
+		return Visibility{}, err
+	}
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{"subjectId": commentID, "classifier": reason},
+	}
+	var response struct {
+		MinimizeComment struct {
+			Comment *struct {
+				IsMinimized     bool   `json:"isMinimized"`
+				MinimizedReason string `json:"minimizedReason"`
+			} `json:"minimizedComment"`
+		} `json:"minimizeComment"`
+	}
+	if err := s.API.GraphQL(minimizeCommentMutation, variables, &response); err != nil {
+		return Visibility{}, err
+	}
+
+	comment := response.MinimizeComment.Comment
+	if comment == nil {
+		return Visibility{}, errors.New("minimizeComment response missing comment")
+	}
+	result := Visibility{CommentNodeID: commentID, IsMinimized: comment.IsMinimized}
+	if comment.IsMinimized {
+		result.MinimizedReason = reason
+	}
+	return result, nil
+}
+
+// Unhide restores a minimized comment.
+func (s *Service) Unhide(commentID string) (Visibility, error) {
+	id := strings.TrimSpace(commentID)
+	if id == "" {
+		return Visibility{}, errors.New("comment id is required")
+	}
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{"subjectId": id},
+	}
+	var response struct {
+		UnminimizeComment struct {
+			Comment *struct {
+				IsMinimized bool `json:"isMinimized"`
+			} `json:"unminimizedComment"`
+		} `json:"unminimizeComment"`
+	}
+	if err := s.API.GraphQL(unminimizeCommentMutation, variables, &response); err != nil {
+		return Visibility{}, err
+	}
+
+	comment := response.UnminimizeComment.Comment
+	if comment == nil {
+		return Visibility{}, errors.New("unminimizeComment response missing comment")
+	}
+	return Visibility{CommentNodeID: id, IsMinimized: comment.IsMinimized}, nil
+}
@@ -0,0 +1,105 @@
+package comments
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const addReactionMutation = `mutation AddReaction($input: AddReactionInput!) {
+  addReaction(input: $input) {
+    subject {
+      id
+      reactionGroups { content viewerHasReacted users { totalCount } }
+    }
+  }
+}`
+
+const removeReactionMutation = `mutation RemoveReaction($input: RemoveReactionInput!) {
+  removeReaction(input: $input) {
+    subject {
+      id
+      reactionGroups { content viewerHasReacted users { totalCount } }
+    }
+  }
+}`
+
+// Reactions are the GitHub ReactionContent values.
+var Reactions = []string{"THUMBS_UP", "THUMBS_DOWN", "LAUGH", "HOORAY", "CONFUSED", "HEART", "ROCKET", "EYES"}
+
+// ReactOptions identifies a comment and the reaction to add or remove.
+type ReactOptions struct {
+	CommentID string
+	Reaction  string
+	Remove    bool
+}
+
+// ReactionResult is the state of one reaction on a comment after a change.
+type ReactionResult struct {
+	CommentNodeID    string `json:"comment_node_id"`
+	Reaction         string `json:"reaction"`
+	Count            int    `json:"count"`
+	ViewerHasReacted bool   `json:"viewer_has_reacted"`
+}
+
+// ParseReaction normalizes a reaction name such as "thumbs_up" to its
+// ReactionContent value.
+func ParseReaction(raw string) (string, error) {
+	reaction := strings.ToUpper(strings.TrimSpace(raw))
+	for _, known := range Reactions {
+		if reaction == known {
+			return reaction, nil
+		}
+	}
+	return "", fmt.Errorf("invalid reaction %q: must be one of %s", raw, strings.Join(Reactions, ", "))
+}
+
+// React adds the viewer's reaction to a comment, or removes it with
+// opts.Remove. Both are idempotent on GitHub.
+func (s *Service) React(opts ReactOptions) (ReactionResult, error) {
+	commentID := strings.TrimSpace(opts.CommentID)
+	if commentID == "" {
+		return ReactionResult{}, errors.New("comment id is required")
+	}
+	reaction, err := ParseReaction(opts.Reaction)
+	if err != nil {
+		return ReactionResult{}, err
+	}
+
+	mutation, field := addReactionMutation, "addReaction"
+	if opts.Remove {
+		mutation, field = removeReactionMutation, "removeReaction"
+	}
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{"subjectId": commentID, "content": reaction},
+	}
+
+	var response map[string]*struct {
+		Subject *struct {
+			ID             string `json:"id"`
+			ReactionGroups []struct {
+				Content          string `json:"content"`
+				ViewerHasReacted bool   `json:"viewerHasReacted"`
+				Users            struct {
+					TotalCount int `json:"totalCount"`
+				} `json:"users"`
+			} `json:"reactionGroups"`
+		} `json:"subject"`
+	}
+	if err := s.API.GraphQL(mutation, variables, &response); err != nil {
+		return ReactionResult{}, err
+	}
+
+	payload := response[field]
+	if payload == nil || payload.Subject == nil || strings.TrimSpace(payload.Subject.ID) == "" {
+		return ReactionResult{}, fmt.Errorf("%s response missing subject", field)
+	}
+	result := ReactionResult{CommentNodeID: payload.Subject.ID, Reaction: reaction}
+	for _, group := range payload.Subject.ReactionGroups {
+		if group.Content == reaction {
+			result.Count = group.Users.TotalCount
+			result.ViewerHasReacted = group.ViewerHasReacted
+		}
+	}
+	return result, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
 	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/secrets"
 )
 
 const addThreadReplyMutation = `mutation AddPullRequestReviewThreadReply($input: AddPullRequestReviewThreadReplyInput!) {
@@ -50,6 +51,8 @@ const threadDetailsQuery = `query PullRequestReviewThreadDetails($id: ID!) {
 // Service provides high-level review comment operations.
 type Service struct {
 	API ghcli.API
+	// Secrets, when set, checks every body before it is posted.
+	Secrets *secrets.Scanner
 }
 
 // ReplyOptions contains the payload for replying to a review comment thread.
@@ -121,10 +124,17 @@ func (s *Service) Reply(_ resolver.Identity, opts ReplyOptions) (Reply, error) {
 	if strings.TrimSpace(opts.Body) == "" {
 		return Reply{}, errors.New("reply body is required")
 	}
+	body, err := s.Secrets.Check(opts.Body)
+	if err != nil {
+		return Reply{}, err
+	}
+	if err := ghcli.CheckBodyLength(body); err != nil {
+		return Reply{}, err
+	}
 
 	input := map[string]interface{}{
 		"pullRequestReviewThreadId": threadID,
-		"body":                      opts.Body,
+		"body":                      body,
 	}
 	if reviewID := strings.TrimSpace(opts.ReviewID); reviewID != "" {
 		input["pullRequestReviewId"] = reviewID
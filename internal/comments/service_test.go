@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/secrets"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -56,6 +57,27 @@ func TestServiceReply_RejectsBlankBody(t *testing.T) {
 	assert.Contains(t, err.Error(), "reply body is required")
 }
 
+func TestServiceReply_RejectsOversizedBody(t *testing.T) {
+	api := &fakeAPI{}
+	svc := NewService(api)
+
+	_, err := svc.Reply(resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: strings.Repeat("é", 65537)})
+	require.EqualError(t, err, "body is 65537 characters; GitHub allows at most 65536")
+}
+
+func TestServiceReply_RefusesSecrets(t *testing.T) {
+	api := &fakeAPI{}
+	scanner, err := secrets.New(nil, false)
+	require.NoError(t, err)
+	svc := &Service{API: api, Secrets: scanner}
+
+	_, err = svc.Reply(resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: "AKIA" + strings.Repeat("Q", 16)})
+	var found *secrets.Error
+	require.ErrorAs(t, err, &found)
+	require.Len(t, found.Findings, 1)
+	assert.Equal(t, "aws-access-key-id", found.Findings[0].Rule)
+}
+
 func TestServiceReply_SendsMutation(t *testing.T) {
 	api := &fakeAPI{}
 	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
@@ -285,3 +307,233 @@ func TestServiceReply_ErrorsOnMissingThread(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to load thread details")
 }
+
+func TestServiceEdit_SendsMutation(t *testing.T) {
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Contains(t, query, "updatePullRequestReviewComment")
+		input := variables["input"].(map[string]interface{})
+		assert.Equal(t, "PRRC_1", input["pullRequestReviewCommentId"])
+		assert.Equal(t, "updated", input["body"])
+		return assign(result, map[string]interface{}{
+			"updatePullRequestReviewComment": map[string]interface{}{
+				"pullRequestReviewComment": map[string]interface{}{
+					"id":        "PRRC_1",
+					"body":      "updated",
+					"updatedAt": "2025-12-03T10:00:00Z",
+				},
+			},
+		})
+	}
+
+	edit, err := NewService(api).Edit(EditOptions{CommentID: " PRRC_1 ", Body: "updated"})
+	require.NoError(t, err)
+	assert.Equal(t, Edit{CommentNodeID: "PRRC_1", Body: "updated", UpdatedAt: "2025-12-03T10:00:00Z"}, edit)
+}
+
+func TestServiceDelete_SendsMutation(t *testing.T) {
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Contains(t, query, "deletePullRequestReviewComment")
+		assert.Equal(t, map[string]interface{}{"id": "PRRC_1"}, variables["input"])
+		return nil
+	}
+
+	deletion, err := NewService(api).Delete("PRRC_1")
+	require.NoError(t, err)
+	assert.Equal(t, Deletion{CommentNodeID: "PRRC_1", Deleted: true}, deletion)
+
+	_, err = NewService(&fakeAPI{}).Delete(" ")
+	require.EqualError(t, err, "comment id is required")
+}
+
+func TestServiceReact(t *testing.T) {
+	api := &fakeAPI{}
+	var mutations []string
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		input := variables["input"].(map[string]interface{})
+		assert.Equal(t, "PRRC_1", input["subjectId"])
+		assert.Equal(t, "THUMBS_UP", input["content"])
+
+		field, reacted, count := "addReaction", true, 3
+		if strings.Contains(query, "RemoveReaction") {
+			field, reacted, count = "removeReaction", false, 2
+		}
+		mutations = append(mutations, field)
+		return assign(result, map[string]interface{}{
+			field: map[string]interface{}{
+				"subject": map[string]interface{}{
+					"id": "PRRC_1",
+					"reactionGroups": []map[string]interface{}{
+						{"content": "HEART", "viewerHasReacted": true, "users": map[string]interface{}{"totalCount": 1}},
+						{"content": "THUMBS_UP", "viewerHasReacted": reacted, "users": map[string]interface{}{"totalCount": count}},
+					},
+				},
+			},
+		})
+	}
+	svc := NewService(api)
+
+	added, err := svc.React(ReactOptions{CommentID: " PRRC_1 ", Reaction: "thumbs_up"})
+	require.NoError(t, err)
+	assert.Equal(t, ReactionResult{CommentNodeID: "PRRC_1", Reaction: "THUMBS_UP", Count: 3, ViewerHasReacted: true}, added)
+
+	removed, err := svc.React(ReactOptions{CommentID: "PRRC_1", Reaction: "THUMBS_UP", Remove: true})
+	require.NoError(t, err)
+	assert.Equal(t, ReactionResult{CommentNodeID: "PRRC_1", Reaction: "THUMBS_UP", Count: 2}, removed)
+	assert.Equal(t, []string{"addReaction", "removeReaction"}, mutations)
+
+	_, err = svc.React(ReactOptions{CommentID: "PRRC_1", Reaction: "+1"})
+	require.EqualError(t, err, `invalid reaction "+1": must be one of THUMBS_UP, THUMBS_DOWN, LAUGH, HOORAY, CONFUSED, HEART, ROCKET, EYES`)
+}
+
+func TestServiceHideAndUnhide(t *testing.T) {
+	api := &fakeAPI{}
+	var mutations []string
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		input := variables["input"].(map[string]interface{})
+		assert.Equal(t, "PRRC_1", input["subjectId"])
+		if strings.Contains(query, "UnminimizeComment") {
+			mutations = append(mutations, "unminimizeComment")
+			return assign(result, map[string]interface{}{
+				"unminimizeComment": map[string]interface{}{"unminimizedComment": map[string]interface{}{"isMinimized": false}},
+			})
+		}
+		mutations = append(mutations, "minimizeComment")
+		assert.Equal(t, "OFF_TOPIC", input["classifier"])
+		return assign(result, map[string]interface{}{
+			"minimizeComment": map[string]interface{}{"minimizedComment": map[string]interface{}{"isMinimized": true, "minimizedReason": "off-topic"}},
+		})
+	}
+	svc := NewService(api)
+
+	hidden, err := svc.Hide(HideOptions{CommentID: " PRRC_1 ", Reason: "off-topic"})
+	require.NoError(t, err)
+	assert.Equal(t, Visibility{CommentNodeID: "PRRC_1", IsMinimized: true, MinimizedReason: "OFF_TOPIC"}, hidden)
+
+	shown, err := svc.Unhide("PRRC_1")
+	require.NoError(t, err)
+	assert.Equal(t, Visibility{CommentNodeID: "PRRC_1"}, shown)
+	assert.Equal(t, []string{"minimizeComment", "unminimizeComment"}, mutations)
+
+	_, err = svc.Hide(HideOptions{CommentID: "PRRC_1", Reason: "ABUSE"})
+	require.EqualError(t, err, `invalid reason "ABUSE": must be one of OUTDATED, RESOLVED, OFF_TOPIC, SPAM, DUPLICATE`)
+}
+
+func TestServiceListMergesCommentKinds(t *testing.T) {
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		if strings.Contains(query, "query ReportConversation") {
+			assert.Equal(t, "cursor1", variables["after"])
+			return assign(result, map[string]interface{}{
+				"repository": map[string]interface{}{"pullRequest": map[string]interface{}{"comments": map[string]interface{}{
+					"nodes":    []map[string]interface{}{{"id": "IC_2", "body": "second page", "createdAt": "2025-12-03T10:05:00Z", "author": map[string]interface{}{"login": "carol"}}},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				}}},
+			})
+		}
+		require.Contains(t, query, "query Report(")
+		assert.Equal(t, true, variables["includeConversation"])
+		return assign(result, map[string]interface{}{
+			"repository": map[string]interface{}{"pullRequest": map[string]interface{}{
+				"comments": map[string]interface{}{
+					"nodes":    []map[string]interface{}{{"id": "IC_1", "body": "first", "createdAt": "2025-12-03T09:00:00Z", "author": map[string]interface{}{"login": "alice"}}},
+					"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "cursor1"},
+				},
+				"reviews": map[string]interface{}{"nodes": []map[string]interface{}{
+					{"id": "PRR_1", "state": "COMMENTED", "body": "Overall fine", "submittedAt": "2025-12-03T10:00:00Z", "databaseId": 1, "author": map[string]interface{}{"login": "bob"}},
+				}},
+				"reviewThreads": map[string]interface{}{"nodes": []map[string]interface{}{{
+					"id": "PRRT_1", "path": "main.go", "line": 4, "isResolved": false, "isOutdated": false,
+					"comments": map[string]interface{}{"nodes": []map[string]interface{}{
+						{"id": "PRRC_1", "databaseId": 11, "body": "nit", "createdAt": "2025-12-03T09:58:00Z", "updatedAt": "2025-12-03T09:58:00Z", "author": map[string]interface{}{"login": "bob"}, "pullRequestReview": map[string]interface{}{"id": "PRR_1", "state": "COMMENTED", "databaseId": 1}},
+						{"id": "PRRC_2", "databaseId": 12, "body": "done", "createdAt": "2025-12-03T10:01:00Z", "updatedAt": "2025-12-03T10:01:00Z", "author": map[string]interface{}{"login": "alice"}, "pullRequestReview": map[string]interface{}{"id": "PRR_2", "state": "COMMENTED", "databaseId": 2}, "replyTo": map[string]interface{}{"id": "PRRC_1", "databaseId": 11}},
+					}},
+				}}},
+			}},
+		})
+	}
+
+	list, err := NewService(api).List(resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}, ListOptions{})
+	require.NoError(t, err)
+
+	kinds := make([]string, len(list))
+	ids := make([]string, len(list))
+	for i, comment := range list {
+		kinds[i], ids[i] = comment.Kind, comment.CommentNodeID
+	}
+	assert.Equal(t, []string{KindConversation, KindReviewThread, KindReviewBody, KindReviewThread, KindConversation}, kinds)
+	assert.Equal(t, []string{"IC_1", "PRRC_1", "PRR_1", "PRRC_2", "IC_2"}, ids)
+	assert.Equal(t, "PRRT_1", list[3].ThreadID)
+	assert.Equal(t, "main.go", list[3].Path)
+	assert.Equal(t, "COMMENTED", list[2].ReviewState)
+	assert.Empty(t, list[3].ReviewID)
+}
+
+func TestServiceListReadsEveryReviewAndThreadPage(t *testing.T) {
+	comment := func(id, review string, createdAt string) map[string]interface{} {
+		return map[string]interface{}{"id": id, "databaseId": 1, "body": id, "createdAt": createdAt, "updatedAt": createdAt, "author": map[string]interface{}{"login": "bob"}, "pullRequestReview": map[string]interface{}{"id": review, "state": "COMMENTED", "databaseId": 1}}
+	}
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "query ReportReviews("):
+			assert.Equal(t, "reviews1", variables["after"])
+			return assign(result, map[string]interface{}{"repository": map[string]interface{}{"pullRequest": map[string]interface{}{"reviews": map[string]interface{}{
+				"nodes":    []map[string]interface{}{{"id": "PRR_2", "state": "COMMENTED", "body": "Second review", "submittedAt": "2025-12-03T11:00:00Z", "databaseId": 2, "author": map[string]interface{}{"login": "bob"}}},
+				"pageInfo": map[string]interface{}{"hasNextPage": false},
+			}}}})
+		case strings.Contains(query, "query ReportThreads("):
+			assert.Equal(t, "threads1", variables["after"])
+			return assign(result, map[string]interface{}{"repository": map[string]interface{}{"pullRequest": map[string]interface{}{"reviewThreads": map[string]interface{}{
+				"nodes": []map[string]interface{}{{"id": "PRRT_2", "path": "b.go", "isResolved": false, "isOutdated": false,
+					"comments": map[string]interface{}{"nodes": []map[string]interface{}{comment("PRRC_2", "PRR_2", "2025-12-03T11:01:00Z")}}}},
+				"pageInfo": map[string]interface{}{"hasNextPage": false},
+			}}}})
+		}
+		require.Contains(t, query, "query Report(")
+		return assign(result, map[string]interface{}{"repository": map[string]interface{}{"pullRequest": map[string]interface{}{
+			"comments": map[string]interface{}{"nodes": []map[string]interface{}{}, "pageInfo": map[string]interface{}{"hasNextPage": false}},
+			"reviews": map[string]interface{}{
+				"nodes":    []map[string]interface{}{{"id": "PRR_1", "state": "COMMENTED", "body": "First review", "submittedAt": "2025-12-03T10:00:00Z", "databaseId": 1, "author": map[string]interface{}{"login": "bob"}}},
+				"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "reviews1"},
+			},
+			"reviewThreads": map[string]interface{}{
+				"nodes": []map[string]interface{}{{"id": "PRRT_1", "path": "a.go", "isResolved": false, "isOutdated": false,
+					"comments": map[string]interface{}{"nodes": []map[string]interface{}{comment("PRRC_1", "PRR_1", "2025-12-03T10:01:00Z")}}}},
+				"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "threads1"},
+			},
+		}}})
+	}
+
+	list, err := NewService(api).List(resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}, ListOptions{})
+	require.NoError(t, err)
+
+	ids := make([]string, len(list))
+	for i, comment := range list {
+		ids[i] = comment.CommentNodeID
+	}
+	assert.Equal(t, []string{"PRR_1", "PRRC_1", "PRR_2", "PRRC_2"}, ids)
+}
+
+func TestServiceAddConversation(t *testing.T) {
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		if strings.Contains(query, "PullRequestNode") {
+			return assign(result, map[string]interface{}{"repository": map[string]interface{}{"pullRequest": map[string]interface{}{"id": "PR_7"}}})
+		}
+		input := variables["input"].(map[string]interface{})
+		assert.Equal(t, "PR_7", input["subjectId"])
+		assert.Equal(t, "Thanks!", input["body"])
+		return assign(result, map[string]interface{}{"addComment": map[string]interface{}{"commentEdge": map[string]interface{}{"node": map[string]interface{}{
+			"id": "IC_9", "body": "Thanks!", "createdAt": "2025-12-03T11:00:00Z", "author": map[string]interface{}{"login": "octocat"},
+		}}}})
+	}
+
+	comment, err := NewService(api).AddConversation(resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}, "Thanks!")
+	require.NoError(t, err)
+	assert.Equal(t, Comment{Kind: KindConversation, CommentNodeID: "IC_9", AuthorLogin: "octocat", Body: "Thanks!", CreatedAt: "2025-12-03T11:00:00Z"}, comment)
+
+	_, err = NewService(api).AddConversation(resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}, "  ")
+	require.EqualError(t, err, "comment body is required")
+}
@@ -0,0 +1,181 @@
+package commits
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hunkHeaderRE = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// Change is a contiguous block of removed and/or added lines within a patch,
+// excluding unchanged context lines. A block with NewLines == 0 is a pure
+// deletion located after line NewStart of the new file; OldLines == 0 marks a
+// pure insertion.
+type Change struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+}
+
+// LineRange is an inclusive range of line numbers.
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// ParseChanges extracts the changed line blocks from a unified diff patch.
+func ParseChanges(patch string) ([]Change, error) {
+	var (
+		changes []Change
+		current *Change
+		oldLine int
+		newLine int
+		inHunk  bool
+		flush   = func() {
+			if current != nil {
+				changes = append(changes, *current)
+				current = nil
+			}
+		}
+	)
+
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			flush()
+			matches := hunkHeaderRE.FindStringSubmatch(line)
+			if matches == nil {
+				return nil, fmt.Errorf("invalid hunk header %q", line)
+			}
+			oldLine, _ = strconv.Atoi(matches[1])
+			newLine, _ = strconv.Atoi(matches[3])
+			inHunk = true
+			continue
+		}
+		if !inHunk || line == "" {
+			continue
+		}
+
+		switch line[0] {
+		case '-':
+			if current == nil {
+				current = &Change{OldStart: oldLine, NewStart: newLine - 1}
+			}
+			if current.OldLines == 0 {
+				current.OldStart = oldLine
+			}
+			current.OldLines++
+			oldLine++
+		case '+':
+			if current == nil {
+				current = &Change{OldStart: oldLine - 1, NewStart: newLine}
+			}
+			if current.NewLines == 0 {
+				current.NewStart = newLine
+			}
+			current.NewLines++
+			newLine++
+		case '\\':
+			// "\ No newline at end of file" markers carry no line.
+		default:
+			flush()
+			oldLine++
+			newLine++
+		}
+	}
+	flush()
+
+	return changes, nil
+}
+
+// Touches reports whether any change modifies lines within r, expressed in the
+// coordinates of the new file. Deletions count when they fall strictly inside r.
+func Touches(changes []Change, r LineRange) bool {
+	for _, change := range changes {
+		if change.NewLines > 0 {
+			end := change.NewStart + change.NewLines - 1
+			if change.NewStart <= r.End && end >= r.Start {
+				return true
+			}
+			continue
+		}
+		if change.NewStart >= r.Start && change.NewStart < r.End {
+			return true
+		}
+	}
+	return false
+}
+
+// MapToOld translates a range in new-file coordinates into the coordinates of
+// the file before the changes were applied. Endpoints that fall inside a
+// changed block snap to that block's old position.
+func MapToOld(changes []Change, r LineRange) LineRange {
+	return LineRange{Start: mapLine(changes, r.Start), End: mapLine(changes, r.End)}
+}
+
+func mapLine(changes []Change, line int) int {
+	shift := 0
+	for _, change := range changes {
+		if change.NewLines > 0 {
+			end := change.NewStart + change.NewLines - 1
+			if line < change.NewStart {
+				break
+			}
+			if line <= end {
+				start := change.OldStart
+				if change.OldLines == 0 {
+					start++
+				}
+				return start
+			}
+		} else if line <= change.NewStart {
+			break
+		}
+		shift += change.NewLines - change.OldLines
+	}
+	return line - shift
+}
+
+// DiffLines returns the lines a review comment can anchor to on each side of
+// a patch, keyed by line number: left holds removed and context lines of the
+// old file, right holds added and context lines of the new file.
+func DiffLines(patch string) (left, right map[int]string, err error) {
+	left = make(map[int]string)
+	right = make(map[int]string)
+	var oldLine, newLine int
+	inHunk := false
+
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			matches := hunkHeaderRE.FindStringSubmatch(line)
+			if matches == nil {
+				return nil, nil, fmt.Errorf("invalid hunk header %q", line)
+			}
+			oldLine, _ = strconv.Atoi(matches[1])
+			newLine, _ = strconv.Atoi(matches[3])
+			inHunk = true
+			continue
+		}
+		if !inHunk || line == "" {
+			continue
+		}
+
+		switch line[0] {
+		case '-':
+			left[oldLine] = line[1:]
+			oldLine++
+		case '+':
+			right[newLine] = line[1:]
+			newLine++
+		case '\\':
+		default:
+			left[oldLine] = line[1:]
+			right[newLine] = line[1:]
+			oldLine++
+			newLine++
+		}
+	}
+	return left, right, nil
+}
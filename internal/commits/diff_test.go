@@ -0,0 +1,76 @@
+package commits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const samplePatch = `@@ -1,6 +1,7 @@
+ package main
+ 
+-import "fmt"
++import (
++	"fmt"
++)
+ 
+ func main() {
+@@ -20,7 +22,6 @@ func helper() {
+ 	a := 1
+ 	b := 2
+-	c := 3
+ 	return a + b
+ }
+ 
+\ No newline at end of file`
+
+func TestParseChanges(t *testing.T) {
+	changes, err := ParseChanges(samplePatch)
+	require.NoError(t, err)
+	assert.Equal(t, []Change{
+		{OldStart: 3, OldLines: 1, NewStart: 3, NewLines: 3},
+		{OldStart: 22, OldLines: 1, NewStart: 23, NewLines: 0},
+	}, changes)
+}
+
+func TestParseChangesRejectsInvalidHeader(t *testing.T) {
+	_, err := ParseChanges("@@ bogus @@\n+line")
+	require.Error(t, err)
+}
+
+func TestTouches(t *testing.T) {
+	changes, err := ParseChanges(samplePatch)
+	require.NoError(t, err)
+
+	assert.True(t, Touches(changes, LineRange{Start: 4, End: 4}), "modified import block")
+	assert.False(t, Touches(changes, LineRange{Start: 1, End: 2}), "context only")
+	assert.True(t, Touches(changes, LineRange{Start: 22, End: 24}), "deletion inside range")
+	assert.False(t, Touches(changes, LineRange{Start: 24, End: 25}), "lines after deletion")
+}
+
+func TestMapToOld(t *testing.T) {
+	changes, err := ParseChanges(samplePatch)
+	require.NoError(t, err)
+
+	assert.Equal(t, LineRange{Start: 1, End: 2}, MapToOld(changes, LineRange{Start: 1, End: 2}))
+	assert.Equal(t, LineRange{Start: 5, End: 6}, MapToOld(changes, LineRange{Start: 7, End: 8}))
+	assert.Equal(t, LineRange{Start: 3, End: 3}, MapToOld(changes, LineRange{Start: 4, End: 5}))
+	assert.Equal(t, LineRange{Start: 23, End: 24}, MapToOld(changes, LineRange{Start: 24, End: 25}))
+}
+
+func TestDiffLines(t *testing.T) {
+	left, right, err := DiffLines(samplePatch)
+	require.NoError(t, err)
+
+	assert.Equal(t, `import "fmt"`, left[3])
+	assert.Equal(t, "import (", right[3])
+	assert.Equal(t, ")", right[5])
+	assert.Equal(t, "func main() {", right[7])
+	assert.Equal(t, "func main() {", left[5])
+	assert.Equal(t, "\tc := 3", left[22])
+	assert.Equal(t, "\treturn a + b", right[24])
+	assert.NotContains(t, right, 8)
+	assert.Len(t, left, 11)
+	assert.Len(t, right, 12)
+}
@@ -0,0 +1,188 @@
+package commits
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+const perPage = 100
+
+// Commit describes a commit that belongs to a pull request.
+type Commit struct {
+	SHA         string
+	Headline    string
+	CommittedAt time.Time
+}
+
+// File describes how a commit or pull request changed one file.
+type File struct {
+	Filename         string
+	PreviousFilename string
+	Status           string
+	// Patch is the unified diff for the file; GitHub omits it for binary or very large changes.
+	Patch string
+}
+
+// Fetcher loads pull request commit history and per-commit diffs through the REST API.
+// Commit file lists are cached, so a Fetcher can be shared across many lookups.
+type Fetcher struct {
+	API ghcli.API
+
+	mu    sync.Mutex
+	files map[string][]File
+}
+
+// NewFetcher constructs a Fetcher using the provided API client.
+func NewFetcher(api ghcli.API) *Fetcher {
+	return &Fetcher{API: api, files: make(map[string][]File)}
+}
+
+type restCommit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Message   string `json:"message"`
+		Committer *struct {
+			Date time.Time `json:"date"`
+		} `json:"committer"`
+		Author *struct {
+			Date time.Time `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+	Files []struct {
+		Filename         string `json:"filename"`
+		PreviousFilename string `json:"previous_filename"`
+		Status           string `json:"status"`
+		Patch            string `json:"patch"`
+	} `json:"files"`
+}
+
+// PullCommits returns the pull request commits in the order GitHub lists them (oldest first).
+func (f *Fetcher) PullCommits(pr resolver.Identity) ([]Commit, error) {
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d/commits", pr.Owner, pr.Repo, pr.Number)
+
+	commits := make([]Commit, 0)
+	for page := 1; ; page++ {
+		var chunk []restCommit
+		params := map[string]string{
+			"per_page": strconv.Itoa(perPage),
+			"page":     strconv.Itoa(page),
+		}
+		if err := f.API.REST("GET", path, params, nil, &chunk); err != nil {
+			return nil, fmt.Errorf("list pull request commits: %w", err)
+		}
+
+		for _, raw := range chunk {
+			if strings.TrimSpace(raw.SHA) == "" {
+				return nil, errors.New("pull request commit missing sha")
+			}
+			commits = append(commits, Commit{
+				SHA:         raw.SHA,
+				Headline:    headline(raw.Commit.Message),
+				CommittedAt: commitDate(raw),
+			})
+		}
+
+		if len(chunk) < perPage {
+			break
+		}
+	}
+
+	return commits, nil
+}
+
+// PullFiles returns the files changed by the pull request as a whole, with
+// patches relative to the merge base.
+func (f *Fetcher) PullFiles(pr resolver.Identity) ([]File, error) {
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d/files", pr.Owner, pr.Repo, pr.Number)
+
+	files := make([]File, 0)
+	for page := 1; ; page++ {
+		var chunk []struct {
+			Filename         string `json:"filename"`
+			PreviousFilename string `json:"previous_filename"`
+			Status           string `json:"status"`
+			Patch            string `json:"patch"`
+		}
+		params := map[string]string{
+			"per_page": strconv.Itoa(perPage),
+			"page":     strconv.Itoa(page),
+		}
+		if err := f.API.REST("GET", path, params, nil, &chunk); err != nil {
+			return nil, fmt.Errorf("list pull request files: %w", err)
+		}
+
+		for _, file := range chunk {
+			files = append(files, File{
+				Filename:         file.Filename,
+				PreviousFilename: file.PreviousFilename,
+				Status:           file.Status,
+				Patch:            file.Patch,
+			})
+		}
+
+		if len(chunk) < perPage {
+			break
+		}
+	}
+
+	return files, nil
+}
+
+// CommitFiles returns the files changed by a commit.
+func (f *Fetcher) CommitFiles(pr resolver.Identity, sha string) ([]File, error) {
+	f.mu.Lock()
+	cached, ok := f.files[sha]
+	f.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	var raw restCommit
+	path := fmt.Sprintf("repos/%s/%s/commits/%s", pr.Owner, pr.Repo, sha)
+	if err := f.API.REST("GET", path, nil, nil, &raw); err != nil {
+		return nil, fmt.Errorf("load commit %s: %w", sha, err)
+	}
+
+	files := make([]File, len(raw.Files))
+	for i, file := range raw.Files {
+		files[i] = File{
+			Filename:         file.Filename,
+			PreviousFilename: file.PreviousFilename,
+			Status:           file.Status,
+			Patch:            file.Patch,
+		}
+	}
+
+	f.mu.Lock()
+	if f.files == nil {
+		f.files = make(map[string][]File)
+	}
+	f.files[sha] = files
+	f.mu.Unlock()
+
+	return files, nil
+}
+
+func headline(message string) string {
+	if idx := strings.IndexByte(message, '\n'); idx >= 0 {
+		message = message[:idx]
+	}
+	return strings.TrimSpace(message)
+}
+
+func commitDate(raw restCommit) time.Time {
+	if raw.Commit.Committer != nil && !raw.Commit.Committer.Date.IsZero() {
+		return raw.Commit.Committer.Date
+	}
+	if raw.Commit.Author != nil {
+		return raw.Commit.Author.Date
+	}
+	return time.Time{}
+}
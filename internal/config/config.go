@@ -0,0 +1,311 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File names and environment variables read by the loader.
+const (
+	RepoFileName = ".gh-pr-review.yml"
+
+	EnvProfile = "GH_PR_REVIEW_PROFILE"
+	EnvOutput  = "GH_PR_REVIEW_OUTPUT"
+	EnvRepo    = "GH_REPO"
+	EnvHost    = "GH_HOST"
+)
+
+// Output formats.
+const (
+	OutputJSON   = "json"
+	OutputPretty = "pretty"
+)
+
+// Settings are the defaults a config file or profile can provide. Empty
+// fields are unset and fall through to lower precedence sources.
+type Settings struct {
+	Repo      string            `yaml:"repo"`
+	Host      string            `yaml:"host"`
+	Output    string            `yaml:"output"`
+	Retry     Retry             `yaml:"retry"`
+	Templates map[string]string `yaml:"templates"`
+	Scan      Scan              `yaml:"scan"`
+	// Defaults holds flag values keyed by command path (for example
+	// "review view") and flag name.
+	Defaults map[string]map[string]Value `yaml:"defaults"`
+}
+
+// Retry controls how read-only GitHub requests are retried after transient
+// failures. Attempts counts the first request.
+type Retry struct {
+	Attempts int      `yaml:"attempts"`
+	Delay    Duration `yaml:"delay"`
+}
+
+// Scan controls the secret scan of comment and review bodies before they are
+// posted. Scanning is on unless Enabled is false; Patterns add named regular
+// expressions to the built-in rules.
+type Scan struct {
+	Enabled  *bool             `yaml:"enabled"`
+	Redact   *bool             `yaml:"redact"`
+	Patterns map[string]string `yaml:"patterns"`
+}
+
+// File is the content of one config file: base settings plus named profiles.
+type File struct {
+	Settings `yaml:",inline"`
+	Profiles map[string]Settings `yaml:"profiles"`
+}
+
+// Value is a flag value. YAML lists become comma-separated strings, matching
+// how slice flags are written on the command line.
+type Value string
+
+// UnmarshalYAML accepts scalars and lists of scalars.
+func (v *Value) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*v = Value(node.Value)
+		return nil
+	case yaml.SequenceNode:
+		parts := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: list items must be scalars", item.Line)
+			}
+			parts = append(parts, item.Value)
+		}
+		*v = Value(strings.Join(parts, ","))
+		return nil
+	default:
+		return fmt.Errorf("line %d: flag value must be a scalar or a list", node.Line)
+	}
+}
+
+// Duration is a time.Duration written as a Go duration string ("500ms", "2s").
+type Duration time.Duration
+
+// UnmarshalYAML parses a Go duration string.
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	parsed, err := time.ParseDuration(node.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: invalid duration %q", node.Line, node.Value)
+	}
+	if parsed < 0 {
+		return fmt.Errorf("line %d: duration must not be negative", node.Line)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Decode parses a config file, rejecting unknown keys.
+func Decode(r io.Reader) (*File, error) {
+	var file File
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := file.Settings.Validate(); err != nil {
+		return nil, err
+	}
+	for name, profile := range file.Profiles {
+		if err := profile.Validate(); err != nil {
+			return nil, fmt.Errorf("profile %q: %w", name, err)
+		}
+	}
+	return &file, nil
+}
+
+// ReadFile loads a config file. A missing file yields nil without error.
+func ReadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	file, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// UserPath returns $XDG_CONFIG_HOME/gh-pr-review/config.yml, falling back to
+// ~/.config when XDG_CONFIG_HOME is unset.
+func UserPath() (string, error) {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("locate config directory: %w", err)
+		}
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "gh-pr-review", "config.yml"), nil
+}
+
+// RepoPath looks for .gh-pr-review.yml in dir and its parents, stopping at the
+// repository root (the first directory containing .git). It returns "" when
+// there is no repo config.
+func RepoPath(dir string) string {
+	for {
+		candidate := filepath.Join(dir, RepoFileName)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Resolve merges config files, lowest precedence first. Each file's selected
+// profile overrides that file's base settings. A non-empty profile must be
+// defined by at least one file.
+func Resolve(files []*File, profile string) (Settings, error) {
+	var merged Settings
+	found := profile == ""
+	for _, file := range files {
+		if file == nil {
+			continue
+		}
+		merged = merged.Merge(file.Settings)
+		if selected, ok := file.Profiles[profile]; ok && profile != "" {
+			merged = merged.Merge(selected)
+			found = true
+		}
+	}
+	if !found {
+		return Settings{}, fmt.Errorf("unknown profile %q (defined: %s)", profile, profileNames(files))
+	}
+	return merged, nil
+}
+
+// Env returns the settings provided by environment variables.
+func Env(getenv func(string) string) Settings {
+	return Settings{
+		Repo:   strings.TrimSpace(getenv(EnvRepo)),
+		Host:   strings.TrimSpace(getenv(EnvHost)),
+		Output: strings.ToLower(strings.TrimSpace(getenv(EnvOutput))),
+	}
+}
+
+// Merge returns s overridden by every field set in other.
+func (s Settings) Merge(other Settings) Settings {
+	if other.Repo != "" {
+		s.Repo = other.Repo
+	}
+	if other.Host != "" {
+		s.Host = other.Host
+	}
+	if other.Output != "" {
+		s.Output = other.Output
+	}
+	if other.Retry.Attempts > 0 {
+		s.Retry.Attempts = other.Retry.Attempts
+	}
+	if other.Retry.Delay > 0 {
+		s.Retry.Delay = other.Retry.Delay
+	}
+	if len(other.Templates) > 0 {
+		templates := make(map[string]string, len(s.Templates)+len(other.Templates))
+		for name, body := range s.Templates {
+			templates[name] = body
+		}
+		for name, body := range other.Templates {
+			templates[name] = body
+		}
+		s.Templates = templates
+	}
+	if other.Scan.Enabled != nil {
+		s.Scan.Enabled = other.Scan.Enabled
+	}
+	if other.Scan.Redact != nil {
+		s.Scan.Redact = other.Scan.Redact
+	}
+	if len(other.Scan.Patterns) > 0 {
+		patterns := make(map[string]string, len(s.Scan.Patterns)+len(other.Scan.Patterns))
+		for name, pattern := range s.Scan.Patterns {
+			patterns[name] = pattern
+		}
+		for name, pattern := range other.Scan.Patterns {
+			patterns[name] = pattern
+		}
+		s.Scan.Patterns = patterns
+	}
+	if len(other.Defaults) > 0 {
+		defaults := make(map[string]map[string]Value, len(s.Defaults)+len(other.Defaults))
+		for command, flags := range s.Defaults {
+			defaults[command] = flags
+		}
+		for command, flags := range other.Defaults {
+			combined := make(map[string]Value, len(defaults[command])+len(flags))
+			for name, value := range defaults[command] {
+				combined[name] = value
+			}
+			for name, value := range flags {
+				combined[name] = value
+			}
+			defaults[command] = combined
+		}
+		s.Defaults = defaults
+	}
+	return s
+}
+
+// Validate reports settings that cannot be applied.
+func (s Settings) Validate() error {
+	switch s.Output {
+	case "", OutputJSON, OutputPretty:
+	default:
+		return fmt.Errorf("invalid output %q: must be %s or %s", s.Output, OutputJSON, OutputPretty)
+	}
+	if s.Retry.Attempts < 0 {
+		return fmt.Errorf("invalid retry attempts %d: must be positive", s.Retry.Attempts)
+	}
+	for name, pattern := range s.Scan.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid scan pattern %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func profileNames(files []*File) string {
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, file := range files {
+		if file == nil {
+			continue
+		}
+		for name := range file.Profiles {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
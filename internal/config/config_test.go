@@ -0,0 +1,129 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const userConfig = `
+repo: octo/user
+host: github.example.com
+retry:
+  attempts: 3
+  delay: 500ms
+templates:
+  nit: "Nit: {{.Body}}"
+defaults:
+  review view:
+    tail: 2
+    states: [APPROVED, CHANGES_REQUESTED]
+profiles:
+  work:
+    repo: acme/api
+    defaults:
+      review view:
+        unresolved: true
+`
+
+const repoConfig = `
+output: pretty
+defaults:
+  review view:
+    tail: 5
+profiles:
+  work:
+    templates:
+      lgtm: Looks good
+`
+
+func TestResolveLayersFilesAndProfiles(t *testing.T) {
+	user, err := Decode(strings.NewReader(userConfig))
+	require.NoError(t, err)
+	repo, err := Decode(strings.NewReader(repoConfig))
+	require.NoError(t, err)
+
+	base, err := Resolve([]*File{user, repo}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "octo/user", base.Repo)
+	assert.Equal(t, "github.example.com", base.Host)
+	assert.Equal(t, OutputPretty, base.Output)
+	assert.Equal(t, Retry{Attempts: 3, Delay: Duration(500 * time.Millisecond)}, base.Retry)
+	assert.Equal(t, map[string]Value{"tail": "5", "states": "APPROVED,CHANGES_REQUESTED"}, base.Defaults["review view"])
+	assert.Equal(t, map[string]string{"nit": "Nit: {{.Body}}"}, base.Templates)
+
+	work, err := Resolve([]*File{user, nil, repo}, "work")
+	require.NoError(t, err)
+	assert.Equal(t, "acme/api", work.Repo)
+	assert.Equal(t, Value("true"), work.Defaults["review view"]["unresolved"])
+	assert.Equal(t, Value("5"), work.Defaults["review view"]["tail"], "repo config beats the user profile")
+	assert.Equal(t, "Looks good", work.Templates["lgtm"])
+	assert.Equal(t, "Nit: {{.Body}}", work.Templates["nit"])
+
+	_, err = Resolve([]*File{user, repo}, "home")
+	require.EqualError(t, err, `unknown profile "home" (defined: work)`)
+}
+
+func TestEnvOverridesConfig(t *testing.T) {
+	env := map[string]string{EnvRepo: "env/repo", EnvOutput: "JSON"}
+	merged := Settings{Repo: "octo/user", Host: "github.example.com", Output: OutputPretty}.Merge(Env(func(key string) string { return env[key] }))
+	assert.Equal(t, Settings{Repo: "env/repo", Host: "github.example.com", Output: OutputJSON}, merged)
+}
+
+func TestDecodeRejectsInvalidConfig(t *testing.T) {
+	_, err := Decode(strings.NewReader("repos: octo/demo\n"))
+	require.ErrorContains(t, err, "field repos not found")
+
+	_, err = Decode(strings.NewReader("output: yaml\n"))
+	require.EqualError(t, err, `invalid output "yaml": must be json or pretty`)
+
+	_, err = Decode(strings.NewReader("profiles:\n  ci:\n    retry:\n      delay: soon\n"))
+	require.ErrorContains(t, err, `invalid duration "soon"`)
+
+	file, err := Decode(strings.NewReader(""))
+	require.NoError(t, err)
+	assert.Equal(t, &File{}, file)
+}
+
+func TestScanSettingsMerge(t *testing.T) {
+	user, err := Decode(strings.NewReader("scan:\n  redact: true\n  patterns:\n    host: corp\\.example\n"))
+	require.NoError(t, err)
+	repo, err := Decode(strings.NewReader("scan:\n  enabled: false\n  patterns:\n    email: '[a-z]+@example\\.com'\n"))
+	require.NoError(t, err)
+
+	merged, err := Resolve([]*File{user, repo}, "")
+	require.NoError(t, err)
+	require.NotNil(t, merged.Scan.Enabled)
+	assert.False(t, *merged.Scan.Enabled)
+	require.NotNil(t, merged.Scan.Redact)
+	assert.True(t, *merged.Scan.Redact)
+	assert.Equal(t, map[string]string{"host": `corp\.example`, "email": `[a-z]+@example\.com`}, merged.Scan.Patterns)
+
+	_, err = Decode(strings.NewReader("scan:\n  patterns:\n    broken: '('\n"))
+	require.ErrorContains(t, err, `invalid scan pattern "broken"`)
+}
+
+func TestRepoPathStopsAtRepositoryRoot(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	nested := filepath.Join(repo, "a", "b")
+	require.NoError(t, os.MkdirAll(nested, 0o755))
+	require.NoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, RepoFileName), nil, 0o600))
+
+	assert.Equal(t, "", RepoPath(nested), "config above the repository root is ignored")
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, RepoFileName), nil, 0o600))
+	assert.Equal(t, filepath.Join(repo, RepoFileName), RepoPath(nested))
+}
+
+func TestReadFileMissing(t *testing.T) {
+	file, err := ReadFile(filepath.Join(t.TempDir(), "config.yml"))
+	require.NoError(t, err)
+	assert.Nil(t, file)
+}
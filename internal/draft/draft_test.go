@@ -0,0 +1,108 @@
+package draft
+
+import (
+	"testing"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/commits"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPR = resolver.Identity{Host: "github.com", Owner: "Octo", Repo: "Demo", Number: 7}
+
+func TestStoreRoundTrip(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	set, err := store.Load(testPR)
+	require.NoError(t, err)
+	assert.Empty(t, set.Drafts)
+
+	first := set.Add(Draft{Kind: KindThread, Path: "main.go", Line: 3, Side: "RIGHT", Body: "nit"})
+	second := set.Add(Draft{Kind: KindReply, ThreadID: "PRRT_1", Body: "ack"})
+	assert.Equal(t, "1", first.ID)
+	assert.Equal(t, "2", second.ID)
+	require.NoError(t, store.Save(testPR, set))
+
+	loaded, err := store.Load(testPR)
+	require.NoError(t, err)
+	require.Len(t, loaded.Drafts, 2)
+	assert.Equal(t, []string{"9"}, loaded.Remove("1", "9"))
+	assert.Equal(t, "3", loaded.Add(Draft{Kind: KindReply, ThreadID: "PRRT_2", Body: "ok"}).ID)
+
+	loaded.Remove("2", "3")
+	require.NoError(t, store.Save(testPR, loaded))
+	empty, err := store.Load(testPR)
+	require.NoError(t, err)
+	assert.Empty(t, empty.Drafts)
+	assert.Equal(t, 1, empty.NextID)
+}
+
+// placementPatch replaces line 11 and inserts two lines before the function.
+const placementPatch = `@@ -10,4 +10,6 @@ package main
+ import "fmt"
+-var x = 1
++var x = 2
++
++// greet prints a greeting.
+ func greet() {
+ 	fmt.Println("hi")`
+
+func text(s string) *string { return &s }
+
+func TestPlace(t *testing.T) {
+	files := []commits.File{{Filename: "main.go", Patch: placementPatch}, {Filename: "logo.png"}}
+
+	cases := []struct {
+		name  string
+		draft Draft
+		want  Placement
+	}{
+		{
+			name:  "unchanged line",
+			draft: Draft{Path: "main.go", Line: 11, Side: "RIGHT", LineText: text("var x = 2")},
+			want:  Placement{Status: PlacementOK, Line: 11},
+		},
+		{
+			name:  "no text only checks the diff",
+			draft: Draft{Path: "main.go", Line: 14, StartLine: 13, Side: "RIGHT"},
+			want:  Placement{Status: PlacementOK, Line: 14, StartLine: 13},
+		},
+		{
+			name:  "moved",
+			draft: Draft{Path: "main.go", Line: 12, StartLine: 11, Side: "RIGHT", LineText: text("func greet() {")},
+			want:  Placement{Status: PlacementMoved, Line: 14, StartLine: 13, Reason: "drafted text moved from line 12 to 14"},
+		},
+		{
+			name:  "left side",
+			draft: Draft{Path: "main.go", Line: 11, Side: "LEFT", LineText: text("var x = 1")},
+			want:  Placement{Status: PlacementOK, Line: 11},
+		},
+		{
+			name:  "text gone",
+			draft: Draft{Path: "main.go", Line: 11, Side: "RIGHT", LineText: text("var x = 3")},
+			want:  Placement{Status: PlacementInvalid, Reason: "line 11 of main.go no longer holds the drafted text"},
+		},
+		{
+			name:  "outside diff",
+			draft: Draft{Path: "main.go", Line: 40, Side: "RIGHT"},
+			want:  Placement{Status: PlacementInvalid, Reason: "line 40 of main.go is outside the diff"},
+		},
+		{
+			name:  "file not in pull request",
+			draft: Draft{Path: "other.go", Line: 1, Side: "RIGHT"},
+			want:  Placement{Status: PlacementInvalid, Reason: "other.go is not changed by the pull request"},
+		},
+		{
+			name:  "no patch",
+			draft: Draft{Path: "logo.png", Line: 1, Side: "RIGHT"},
+			want:  Placement{Status: PlacementInvalid, Reason: "GitHub returned no diff for logo.png (binary or too large)"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Place(tc.draft, files))
+		})
+	}
+}
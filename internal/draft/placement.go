@@ -0,0 +1,102 @@
+package draft
+
+import (
+	"fmt"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/commits"
+)
+
+// Placement statuses.
+const (
+	PlacementOK      = "ok"
+	PlacementMoved   = "moved"
+	PlacementInvalid = "invalid"
+)
+
+// Placement is where a thread draft lands in the current pull request diff.
+type Placement struct {
+	Status    string
+	Line      int
+	StartLine int
+	Reason    string
+}
+
+// Place checks a thread draft against the pull request files. A draft is ok
+// when its lines are still part of the diff and, if the drafted line text is
+// known, that text is still on the drafted line. When the text now sits on
+// exactly one other commentable line, the draft is moved by the same offset.
+// Everything else is invalid.
+func Place(d Draft, files []commits.File) Placement {
+	var file *commits.File
+	for i := range files {
+		if files[i].Filename == d.Path {
+			file = &files[i]
+			break
+		}
+	}
+	if file == nil {
+		return invalid("%s is not changed by the pull request", d.Path)
+	}
+	if file.Patch == "" {
+		return invalid("GitHub returned no diff for %s (binary or too large)", d.Path)
+	}
+
+	left, right, err := commits.DiffLines(file.Patch)
+	if err != nil {
+		return invalid("parse diff for %s: %v", d.Path, err)
+	}
+	lines := right
+	if d.Side == "LEFT" {
+		lines = left
+	}
+	startLines := lines
+	if d.StartSide == "LEFT" {
+		startLines = left
+	} else if d.StartSide == "RIGHT" {
+		startLines = right
+	}
+
+	offset := 0
+	if d.LineText != nil {
+		if text, ok := lines[d.Line]; !ok || text != *d.LineText {
+			candidate, found := uniqueLine(lines, *d.LineText)
+			if !found {
+				return invalid("line %d of %s no longer holds the drafted text", d.Line, d.Path)
+			}
+			offset = candidate - d.Line
+		}
+	}
+
+	line := d.Line + offset
+	if _, ok := lines[line]; !ok {
+		return invalid("line %d of %s is outside the diff", line, d.Path)
+	}
+	placement := Placement{Status: PlacementOK, Line: line}
+	if d.StartLine > 0 {
+		placement.StartLine = d.StartLine + offset
+		if _, ok := startLines[placement.StartLine]; !ok {
+			return invalid("start line %d of %s is outside the diff", placement.StartLine, d.Path)
+		}
+	}
+	if offset != 0 {
+		placement.Status = PlacementMoved
+		placement.Reason = fmt.Sprintf("drafted text moved from line %d to %d", d.Line, line)
+	}
+	return placement
+}
+
+// uniqueLine returns the only line whose text equals target.
+func uniqueLine(lines map[int]string, target string) (int, bool) {
+	match, count := 0, 0
+	for number, text := range lines {
+		if text == target {
+			match = number
+			count++
+		}
+	}
+	return match, count == 1
+}
+
+func invalid(format string, args ...interface{}) Placement {
+	return Placement{Status: PlacementInvalid, Reason: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,191 @@
+package draft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+// Draft kinds.
+const (
+	KindThread = "thread"
+	KindReply  = "reply"
+)
+
+// Draft is a review comment composed locally and not yet sent to GitHub.
+// Thread drafts carry the ThreadInput fields; reply drafts carry ThreadID.
+type Draft struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Path      string `json:"path,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Side      string `json:"side,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	StartSide string `json:"start_side,omitempty"`
+	// LineText is the content of Line when the draft was written, used to
+	// detect drafts whose line moved. Nil when the file was not readable.
+	LineText  *string   `json:"line_text,omitempty"`
+	ThreadID  string    `json:"thread_id,omitempty"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Set is the list of drafts stored for one pull request.
+type Set struct {
+	NextID int     `json:"next_id"`
+	Drafts []Draft `json:"drafts"`
+}
+
+// Add assigns the next local id to d and appends it.
+func (s *Set) Add(d Draft) Draft {
+	if s.NextID < 1 {
+		s.NextID = 1
+	}
+	d.ID = strconv.Itoa(s.NextID)
+	s.NextID++
+	s.Drafts = append(s.Drafts, d)
+	return d
+}
+
+// Find returns the draft with the given id.
+func (s *Set) Find(id string) (*Draft, bool) {
+	for i := range s.Drafts {
+		if s.Drafts[i].ID == id {
+			return &s.Drafts[i], true
+		}
+	}
+	return nil, false
+}
+
+// Remove deletes the drafts with the given ids and reports the ids that were not found.
+func (s *Set) Remove(ids ...string) []string {
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	kept := s.Drafts[:0]
+	for _, d := range s.Drafts {
+		if wanted[d.ID] {
+			delete(wanted, d.ID)
+			continue
+		}
+		kept = append(kept, d)
+	}
+	s.Drafts = kept
+
+	missing := make([]string, 0, len(wanted))
+	for _, id := range ids {
+		if wanted[id] {
+			missing = append(missing, id)
+			delete(wanted, id)
+		}
+	}
+	return missing
+}
+
+// Store persists drafts on disk, one JSON file per pull request.
+type Store struct {
+	Dir string
+}
+
+// NewStore constructs a Store rooted at dir.
+func NewStore(dir string) *Store {
+	return &Store{Dir: dir}
+}
+
+// DefaultStore returns a Store in the user config directory.
+func DefaultStore() (*Store, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("locate config directory: %w", err)
+	}
+	return NewStore(filepath.Join(base, "gh-pr-review", "drafts")), nil
+}
+
+// Load returns the drafts stored for the pull request. A pull request without
+// drafts yields an empty set.
+func (s *Store) Load(pr resolver.Identity) (*Set, error) {
+	path, err := s.path(pr)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Set{NextID: 1, Drafts: []Draft{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read drafts: %w", err)
+	}
+	var set Set
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("decode drafts %s: %w", path, err)
+	}
+	if set.Drafts == nil {
+		set.Drafts = []Draft{}
+	}
+	return &set, nil
+}
+
+// Save replaces the stored drafts. Saving an empty set removes the file.
+func (s *Store) Save(pr resolver.Identity, set *Set) error {
+	path, err := s.path(pr)
+	if err != nil {
+		return err
+	}
+	if len(set.Drafts) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove drafts: %w", err)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create draft directory: %w", err)
+	}
+	data, err := json.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode drafts: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write drafts: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write drafts: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write drafts: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("write drafts: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) path(pr resolver.Identity) (string, error) {
+	if strings.TrimSpace(s.Dir) == "" {
+		return "", errors.New("draft directory is not configured")
+	}
+	if pr.Host == "" || pr.Owner == "" || pr.Repo == "" || pr.Number <= 0 {
+		return "", errors.New("drafts require a fully resolved pull request")
+	}
+	return filepath.Join(
+		s.Dir,
+		strings.ToLower(pr.Host),
+		strings.ToLower(pr.Owner),
+		strings.ToLower(pr.Repo),
+		strconv.Itoa(pr.Number)+".json",
+	), nil
+}
@@ -1,19 +1,35 @@
 package ghcli
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // Client executes GitHub API requests through the `gh` CLI to reuse
 // the authenticated context and host configuration provided by the user.
 type Client struct {
-	Host string
+	Host  string
+	Retry RetryPolicy
+}
+
+// RetryPolicy controls how read-only requests (REST GETs and GraphQL queries)
+// are retried after transient failures: rate limiting, 5xx responses, and
+// network timeouts. Mutations are never retried. Attempts counts the first
+// request; the delay doubles after every retry.
+type RetryPolicy struct {
+	Attempts int
+	Delay    time.Duration
 }
 
 // API defines the subset of GitHub API interactions required by the command logic.
@@ -22,6 +38,17 @@ type API interface {
 	GraphQL(query string, variables map[string]interface{}, result interface{}) error
 }
 
+// MaxBodyLength is the largest comment or review body GitHub accepts, in characters.
+const MaxBodyLength = 65536
+
+// CheckBodyLength rejects bodies GitHub would refuse for exceeding MaxBodyLength.
+func CheckBodyLength(body string) error {
+	if n := utf8.RuneCountInString(body); n > MaxBodyLength {
+		return fmt.Errorf("body is %d characters; GitHub allows at most %d", n, MaxBodyLength)
+	}
+	return nil
+}
+
 // GraphQLErrorEntry captures a single GraphQL error payload.
 type GraphQLErrorEntry struct {
 	Message string        `json:"message"`
@@ -133,9 +160,9 @@ func (c *Client) REST(method, path string, params map[string]string, body interf
 		args = append(args, "--input", "-")
 	}
 
-	stdout, stderr, err := runGh(args, stdinData)
+	stdout, err := c.run(args, stdinData, strings.EqualFold(method, "GET"))
 	if err != nil {
-		return wrapError(err, stdout, stderr)
+		return err
 	}
 
 	if result == nil {
@@ -169,9 +196,9 @@ func (c *Client) GraphQL(query string, variables map[string]interface{}, result
 	}
 	args = append(args, "--input", "-")
 
-	stdout, stderr, err := runGh(args, data)
+	stdout, err := c.run(args, data, !isMutation(query))
 	if err != nil {
-		return wrapError(err, stdout, stderr)
+		return err
 	}
 
 	if result == nil {
@@ -210,6 +237,135 @@ func (c *Client) GraphQL(query string, variables map[string]interface{}, result
 	return nil
 }
 
+// ConditionalAPI is implemented by clients that can issue ETag-based
+// conditional GET requests. GitHub does not count 304 responses against the
+// rate limit, which makes them suitable for polling.
+type ConditionalAPI interface {
+	// ConditionalGET fetches path, sending etag as If-None-Match when non-empty.
+	// It returns the response ETag and whether the resource was modified; result
+	// is only populated for modified responses and may be nil.
+	ConditionalGET(path, etag string, result interface{}) (string, bool, error)
+}
+
+// ConditionalGET performs a conditional REST GET through `gh api --include`.
+func (c *Client) ConditionalGET(path, etag string, result interface{}) (string, bool, error) {
+	args := []string{"api"}
+	if host := strings.TrimSpace(c.Host); host != "" {
+		args = append(args, "--hostname", host)
+	}
+	args = append(args, "--header", "X-GitHub-Api-Version: 2022-11-28")
+	if etag != "" {
+		args = append(args, "--header", "If-None-Match: "+etag)
+	}
+	args = append(args, "--include", path, "-X", "GET")
+
+	var status int
+	var header http.Header
+	var body []byte
+	err := c.retry(true, func() error {
+		stdout, stderr, runErr := runGh(args, nil)
+		status, header, body = splitIncludedResponse(stdout)
+		if status == http.StatusNotModified || runErr == nil {
+			return nil
+		}
+		return wrapError(runErr, body, stderr)
+	})
+	if status == http.StatusNotModified {
+		return etag, false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	if result != nil && len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, result); err != nil {
+			return "", false, fmt.Errorf("unmarshal response: %w", err)
+		}
+	}
+	return header.Get("ETag"), true, nil
+}
+
+// splitIncludedResponse separates the status line and headers printed by
+// `gh api --include` from the response body.
+func splitIncludedResponse(out []byte) (int, http.Header, []byte) {
+	reader := bufio.NewReader(bytes.NewReader(out))
+	statusLine, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(statusLine, "HTTP/") {
+		return 0, http.Header{}, out
+	}
+	status := 0
+	if fields := strings.Fields(statusLine); len(fields) >= 2 {
+		status, _ = strconv.Atoi(fields[1])
+	}
+
+	header := http.Header{}
+	for {
+		line, err := reader.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			header.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	body, _ := io.ReadAll(reader)
+	return status, header, body
+}
+
+// run executes `gh`, retrying transient failures when the request is read-only.
+func (c *Client) run(args []string, stdin []byte, readOnly bool) ([]byte, error) {
+	var stdout []byte
+	err := c.retry(readOnly, func() error {
+		out, stderr, err := runGh(args, stdin)
+		stdout = out
+		if err != nil {
+			return wrapError(err, out, stderr)
+		}
+		return nil
+	})
+	return stdout, err
+}
+
+// retry calls attempt until it succeeds, fails permanently, or the policy's
+// attempts are used up.
+func (c *Client) retry(readOnly bool, attempt func() error) error {
+	delay := c.Retry.Delay
+	for n := 1; ; n++ {
+		err := attempt()
+		if err == nil || !readOnly || n >= c.Retry.Attempts || !Transient(err) {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// Transient reports whether a failed request may succeed when repeated.
+func Transient(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch {
+	case apiErr.StatusCode == http.StatusTooManyRequests, apiErr.StatusCode >= 500:
+		return true
+	case apiErr.StatusCode == http.StatusForbidden:
+		return apiErr.ContainsLower("rate limit")
+	case apiErr.StatusCode == 0:
+		return apiErr.ContainsLower("timeout") || apiErr.ContainsLower("connection reset") || apiErr.ContainsLower("connection refused")
+	}
+	return false
+}
+
+func isMutation(query string) bool {
+	return strings.HasPrefix(strings.TrimSpace(query), "mutation")
+}
+
 // runGh executes the `gh` CLI command with provided arguments and optional stdin data.
 func runGh(args []string, stdin []byte) ([]byte, string, error) {
 	cmd := exec.Command("gh", args...)
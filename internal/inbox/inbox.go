@@ -0,0 +1,357 @@
+// Package inbox finds the open pull requests that need the viewer's attention
+// across repositories.
+package inbox
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/report"
+)
+
+const searchQuery = `query InboxSearch($query: String!, $first: Int!) {
+  viewer { login }
+  search(query: $query, type: ISSUE, first: $first) {
+    nodes {
+      ... on PullRequest {
+        number
+        title
+        url
+        isDraft
+        updatedAt
+        headRefOid
+        repository { nameWithOwner }
+        author { login }
+        commits(last: 1) {
+          nodes { commit { committedDate } }
+        }
+        reviews(last: 50) {
+          nodes {
+            id
+            state
+            submittedAt
+            author { login }
+            commit { oid }
+          }
+        }
+        reviewThreads(first: 100) {
+          nodes {
+            isResolved
+            isOutdated
+            comments(last: 20) {
+              nodes {
+                createdAt
+                author { login }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// Reasons a pull request is in the inbox.
+const (
+	ReasonReviewRequested = "review_requested"
+	ReasonReviewed        = "reviewed"
+	ReasonAuthored        = "authored"
+)
+
+// DefaultLimit is the number of pull requests fetched per search.
+const DefaultLimit = 30
+
+// MaxLimit keeps a search under GitHub's 500,000 node limit: each pull
+// request costs up to 1 + 1 + 50 + 100 + 100*20 = 2,152 nodes, so 50 of them
+// request about 108,000.
+const MaxLimit = 50
+
+// Options scopes the searches. Empty Orgs and Repos search everything the
+// viewer can see.
+type Options struct {
+	Orgs  []string
+	Repos []string
+	// Limit caps the pull requests fetched per search (1-MaxLimit).
+	Limit int
+}
+
+// Item summarizes one pull request that needs attention.
+type Item struct {
+	Repo        string   `json:"repo"`
+	Number      int      `json:"number"`
+	Title       string   `json:"title"`
+	URL         string   `json:"url"`
+	AuthorLogin string   `json:"author_login"`
+	IsDraft     bool     `json:"is_draft"`
+	UpdatedAt   string   `json:"updated_at"`
+	Reasons     []string `json:"reasons"`
+	// UnresolvedThreads counts unresolved review threads, outdated or not.
+	UnresolvedThreads int `json:"unresolved_threads"`
+	// NewReplies counts comments by others posted after the viewer's last
+	// comment in the same thread.
+	NewReplies int                     `json:"new_replies"`
+	Reviewers  []report.ReviewerStatus `json:"reviewers"`
+
+	updatedAt time.Time
+}
+
+// Service runs the inbox searches.
+type Service struct {
+	API ghcli.API
+}
+
+// NewService constructs an inbox Service.
+func NewService(api ghcli.API) *Service {
+	return &Service{API: api}
+}
+
+// Fetch returns the pull requests that request the viewer's review, that the
+// viewer reviewed and that have new activity since, or that the viewer
+// authored and have unresolved threads. Items are sorted by staleness, least
+// recently updated first.
+func (s *Service) Fetch(opts Options) ([]Item, error) {
+	limit := opts.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit < 1 || limit > MaxLimit {
+		return nil, fmt.Errorf("invalid limit %d: must be between 1 and %d", limit, MaxLimit)
+	}
+	scope := scopeQualifiers(opts)
+
+	items := make(map[string]*Item)
+	order := make([]string, 0)
+	for _, search := range []struct {
+		reason    string
+		qualifier string
+	}{
+		{ReasonReviewRequested, "review-requested:@me"},
+		{ReasonReviewed, "reviewed-by:@me"},
+		{ReasonAuthored, "author:@me"},
+	} {
+		query := strings.Join(append([]string{"is:pr", "is:open", "archived:false", search.qualifier}, scope...), " ")
+		results, err := s.search(query, limit)
+		if err != nil {
+			return nil, fmt.Errorf("search %q: %w", search.qualifier, err)
+		}
+		for _, result := range results {
+			if !result.matches(search.reason) {
+				continue
+			}
+			key := fmt.Sprintf("%s#%d", result.item.Repo, result.item.Number)
+			existing, ok := items[key]
+			if !ok {
+				item := result.item
+				items[key] = &item
+				order = append(order, key)
+				existing = &item
+			}
+			existing.Reasons = append(existing.Reasons, search.reason)
+		}
+	}
+
+	inbox := make([]Item, 0, len(order))
+	for _, key := range order {
+		inbox = append(inbox, *items[key])
+	}
+	sort.SliceStable(inbox, func(i, j int) bool {
+		return inbox[i].updatedAt.Before(inbox[j].updatedAt)
+	})
+	return inbox, nil
+}
+
+// scopeQualifiers turns --org and --repo into search qualifiers. GitHub ORs
+// repeated qualifiers of the same kind.
+func scopeQualifiers(opts Options) []string {
+	qualifiers := make([]string, 0, len(opts.Orgs)+len(opts.Repos))
+	for _, org := range opts.Orgs {
+		if org = strings.TrimSpace(org); org != "" {
+			qualifiers = append(qualifiers, "org:"+org)
+		}
+	}
+	for _, repo := range opts.Repos {
+		if repo = strings.TrimSpace(repo); repo != "" {
+			qualifiers = append(qualifiers, "repo:"+repo)
+		}
+	}
+	return qualifiers
+}
+
+// candidate is one search result with the facts used to filter it.
+type candidate struct {
+	item Item
+	// newActivity reports replies or commits after the viewer's last review
+	// or comment.
+	newActivity bool
+}
+
+func (c candidate) matches(reason string) bool {
+	switch reason {
+	case ReasonReviewed:
+		return c.newActivity
+	case ReasonAuthored:
+		return c.item.UnresolvedThreads > 0
+	default:
+		return true
+	}
+}
+
+type login struct {
+	Login string `json:"login"`
+}
+
+type threadComment struct {
+	at     time.Time
+	author string
+}
+
+func (s *Service) search(query string, limit int) ([]candidate, error) {
+	var response struct {
+		Viewer login `json:"viewer"`
+		Search struct {
+			Nodes []struct {
+				Number     int    `json:"number"`
+				Title      string `json:"title"`
+				URL        string `json:"url"`
+				IsDraft    bool   `json:"isDraft"`
+				UpdatedAt  string `json:"updatedAt"`
+				HeadRefOID string `json:"headRefOid"`
+				Repository *struct {
+					NameWithOwner string `json:"nameWithOwner"`
+				} `json:"repository"`
+				Author  *login `json:"author"`
+				Commits struct {
+					Nodes []struct {
+						Commit struct {
+							CommittedDate string `json:"committedDate"`
+						} `json:"commit"`
+					} `json:"nodes"`
+				} `json:"commits"`
+				Reviews struct {
+					Nodes []struct {
+						ID          string  `json:"id"`
+						State       string  `json:"state"`
+						SubmittedAt *string `json:"submittedAt"`
+						Author      *login  `json:"author"`
+						Commit      *struct {
+							OID string `json:"oid"`
+						} `json:"commit"`
+					} `json:"nodes"`
+				} `json:"reviews"`
+				ReviewThreads struct {
+					Nodes []struct {
+						IsResolved bool `json:"isResolved"`
+						IsOutdated bool `json:"isOutdated"`
+						Comments   struct {
+							Nodes []struct {
+								CreatedAt string `json:"createdAt"`
+								Author    *login `json:"author"`
+							} `json:"nodes"`
+						} `json:"comments"`
+					} `json:"nodes"`
+				} `json:"reviewThreads"`
+			} `json:"nodes"`
+		} `json:"search"`
+	}
+	variables := map[string]interface{}{"query": query, "first": limit}
+	if err := s.API.GraphQL(searchQuery, variables, &response); err != nil {
+		return nil, err
+	}
+	viewer := strings.TrimSpace(response.Viewer.Login)
+	if viewer == "" {
+		return nil, errors.New("viewer login unavailable")
+	}
+
+	candidates := make([]candidate, 0, len(response.Search.Nodes))
+	for _, node := range response.Search.Nodes {
+		// Non-pull-request results decode as empty nodes.
+		if node.Repository == nil || node.Number == 0 {
+			continue
+		}
+		updatedAt, err := time.Parse(time.RFC3339, node.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse updatedAt of %s#%d: %w", node.Repository.NameWithOwner, node.Number, err)
+		}
+		item := Item{
+			Repo:      node.Repository.NameWithOwner,
+			Number:    node.Number,
+			Title:     node.Title,
+			URL:       node.URL,
+			IsDraft:   node.IsDraft,
+			UpdatedAt: node.UpdatedAt,
+			Reasons:   make([]string, 0, 1),
+			updatedAt: updatedAt,
+		}
+		if node.Author != nil {
+			item.AuthorLogin = node.Author.Login
+		}
+
+		var viewerReviewedAt time.Time
+		reviews := make([]report.Review, 0, len(node.Reviews.Nodes))
+		for _, raw := range node.Reviews.Nodes {
+			if raw.Author == nil || raw.SubmittedAt == nil {
+				continue
+			}
+			submittedAt, err := time.Parse(time.RFC3339, *raw.SubmittedAt)
+			if err != nil {
+				return nil, fmt.Errorf("parse review submittedAt: %w", err)
+			}
+			review := report.Review{ID: raw.ID, State: report.State(raw.State), AuthorLogin: raw.Author.Login, SubmittedAt: &submittedAt}
+			if raw.Commit != nil {
+				review.CommitOID = raw.Commit.OID
+			}
+			reviews = append(reviews, review)
+			if strings.EqualFold(raw.Author.Login, viewer) && submittedAt.After(viewerReviewedAt) {
+				viewerReviewedAt = submittedAt
+			}
+		}
+
+		threads := make([]report.Thread, 0, len(node.ReviewThreads.Nodes))
+		for _, raw := range node.ReviewThreads.Nodes {
+			threads = append(threads, report.Thread{IsResolved: raw.IsResolved, IsOutdated: raw.IsOutdated})
+
+			var viewerCommentedAt time.Time
+			comments := make([]threadComment, 0, len(raw.Comments.Nodes))
+			for _, comment := range raw.Comments.Nodes {
+				at, err := time.Parse(time.RFC3339, comment.CreatedAt)
+				if err != nil {
+					return nil, fmt.Errorf("parse comment createdAt: %w", err)
+				}
+				author := "ghost"
+				if comment.Author != nil {
+					author = comment.Author.Login
+				}
+				comments = append(comments, threadComment{at: at, author: author})
+				if strings.EqualFold(author, viewer) && at.After(viewerCommentedAt) {
+					viewerCommentedAt = at
+				}
+			}
+			if viewerCommentedAt.IsZero() {
+				continue
+			}
+			for _, comment := range comments {
+				if comment.at.After(viewerCommentedAt) && !strings.EqualFold(comment.author, viewer) {
+					item.NewReplies++
+				}
+			}
+		}
+
+		status := report.BuildStatus(reviews, threads, report.StatusInput{HeadSHA: node.HeadRefOID, AuthorLogin: item.AuthorLogin})
+		item.Reviewers = status.Reviewers
+		item.UnresolvedThreads = status.Threads.Unresolved
+
+		newCommits := false
+		if len(node.Commits.Nodes) > 0 && !viewerReviewedAt.IsZero() {
+			committedAt, err := time.Parse(time.RFC3339, node.Commits.Nodes[0].Commit.CommittedDate)
+			if err == nil && committedAt.After(viewerReviewedAt) {
+				newCommits = true
+			}
+		}
+		candidates = append(candidates, candidate{item: item, newActivity: item.NewReplies > 0 || newCommits})
+	}
+	return candidates, nil
+}
@@ -0,0 +1,129 @@
+package inbox
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/report"
+)
+
+type fakeAPI struct {
+	graphqlFunc func(query string, variables map[string]interface{}, result interface{}) error
+}
+
+func (f *fakeAPI) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+	return errors.New("unexpected REST call")
+}
+
+func (f *fakeAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+	if f.graphqlFunc == nil {
+		return errors.New("unexpected GraphQL call")
+	}
+	return f.graphqlFunc(query, variables, result)
+}
+
+func assign(result interface{}, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+type obj = map[string]interface{}
+
+func pull(repo string, number int, updatedAt string, extra obj) obj {
+	node := obj{
+		"number": number, "title": "PR " + repo, "url": "https://github.com/" + repo + "/pull/1",
+		"updatedAt": updatedAt, "headRefOid": "head",
+		"repository":    obj{"nameWithOwner": repo},
+		"author":        obj{"login": "octocat"},
+		"commits":       obj{"nodes": []obj{{"commit": obj{"committedDate": "2025-12-01T00:00:00Z"}}}},
+		"reviews":       obj{"nodes": []obj{}},
+		"reviewThreads": obj{"nodes": []obj{}},
+	}
+	for key, value := range extra {
+		node[key] = value
+	}
+	return node
+}
+
+func TestFetchMergesSearchesAndSortsByStaleness(t *testing.T) {
+	var queries []string
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		search := variables["query"].(string)
+		queries = append(queries, search)
+		assert.Equal(t, 10, variables["first"])
+
+		var nodes []obj
+		switch {
+		case strings.Contains(search, "review-requested:@me"):
+			nodes = []obj{pull("octo/api", 1, "2025-12-05T00:00:00Z", nil), {}}
+		case strings.Contains(search, "reviewed-by:@me"):
+			nodes = []obj{
+				// Reviewed, then someone replied to the viewer's comment.
+				pull("octo/web", 2, "2025-12-02T00:00:00Z", obj{
+					"reviews": obj{"nodes": []obj{
+						{"id": "PRR_1", "state": "APPROVED", "submittedAt": "2025-12-01T10:00:00Z", "author": obj{"login": "me"}, "commit": obj{"oid": "head"}},
+					}},
+					"reviewThreads": obj{"nodes": []obj{{
+						"isResolved": false, "isOutdated": false,
+						"comments": obj{"nodes": []obj{
+							{"createdAt": "2025-12-01T10:00:00Z", "author": obj{"login": "me"}},
+							{"createdAt": "2025-12-01T11:00:00Z", "author": obj{"login": "octocat"}},
+							{"createdAt": "2025-12-01T12:00:00Z", "author": obj{"login": "carol"}},
+						}},
+					}}},
+				}),
+				// Reviewed after the last commit with no replies: nothing new.
+				pull("octo/docs", 3, "2025-12-01T00:00:00Z", obj{
+					"reviews": obj{"nodes": []obj{{"id": "PRR_2", "state": "COMMENTED", "submittedAt": "2025-12-02T00:00:00Z", "author": obj{"login": "me"}}}},
+				}),
+				// Also requested: merged into one item.
+				pull("octo/api", 1, "2025-12-05T00:00:00Z", obj{
+					"reviews": obj{"nodes": []obj{{"id": "PRR_3", "state": "COMMENTED", "submittedAt": "2025-11-30T00:00:00Z", "author": obj{"login": "me"}}}},
+				}),
+			}
+		case strings.Contains(search, "author:@me"):
+			nodes = []obj{
+				pull("octo/cli", 4, "2025-12-03T00:00:00Z", obj{
+					"author":        obj{"login": "me"},
+					"reviewThreads": obj{"nodes": []obj{{"isResolved": false, "isOutdated": true, "comments": obj{"nodes": []obj{}}}}},
+				}),
+				pull("octo/cli", 5, "2025-12-04T00:00:00Z", obj{"author": obj{"login": "me"}}),
+			}
+		}
+		return assign(result, obj{"viewer": obj{"login": "me"}, "search": obj{"nodes": nodes}})
+	}
+
+	items, err := NewService(api).Fetch(Options{Orgs: []string{"octo"}, Repos: []string{" "}, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"is:pr is:open archived:false review-requested:@me org:octo",
+		"is:pr is:open archived:false reviewed-by:@me org:octo",
+		"is:pr is:open archived:false author:@me org:octo",
+	}, queries)
+
+	keys := make([]string, len(items))
+	for i, item := range items {
+		keys[i] = item.Repo + ":" + strings.Join(item.Reasons, ",")
+	}
+	assert.Equal(t, []string{"octo/web:reviewed", "octo/cli:authored", "octo/api:review_requested,reviewed"}, keys)
+
+	web := items[0]
+	assert.Equal(t, 2, web.NewReplies)
+	assert.Equal(t, 1, web.UnresolvedThreads)
+	assert.Equal(t, []report.ReviewerStatus{{Login: "me", State: report.StateApproved, ReviewID: "PRR_1", SubmittedAt: "2025-12-01T10:00:00Z", CommitSHA: "head", OnHead: true}}, web.Reviewers)
+	assert.Equal(t, 1, items[1].UnresolvedThreads)
+}
+
+func TestFetchRejectsInvalidLimit(t *testing.T) {
+	_, err := NewService(&fakeAPI{}).Fetch(Options{Limit: 51})
+	require.EqualError(t, err, "invalid limit 51: must be between 1 and 50")
+}
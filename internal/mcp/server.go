@@ -0,0 +1,271 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// JSON-RPC error codes used by the server.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// supportedProtocolVersions lists the MCP revisions the server accepts, newest first.
+var supportedProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// Tool is a callable operation advertised through tools/list.
+type Tool struct {
+	Name        string
+	Description string
+	// InputSchema is the JSON Schema of the arguments object.
+	InputSchema map[string]interface{}
+	// Handler receives the raw arguments object and returns a JSON-encodable
+	// result. Returned errors are reported as tool errors (isError: true).
+	Handler func(ctx context.Context, arguments json.RawMessage) (interface{}, error)
+}
+
+// ToolError lets handlers attach structured details to a tool error.
+type ToolError struct {
+	Message string
+	Details map[string]interface{}
+}
+
+func (e *ToolError) Error() string {
+	return e.Message
+}
+
+// Server speaks MCP JSON-RPC over newline-delimited streams (the stdio transport).
+type Server struct {
+	Name    string
+	Version string
+
+	tools []Tool
+	index map[string]int
+	mu    sync.Mutex
+}
+
+// NewServer constructs a Server advertising the given implementation name and version.
+func NewServer(name, version string) *Server {
+	return &Server{Name: name, Version: version, index: make(map[string]int)}
+}
+
+// AddTool registers a tool. Registering the same name twice replaces the earlier tool.
+func (s *Server) AddTool(tool Tool) {
+	if i, ok := s.index[tool.Name]; ok {
+		s.tools[i] = tool
+		return
+	}
+	s.index[tool.Name] = len(s.tools)
+	s.tools = append(s.tools, tool)
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type callResult struct {
+	Content           []content   `json:"content"`
+	StructuredContent interface{} `json:"structuredContent,omitempty"`
+	IsError           bool        `json:"isError,omitempty"`
+}
+
+// Serve reads requests from r and writes responses to w until r is exhausted
+// or ctx is cancelled. Requests are handled one at a time in arrival order.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	reader := bufio.NewReader(r)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line, readErr := reader.ReadBytes('\n')
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			if resp := s.handle(ctx, trimmed); resp != nil {
+				s.mu.Lock()
+				err := enc.Encode(resp)
+				s.mu.Unlock()
+				if err != nil {
+					return fmt.Errorf("write response: %w", err)
+				}
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+		if readErr != nil {
+			return fmt.Errorf("read request: %w", readErr)
+		}
+	}
+}
+
+// handle processes one message, returning nil for notifications.
+func (s *Server) handle(ctx context.Context, message []byte) *response {
+	if message[0] == '[' {
+		return errorResponse(json.RawMessage("null"), codeInvalidRequest, "batch requests are not supported")
+	}
+
+	var req request
+	if err := json.Unmarshal(message, &req); err != nil {
+		return errorResponse(json.RawMessage("null"), codeParseError, "parse error: "+err.Error())
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		if len(req.ID) == 0 {
+			return nil
+		}
+		return errorResponse(req.ID, codeInvalidRequest, "invalid request")
+	}
+	// Notifications (no id) never receive a response.
+	if len(req.ID) == 0 {
+		return nil
+	}
+
+	switch req.Method {
+	case "initialize":
+		return resultResponse(req.ID, s.initialize(req.Params))
+	case "ping":
+		return resultResponse(req.ID, struct{}{})
+	case "tools/list":
+		return resultResponse(req.ID, s.listTools())
+	case "tools/call":
+		return s.callTool(ctx, req)
+	default:
+		return errorResponse(req.ID, codeMethodNotFound, "method not found: "+req.Method)
+	}
+}
+
+func (s *Server) initialize(params json.RawMessage) map[string]interface{} {
+	var init struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	_ = json.Unmarshal(params, &init)
+
+	version := supportedProtocolVersions[0]
+	for _, candidate := range supportedProtocolVersions {
+		if candidate == init.ProtocolVersion {
+			version = candidate
+			break
+		}
+	}
+
+	return map[string]interface{}{
+		"protocolVersion": version,
+		"capabilities": map[string]interface{}{
+			"tools": map[string]interface{}{},
+		},
+		"serverInfo": map[string]interface{}{
+			"name":    s.Name,
+			"version": s.Version,
+		},
+	}
+}
+
+func (s *Server) listTools() map[string]interface{} {
+	tools := make([]map[string]interface{}, len(s.tools))
+	for i, tool := range s.tools {
+		tools[i] = map[string]interface{}{
+			"name":        tool.Name,
+			"description": tool.Description,
+			"inputSchema": tool.InputSchema,
+		}
+	}
+	return map[string]interface{}{"tools": tools}
+}
+
+func (s *Server) callTool(ctx context.Context, req request) *response {
+	var params struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return errorResponse(req.ID, codeInvalidParams, "invalid tools/call params: "+err.Error())
+	}
+	i, ok := s.index[params.Name]
+	if !ok {
+		return errorResponse(req.ID, codeInvalidParams, "unknown tool: "+params.Name)
+	}
+	arguments := params.Arguments
+	if len(arguments) == 0 || string(arguments) == "null" {
+		arguments = json.RawMessage("{}")
+	}
+
+	result, err := s.tools[i].Handler(ctx, arguments)
+	if err != nil {
+		return resultResponse(req.ID, toolErrorResult(err))
+	}
+
+	text, err := json.Marshal(result)
+	if err != nil {
+		return resultResponse(req.ID, toolErrorResult(fmt.Errorf("encode result: %w", err)))
+	}
+	call := callResult{Content: []content{{Type: "text", Text: string(text)}}}
+	// structuredContent must be an object; arrays are only sent as text.
+	if bytes.HasPrefix(bytes.TrimSpace(text), []byte("{")) {
+		call.StructuredContent = json.RawMessage(text)
+	}
+	return resultResponse(req.ID, call)
+}
+
+func toolErrorResult(err error) callResult {
+	structured := map[string]interface{}{"error": err.Error()}
+	var toolErr *ToolError
+	if errors.As(err, &toolErr) {
+		for key, value := range toolErr.Details {
+			structured[key] = value
+		}
+	}
+	return callResult{
+		Content:           []content{{Type: "text", Text: err.Error()}},
+		StructuredContent: structured,
+		IsError:           true,
+	}
+}
+
+func resultResponse(id json.RawMessage, result interface{}) *response {
+	return &response{JSONRPC: "2.0", ID: id, Result: result}
+}
+
+func errorResponse(id json.RawMessage, code int, message string) *response {
+	return &response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}}
+}
+
+// DecodeArguments strictly decodes a tool arguments object into dst, rejecting
+// unknown properties the same way the input schemas do.
+func DecodeArguments(arguments json.RawMessage, dst interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(arguments))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return &ToolError{Message: "invalid arguments: " + err.Error()}
+	}
+	return nil
+}
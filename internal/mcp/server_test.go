@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveLines(t *testing.T, server *Server, lines ...string) []map[string]interface{} {
+	t.Helper()
+	var out bytes.Buffer
+	require.NoError(t, server.Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")), &out))
+
+	var responses []map[string]interface{}
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var resp map[string]interface{}
+		require.NoError(t, dec.Decode(&resp))
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func echoServer() *Server {
+	server := NewServer("test", "1.0.0")
+	server.AddTool(Tool{
+		Name:        "echo",
+		Description: "Echo the message",
+		InputSchema: map[string]interface{}{"type": "object"},
+		Handler: func(_ context.Context, arguments json.RawMessage) (interface{}, error) {
+			var args struct {
+				Message string `json:"message"`
+			}
+			if err := DecodeArguments(arguments, &args); err != nil {
+				return nil, err
+			}
+			if args.Message == "" {
+				return nil, &ToolError{Message: "message is required", Details: map[string]interface{}{"field": "message"}}
+			}
+			return map[string]string{"message": args.Message}, nil
+		},
+	})
+	server.AddTool(Tool{
+		Name: "list",
+		Handler: func(context.Context, json.RawMessage) (interface{}, error) {
+			return []string{"a"}, nil
+		},
+	})
+	server.AddTool(Tool{
+		Name: "fail",
+		Handler: func(context.Context, json.RawMessage) (interface{}, error) {
+			return nil, errors.New("boom")
+		},
+	})
+	return server
+}
+
+func TestInitializeNegotiatesProtocolVersion(t *testing.T) {
+	responses := serveLines(t, echoServer(),
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`,
+	)
+	require.Len(t, responses, 2)
+
+	first := responses[0]["result"].(map[string]interface{})
+	assert.Equal(t, "2024-11-05", first["protocolVersion"])
+	assert.Equal(t, map[string]interface{}{"name": "test", "version": "1.0.0"}, first["serverInfo"])
+	assert.Contains(t, first["capabilities"], "tools")
+
+	second := responses[1]["result"].(map[string]interface{})
+	assert.Equal(t, supportedProtocolVersions[0], second["protocolVersion"])
+}
+
+func TestToolsListAndCall(t *testing.T) {
+	responses := serveLines(t, echoServer(),
+		`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","arguments":{"message":"hi"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"list"}}`,
+	)
+	require.Len(t, responses, 3)
+
+	tools := responses[0]["result"].(map[string]interface{})["tools"].([]interface{})
+	require.Len(t, tools, 3)
+	assert.Equal(t, "echo", tools[0].(map[string]interface{})["name"])
+
+	call := responses[1]["result"].(map[string]interface{})
+	assert.Nil(t, call["isError"])
+	assert.Equal(t, map[string]interface{}{"message": "hi"}, call["structuredContent"])
+	assert.Equal(t, `{"message":"hi"}`, call["content"].([]interface{})[0].(map[string]interface{})["text"])
+
+	list := responses[2]["result"].(map[string]interface{})
+	assert.NotContains(t, list, "structuredContent")
+	assert.Equal(t, `["a"]`, list["content"].([]interface{})[0].(map[string]interface{})["text"])
+}
+
+func TestToolErrorsAreResults(t *testing.T) {
+	responses := serveLines(t, echoServer(),
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","arguments":{"unknown":true}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"fail"}}`,
+	)
+	require.Len(t, responses, 3)
+
+	missing := responses[0]["result"].(map[string]interface{})
+	assert.Equal(t, true, missing["isError"])
+	assert.Equal(t, map[string]interface{}{"error": "message is required", "field": "message"}, missing["structuredContent"])
+
+	unknown := responses[1]["result"].(map[string]interface{})
+	assert.Equal(t, true, unknown["isError"])
+	assert.Contains(t, unknown["structuredContent"].(map[string]interface{})["error"], "unknown field")
+
+	failed := responses[2]["result"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"error": "boom"}, failed["structuredContent"])
+}
+
+func TestProtocolErrors(t *testing.T) {
+	responses := serveLines(t, echoServer(),
+		`not json`,
+		`{"jsonrpc":"2.0","id":"a","method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":"b","method":"tools/call","params":{"name":"missing"}}`,
+		`[{"jsonrpc":"2.0","id":1,"method":"ping"}]`,
+		`{"jsonrpc":"2.0","id":"c","method":"ping"}`,
+	)
+	require.Len(t, responses, 5)
+
+	codes := make([]float64, 0, 4)
+	for _, resp := range responses[:4] {
+		codes = append(codes, resp["error"].(map[string]interface{})["code"].(float64))
+	}
+	assert.Equal(t, []float64{codeParseError, codeMethodNotFound, codeInvalidParams, codeInvalidRequest}, codes)
+	assert.Equal(t, "b", responses[2]["id"])
+	assert.Equal(t, map[string]interface{}{}, responses[4]["result"])
+}
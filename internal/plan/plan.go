@@ -0,0 +1,167 @@
+package plan
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Plan is an ordered list of operations applied to one pull request.
+type Plan struct {
+	// Repo and PR select the pull request when not given on the command line.
+	Repo string `json:"repo,omitempty"`
+	PR   int    `json:"pr,omitempty"`
+	// ContinueOnError keeps executing independent steps after a failure.
+	ContinueOnError bool   `json:"continue_on_error,omitempty"`
+	Steps           []Step `json:"steps"`
+}
+
+// Step is one operation. Every field other than id and op is an argument of
+// the operation.
+type Step struct {
+	ID   string
+	Op   string
+	Args map[string]interface{}
+}
+
+// UnmarshalJSON splits id and op from the operation arguments.
+func (s *Step) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var fields map[string]interface{}
+	if err := dec.Decode(&fields); err != nil {
+		return err
+	}
+	if fields == nil {
+		return errors.New("step must be an object")
+	}
+
+	step := Step{Args: make(map[string]interface{}, len(fields))}
+	for key, value := range fields {
+		switch key {
+		case "id", "op":
+			text, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("step %s must be a string", key)
+			}
+			if key == "id" {
+				step.ID = strings.TrimSpace(text)
+			} else {
+				step.Op = strings.TrimSpace(text)
+			}
+		default:
+			step.Args[key] = value
+		}
+	}
+	*s = step
+	return nil
+}
+
+// MarshalJSON writes the step back in its flattened form.
+func (s Step) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{}, len(s.Args)+2)
+	for key, value := range s.Args {
+		fields[key] = value
+	}
+	if s.ID != "" {
+		fields["id"] = s.ID
+	}
+	fields["op"] = s.Op
+	return json.Marshal(fields)
+}
+
+// Decode reads a plan document, rejecting unknown top-level fields.
+func Decode(r io.Reader) (Plan, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var p Plan
+	if err := dec.Decode(&p); err != nil {
+		return Plan{}, fmt.Errorf("decode plan: %w", err)
+	}
+	if len(p.Steps) == 0 {
+		return Plan{}, errors.New("plan has no steps")
+	}
+	return p, nil
+}
+
+// referencePattern matches a whole string of the form $steps.<id>.<field>.
+var referencePattern = regexp.MustCompile(`^\$steps\.([^.\s]+)\.([^.\s]+)$`)
+
+// reference is a parsed $steps.<id>.<field> value.
+type reference struct {
+	Step  string
+	Field string
+}
+
+func (r reference) String() string {
+	return "$steps." + r.Step + "." + r.Field
+}
+
+func parseReference(value string) (reference, bool) {
+	match := referencePattern.FindStringSubmatch(value)
+	if match == nil {
+		return reference{}, false
+	}
+	return reference{Step: match[1], Field: match[2]}, true
+}
+
+// references lists the step outputs an argument tree refers to.
+func references(value interface{}) []reference {
+	var refs []reference
+	walk(value, func(text string) (interface{}, bool) {
+		if ref, ok := parseReference(text); ok {
+			refs = append(refs, ref)
+		}
+		return nil, false
+	})
+	return refs
+}
+
+// substitute returns a copy of args with every reference replaced by lookup.
+func substitute(args map[string]interface{}, lookup func(reference) (interface{}, error)) (map[string]interface{}, error) {
+	var firstErr error
+	replaced := walk(args, func(text string) (interface{}, bool) {
+		ref, ok := parseReference(text)
+		if !ok {
+			return nil, false
+		}
+		value, err := lookup(ref)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		return value, true
+	})
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return replaced.(map[string]interface{}), nil
+}
+
+// walk rebuilds value, offering every string to replace.
+func walk(value interface{}, replace func(string) (interface{}, bool)) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = walk(item, replace)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = walk(item, replace)
+		}
+		return out
+	case string:
+		if replacement, ok := replace(v); ok {
+			return replacement
+		}
+		return v
+	default:
+		return v
+	}
+}
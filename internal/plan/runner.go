@@ -0,0 +1,238 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Step statuses reported in the result log.
+const (
+	StatusValid     = "valid"
+	StatusInvalid   = "invalid"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusSkipped   = "skipped"
+)
+
+// Action performs a prepared step and returns its JSON-encodable result.
+type Action func() (interface{}, error)
+
+// Operation describes one kind of step.
+type Operation struct {
+	// Outputs maps each result field later steps may reference to an example
+	// value. Dry runs substitute the examples so argument validation still runs.
+	Outputs map[string]interface{}
+	// Prepare validates the JSON arguments without calling GitHub and returns
+	// the action that performs the step.
+	Prepare func(args json.RawMessage) (Action, error)
+}
+
+// Runner validates and executes plans against a fixed set of operations.
+type Runner struct {
+	Operations map[string]Operation
+}
+
+// StepResult is one entry of the result log.
+type StepResult struct {
+	Index  int         `json:"index"`
+	ID     string      `json:"id,omitempty"`
+	Op     string      `json:"op"`
+	Status string      `json:"status"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// Result summarizes a plan run.
+type Result struct {
+	DryRun    bool         `json:"dry_run"`
+	Valid     bool         `json:"valid"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+	Skipped   int          `json:"skipped"`
+	Steps     []StepResult `json:"steps"`
+}
+
+// OK reports whether the plan was valid and no executed step failed.
+func (r Result) OK() bool {
+	return r.Valid && r.Failed == 0 && r.Skipped == 0
+}
+
+// Validate checks every step without executing anything.
+func (r *Runner) Validate(p Plan) Result {
+	result := Result{DryRun: true, Valid: true, Steps: make([]StepResult, len(p.Steps))}
+	earlier := make(map[string]string, len(p.Steps))
+
+	for i, step := range p.Steps {
+		entry := StepResult{Index: i, ID: step.ID, Op: step.Op, Status: StatusValid}
+		if err := r.validateStep(step, earlier); err != nil {
+			entry.Status = StatusInvalid
+			entry.Error = err.Error()
+			result.Valid = false
+		}
+		if step.ID != "" {
+			if _, dup := earlier[step.ID]; !dup {
+				earlier[step.ID] = step.Op
+			}
+		}
+		result.Steps[i] = entry
+	}
+	return result
+}
+
+func (r *Runner) validateStep(step Step, earlier map[string]string) error {
+	if step.Op == "" {
+		return fmt.Errorf("op is required (one of %s)", strings.Join(r.operationNames(), ", "))
+	}
+	op, ok := r.Operations[step.Op]
+	if !ok {
+		return fmt.Errorf("unknown op %q (one of %s)", step.Op, strings.Join(r.operationNames(), ", "))
+	}
+	if step.ID != "" {
+		if strings.ContainsAny(step.ID, ". \t") {
+			return fmt.Errorf("invalid step id %q: must not contain dots or spaces", step.ID)
+		}
+		if _, dup := earlier[step.ID]; dup {
+			return fmt.Errorf("duplicate step id %q", step.ID)
+		}
+	}
+
+	args, err := substitute(step.Args, func(ref reference) (interface{}, error) {
+		producer, ok := earlier[ref.Step]
+		if !ok {
+			return nil, fmt.Errorf("%s refers to unknown or later step %q", ref, ref.Step)
+		}
+		example, ok := r.Operations[producer].Outputs[ref.Field]
+		if !ok {
+			return nil, fmt.Errorf("%s: %s steps do not produce %q", ref, producer, ref.Field)
+		}
+		return example, nil
+	})
+	if err != nil {
+		return err
+	}
+	_, err = prepare(op, args)
+	return err
+}
+
+// Run validates the whole plan and, when it is valid, executes the steps in
+// order. An invalid plan executes nothing. After a failed step the remaining
+// steps are skipped unless continueOnError is set, in which case only steps
+// that reference a failed or skipped step are skipped.
+func (r *Runner) Run(p Plan, continueOnError bool) Result {
+	result := r.Validate(p)
+	result.DryRun = false
+	if !result.Valid {
+		return result
+	}
+
+	outputs := make(map[string]map[string]interface{}, len(p.Steps))
+	unavailable := make(map[string]bool)
+	stopped := false
+
+	for i, step := range p.Steps {
+		entry := StepResult{Index: i, ID: step.ID, Op: step.Op}
+		markUnavailable := func() {
+			if step.ID != "" {
+				unavailable[step.ID] = true
+			}
+		}
+
+		if stopped {
+			entry.Status = StatusSkipped
+			entry.Error = "not run after an earlier failure"
+			result.Skipped++
+			markUnavailable()
+			result.Steps[i] = entry
+			continue
+		}
+		if blocked := blockedBy(step, unavailable); blocked != "" {
+			entry.Status = StatusSkipped
+			entry.Error = fmt.Sprintf("depends on step %q, which did not succeed", blocked)
+			result.Skipped++
+			markUnavailable()
+			result.Steps[i] = entry
+			continue
+		}
+
+		value, err := r.execute(step, outputs)
+		if err != nil {
+			entry.Status = StatusFailed
+			entry.Error = err.Error()
+			result.Failed++
+			markUnavailable()
+			stopped = !continueOnError
+			result.Steps[i] = entry
+			continue
+		}
+
+		entry.Status = StatusSucceeded
+		entry.Result = value
+		result.Succeeded++
+		if step.ID != "" {
+			outputs[step.ID] = resultFields(value)
+		}
+		result.Steps[i] = entry
+	}
+	return result
+}
+
+func (r *Runner) execute(step Step, outputs map[string]map[string]interface{}) (interface{}, error) {
+	args, err := substitute(step.Args, func(ref reference) (interface{}, error) {
+		value, ok := outputs[ref.Step][ref.Field]
+		if !ok || value == nil {
+			return nil, fmt.Errorf("%s is not set in the result of step %q", ref, ref.Step)
+		}
+		return value, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	action, err := prepare(r.Operations[step.Op], args)
+	if err != nil {
+		return nil, err
+	}
+	return action()
+}
+
+func prepare(op Operation, args map[string]interface{}) (Action, error) {
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("encode arguments: %w", err)
+	}
+	return op.Prepare(raw)
+}
+
+// blockedBy returns the first unavailable step the arguments refer to.
+func blockedBy(step Step, unavailable map[string]bool) string {
+	for _, ref := range references(step.Args) {
+		if unavailable[ref.Step] {
+			return ref.Step
+		}
+	}
+	return ""
+}
+
+// resultFields flattens a step result into the fields references can read.
+// Results that are not JSON objects expose no fields.
+func resultFields(value interface{}) map[string]interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+func (r *Runner) operationNames() []string {
+	names := make([]string, 0, len(r.Operations))
+	for name := range r.Operations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
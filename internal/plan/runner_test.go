@@ -0,0 +1,137 @@
+package plan
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a fake operation set that logs executed steps.
+type recorder struct {
+	calls []string
+	fail  map[string]bool
+}
+
+func (r *recorder) runner() *Runner {
+	echo := func(name string) Operation {
+		return Operation{
+			Outputs: map[string]interface{}{"id": "X_example"},
+			Prepare: func(raw json.RawMessage) (Action, error) {
+				var args struct {
+					Value string `json:"value"`
+				}
+				dec := json.NewDecoder(strings.NewReader(string(raw)))
+				dec.DisallowUnknownFields()
+				if err := dec.Decode(&args); err != nil {
+					return nil, err
+				}
+				if !strings.HasPrefix(args.Value, "X_") {
+					return nil, errors.New("value must start with X_")
+				}
+				return func() (interface{}, error) {
+					r.calls = append(r.calls, name+":"+args.Value)
+					if r.fail[args.Value] {
+						return nil, errors.New("boom")
+					}
+					return map[string]string{"id": args.Value + "_out"}, nil
+				}, nil
+			},
+		}
+	}
+	return &Runner{Operations: map[string]Operation{"first": echo("first"), "second": echo("second")}}
+}
+
+func decodePlan(t *testing.T, doc string) Plan {
+	t.Helper()
+	p, err := Decode(strings.NewReader(doc))
+	require.NoError(t, err)
+	return p
+}
+
+func TestDecodeSplitsStepArguments(t *testing.T) {
+	p := decodePlan(t, `{"repo":"octo/demo","pr":7,"steps":[{"id":"a","op":"first","value":"X_1","line":3}]}`)
+
+	assert.Equal(t, "octo/demo", p.Repo)
+	assert.Equal(t, 7, p.PR)
+	require.Len(t, p.Steps, 1)
+	assert.Equal(t, "a", p.Steps[0].ID)
+	assert.Equal(t, "first", p.Steps[0].Op)
+	assert.Equal(t, map[string]interface{}{"value": "X_1", "line": json.Number("3")}, p.Steps[0].Args)
+
+	_, err := Decode(strings.NewReader(`{"steps":[]}`))
+	require.EqualError(t, err, "plan has no steps")
+	_, err = Decode(strings.NewReader(`{"steps":[{"op":"first"}],"extra":1}`))
+	require.Error(t, err)
+}
+
+func TestRunSubstitutesReferences(t *testing.T) {
+	rec := &recorder{}
+	p := decodePlan(t, `{"steps":[
+		{"id":"a","op":"first","value":"X_1"},
+		{"op":"second","value":"$steps.a.id"}
+	]}`)
+
+	result := rec.runner().Run(p, false)
+	assert.True(t, result.OK())
+	assert.Equal(t, []string{"first:X_1", "second:X_1_out"}, rec.calls)
+	assert.Equal(t, 2, result.Succeeded)
+	assert.Equal(t, StatusSucceeded, result.Steps[1].Status)
+	assert.Equal(t, map[string]string{"id": "X_1_out_out"}, result.Steps[1].Result)
+}
+
+func TestValidateReportsEveryInvalidStep(t *testing.T) {
+	rec := &recorder{}
+	p := decodePlan(t, `{"steps":[
+		{"id":"a","op":"first","value":"$steps.b.id"},
+		{"id":"b","op":"second","value":"$steps.a.missing"},
+		{"id":"a","op":"first","value":"X_1"},
+		{"op":"third"},
+		{"op":"first","value":"bad"},
+		{"op":"second","value":"$steps.b.id"}
+	]}`)
+
+	result := rec.runner().Validate(p)
+	assert.False(t, result.Valid)
+	assert.True(t, result.DryRun)
+	statuses := make([]string, len(result.Steps))
+	for i, step := range result.Steps {
+		statuses[i] = step.Status
+	}
+	assert.Equal(t, []string{StatusInvalid, StatusInvalid, StatusInvalid, StatusInvalid, StatusInvalid, StatusValid}, statuses)
+	assert.Contains(t, result.Steps[0].Error, "unknown or later step")
+	assert.Contains(t, result.Steps[1].Error, `do not produce "missing"`)
+	assert.Contains(t, result.Steps[2].Error, "duplicate step id")
+	assert.Contains(t, result.Steps[3].Error, `unknown op "third"`)
+	assert.Contains(t, result.Steps[4].Error, "must start with X_")
+
+	result = rec.runner().Run(p, true)
+	assert.False(t, result.Valid)
+	assert.Empty(t, rec.calls)
+}
+
+func TestRunStopsOrContinuesAfterFailure(t *testing.T) {
+	doc := `{"steps":[
+		{"id":"a","op":"first","value":"X_1"},
+		{"op":"second","value":"$steps.a.id"},
+		{"op":"second","value":"X_2"}
+	]}`
+
+	rec := &recorder{fail: map[string]bool{"X_1": true}}
+	result := rec.runner().Run(decodePlan(t, doc), false)
+	assert.Equal(t, []string{"first:X_1"}, rec.calls)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 2, result.Skipped)
+	assert.Equal(t, "boom", result.Steps[0].Error)
+
+	rec = &recorder{fail: map[string]bool{"X_1": true}}
+	result = rec.runner().Run(decodePlan(t, doc), true)
+	assert.Equal(t, []string{"first:X_1", "second:X_2"}, rec.calls)
+	assert.Equal(t, StatusSkipped, result.Steps[1].Status)
+	assert.Contains(t, result.Steps[1].Error, `depends on step "a"`)
+	assert.Equal(t, StatusSucceeded, result.Steps[2].Status)
+	assert.False(t, result.OK())
+}
@@ -56,6 +56,7 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 		return Report{Reviews: []ReportReview{}}
 	}
 
+	activity := make(map[string]time.Time)
 	for _, thread := range threads {
 		if filters.RequireUnresolved && thread.IsResolved {
 			continue
@@ -63,6 +64,12 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 		if filters.RequireNotOutdated && thread.IsOutdated {
 			continue
 		}
+		latest := latestActivity(thread.Comments)
+		if filters.LastSeen != nil {
+			if seenAt, ok := filters.LastSeen[thread.ID]; ok && !latest.After(seenAt) {
+				continue
+			}
+		}
 
 		var parent *ThreadComment
 		replies := make([]ThreadComment, 0, len(thread.Comments))
@@ -79,6 +86,18 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 		if parent == nil || parent.ReviewDatabaseID == nil {
 			continue
 		}
+		if filters.HideMinimized {
+			if parent.IsMinimized {
+				continue
+			}
+			visible := replies[:0]
+			for _, reply := range replies {
+				if !reply.IsMinimized {
+					visible = append(visible, reply)
+				}
+			}
+			replies = visible
+		}
 
 		reviewIdx, ok := reviewIndexByID[*parent.ReviewDatabaseID]
 		if !ok {
@@ -102,10 +121,13 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 				commentNodeID = &replyID
 			}
 			reportReplies[i] = ThreadReply{
-				CommentNodeID: commentNodeID,
-				AuthorLogin:   reply.AuthorLogin,
-				Body:          reply.Body,
-				CreatedAt:     createdAt,
+				CommentNodeID:   commentNodeID,
+				AuthorLogin:     reply.AuthorLogin,
+				Body:            reply.Body,
+				CreatedAt:       createdAt,
+				IsMinimized:     reply.IsMinimized,
+				MinimizedReason: minimizedReason(reply),
+				Reactions:       reply.Reactions,
 			}
 		}
 
@@ -116,16 +138,19 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 			commentNodeID = &id
 		}
 		reportComment := ReportComment{
-			ThreadID:       thread.ID,
-			CommentNodeID:  commentNodeID,
-			Path:           thread.Path,
-			Line:           thread.Line,
-			AuthorLogin:    parent.AuthorLogin,
-			Body:           parent.Body,
-			CreatedAt:      createdAt,
-			IsResolved:     thread.IsResolved,
-			IsOutdated:     thread.IsOutdated,
-			ThreadComments: reportReplies,
+			ThreadID:        thread.ID,
+			CommentNodeID:   commentNodeID,
+			Path:            thread.Path,
+			Line:            thread.Line,
+			AuthorLogin:     parent.AuthorLogin,
+			Body:            parent.Body,
+			CreatedAt:       createdAt,
+			IsResolved:      thread.IsResolved,
+			IsOutdated:      thread.IsOutdated,
+			IsMinimized:     parent.IsMinimized,
+			MinimizedReason: minimizedReason(*parent),
+			Reactions:       parent.Reactions,
+			ThreadComments:  reportReplies,
 		}
 
 		if len(reportReplies) == 0 {
@@ -134,6 +159,7 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 
 		review := &reportReviews[reviewIdx]
 		review.Comments = append(review.Comments, reportComment)
+		activity[thread.ID] = latest
 	}
 
 	for i := range reportReviews {
@@ -142,7 +168,31 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 		}
 	}
 
-	return Report{Reviews: reportReviews}
+	return Report{Reviews: reportReviews, activity: activity}
+}
+
+// minimizedReason normalizes GitHub's reason ("off-topic") to the classifier
+// spelling used by comments hide ("OFF_TOPIC").
+func minimizedReason(comment ThreadComment) string {
+	if !comment.IsMinimized {
+		return ""
+	}
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(comment.MinimizedReason), "-", "_"))
+}
+
+// latestActivity returns the most recent comment creation or edit time.
+func latestActivity(comments []ThreadComment) time.Time {
+	var latest time.Time
+	for _, comment := range comments {
+		at := comment.UpdatedAt
+		if comment.CreatedAt.After(at) {
+			at = comment.CreatedAt
+		}
+		if at.After(latest) {
+			latest = at
+		}
+	}
+	return latest
 }
 
 func allowedStateSet(states []State) map[State]struct{} {
@@ -152,6 +202,7 @@ func allowedStateSet(states []State) map[State]struct{} {
 			StateChangesRequested: {},
 			StateCommented:        {},
 			StateDismissed:        {},
+			StatePending:          {},
 		}
 	}
 
@@ -161,3 +212,49 @@ func allowedStateSet(states []State) map[State]struct{} {
 	}
 	return set
 }
+
+// BuildPullRequest shapes pull request metadata into the serialized report section.
+func BuildPullRequest(pr PullRequest) *ReportPullRequest {
+	var body *string
+	if trimmed := strings.TrimSpace(pr.Body); trimmed != "" {
+		body = &trimmed
+	}
+
+	labels := make([]string, 0, len(pr.Labels))
+	labels = append(labels, pr.Labels...)
+
+	return &ReportPullRequest{
+		Number:      pr.Number,
+		Title:       pr.Title,
+		Body:        body,
+		URL:         pr.URL,
+		State:       pr.State,
+		IsDraft:     pr.IsDraft,
+		AuthorLogin: pr.AuthorLogin,
+		BaseRef:     pr.BaseRef,
+		HeadRef:     pr.HeadRef,
+		HeadSHA:     pr.HeadSHA,
+		Mergeable:   pr.Mergeable,
+		Labels:      labels,
+	}
+}
+
+// BuildConversation shapes top-level conversation comments, sorted by created_at ascending.
+func BuildConversation(comments []ConversationComment) []ReportConversation {
+	sorted := make([]ConversationComment, len(comments))
+	copy(sorted, comments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	conversation := make([]ReportConversation, len(sorted))
+	for i, comment := range sorted {
+		conversation[i] = ReportConversation{
+			CommentNodeID: comment.NodeID,
+			AuthorLogin:   comment.AuthorLogin,
+			Body:          comment.Body,
+			CreatedAt:     comment.CreatedAt.UTC().Format(time.RFC3339),
+		}
+	}
+	return conversation
+}
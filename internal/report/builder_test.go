@@ -239,6 +239,106 @@ func TestBuildReportFilterOptions(t *testing.T) {
 	}
 }
 
+func TestBuildReportHideMinimized(t *testing.T) {
+	reviews := []report.Review{{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1}}
+	at := time.Date(2025, 12, 3, 0, 0, 0, 0, time.UTC)
+
+	threads := []report.Thread{
+		{
+			ID:   "T_visible",
+			Path: "a.go",
+			Comments: []report.ThreadComment{
+				{NodeID: "C1", DatabaseID: 1, CreatedAt: at, AuthorLogin: "alice", ReviewDatabaseID: intPtr(1)},
+				{NodeID: "C2", DatabaseID: 2, Body: "stale bot note", CreatedAt: at.Add(time.Minute), AuthorLogin: "bot", ReviewDatabaseID: intPtr(1), ReplyToDatabaseID: intPtr(1), IsMinimized: true, MinimizedReason: "off-topic"},
+				{NodeID: "C3", DatabaseID: 3, Body: "fixed", CreatedAt: at.Add(2 * time.Minute), AuthorLogin: "bob", ReviewDatabaseID: intPtr(1), ReplyToDatabaseID: intPtr(1)},
+			},
+		},
+		{
+			ID:   "T_hidden",
+			Path: "b.go",
+			Comments: []report.ThreadComment{
+				{NodeID: "C4", DatabaseID: 4, CreatedAt: at, AuthorLogin: "alice", ReviewDatabaseID: intPtr(1), IsMinimized: true, MinimizedReason: "outdated"},
+			},
+		},
+	}
+
+	all := report.BuildReport(reviews, threads, report.FilterOptions{})
+	if len(all.Reviews[0].Comments) != 2 {
+		t.Fatalf("expected both threads without the filter, got %+v", all.Reviews[0].Comments)
+	}
+	hidden := mustFindComment(all.Reviews[0].Comments, "T_hidden")
+	if !hidden.IsMinimized || hidden.MinimizedReason != "OUTDATED" {
+		t.Fatalf("expected minimized parent with OUTDATED reason, got %+v", hidden)
+	}
+	reply := mustFindComment(all.Reviews[0].Comments, "T_visible").ThreadComments[0]
+	if !reply.IsMinimized || reply.MinimizedReason != "OFF_TOPIC" {
+		t.Fatalf("expected minimized reply with OFF_TOPIC reason, got %+v", reply)
+	}
+
+	result := report.BuildReport(reviews, threads, report.FilterOptions{HideMinimized: true})
+	if len(result.Reviews) != 1 || len(result.Reviews[0].Comments) != 1 {
+		t.Fatalf("expected only the visible thread, got %+v", result.Reviews)
+	}
+	visible := result.Reviews[0].Comments[0]
+	if visible.ThreadID != "T_visible" {
+		t.Fatalf("expected T_visible, got %s", visible.ThreadID)
+	}
+	if len(visible.ThreadComments) != 1 || visible.ThreadComments[0].Body != "fixed" {
+		t.Fatalf("expected minimized reply to be dropped, got %+v", visible.ThreadComments)
+	}
+}
+
+func TestBuildReportLastSeen(t *testing.T) {
+	reviews := []report.Review{{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1}}
+	seenAt := time.Date(2025, 12, 3, 0, 1, 0, 0, time.UTC)
+
+	threads := []report.Thread{
+		{
+			ID:   "T_seen",
+			Path: "a.go",
+			Comments: []report.ThreadComment{
+				{NodeID: "C1", DatabaseID: 1, CreatedAt: seenAt, UpdatedAt: seenAt, AuthorLogin: "alice", ReviewDatabaseID: intPtr(1)},
+			},
+		},
+		{
+			ID:   "T_edited",
+			Path: "b.go",
+			Comments: []report.ThreadComment{
+				{NodeID: "C2", DatabaseID: 2, CreatedAt: seenAt, UpdatedAt: seenAt.Add(time.Minute), AuthorLogin: "alice", ReviewDatabaseID: intPtr(1)},
+			},
+		},
+		{
+			ID:   "T_unseen",
+			Path: "c.go",
+			Comments: []report.ThreadComment{
+				{NodeID: "C3", DatabaseID: 3, CreatedAt: seenAt, AuthorLogin: "alice", ReviewDatabaseID: intPtr(1)},
+			},
+		},
+	}
+
+	result := report.BuildReport(reviews, threads, report.FilterOptions{
+		LastSeen: map[string]time.Time{"T_seen": seenAt, "T_edited": seenAt},
+	})
+
+	if len(result.Reviews) != 1 || len(result.Reviews[0].Comments) != 2 {
+		t.Fatalf("expected 2 threads with new activity, got %+v", result.Reviews)
+	}
+	if mustFindComment(result.Reviews[0].Comments, "T_seen").ThreadID != "" {
+		t.Fatal("expected seen thread to be filtered out")
+	}
+
+	activity := result.ThreadActivity()
+	if len(activity) != 2 {
+		t.Fatalf("expected activity for 2 threads, got %v", activity)
+	}
+	if !activity["T_edited"].Equal(seenAt.Add(time.Minute)) {
+		t.Fatalf("expected edit time as activity, got %s", activity["T_edited"])
+	}
+	if !activity["T_unseen"].Equal(seenAt) {
+		t.Fatalf("expected creation time as activity, got %s", activity["T_unseen"])
+	}
+}
+
 func intPtr(v int) *int {
 	return &v
 }
@@ -10,6 +10,8 @@ const (
 	StateChangesRequested State = "CHANGES_REQUESTED"
 	StateCommented        State = "COMMENTED"
 	StateDismissed        State = "DISMISSED"
+	// StatePending is only ever visible for the viewer's own unsubmitted review.
+	StatePending State = "PENDING"
 )
 
 // FilterOptions controls shaping of reviews and threads.
@@ -20,6 +22,10 @@ type FilterOptions struct {
 	RequireNotOutdated   bool
 	TailReplies          int
 	IncludeCommentNodeID bool
+	// HideMinimized drops minimized replies and threads whose parent comment
+	// is minimized.
+	HideMinimized bool
+	LastSeen      map[string]time.Time
 }
 
 // Review models a pull request review fetched from GraphQL.
@@ -30,6 +36,9 @@ type Review struct {
 	SubmittedAt *time.Time
 	AuthorLogin string
 	DatabaseID  int
+	// CommitOID is the head commit the review was submitted against; it is
+	// only fetched for status and models.
+	CommitOID string
 }
 
 // Thread captures a review thread and its constituent comments.
@@ -48,15 +57,99 @@ type ThreadComment struct {
 	DatabaseID         int
 	Body               string
 	CreatedAt          time.Time
+	UpdatedAt          time.Time
 	AuthorLogin        string
 	ReviewDatabaseID   *int
 	ReplyToDatabaseID  *int
 	ReplyToCommentNode *string
+	IsMinimized        bool
+	MinimizedReason    string
+	Reactions          []Reaction
+}
+
+// Reaction counts the reactions of one kind on a comment.
+type Reaction struct {
+	Content          string `json:"content"`
+	Count            int    `json:"count"`
+	ViewerHasReacted bool   `json:"viewer_has_reacted"`
+}
+
+// PullRequest captures pull request metadata fetched alongside reviews.
+type PullRequest struct {
+	Number      int
+	Title       string
+	Body        string
+	URL         string
+	State       string
+	IsDraft     bool
+	AuthorLogin string
+	BaseRef     string
+	HeadRef     string
+	HeadSHA     string
+	Mergeable   string
+	Labels      []string
+}
+
+// ConversationComment represents a top-level (issue) comment on the pull request.
+type ConversationComment struct {
+	NodeID      string
+	Body        string
+	CreatedAt   time.Time
+	AuthorLogin string
 }
 
 // Report is the serialized output structure for the report command.
 type Report struct {
-	Reviews []ReportReview `json:"reviews"`
+	PullRequest *ReportPullRequest `json:"pull_request,omitempty"`
+	Reviews     []ReportReview     `json:"reviews"`
+	// Conversation is nil unless requested, so an empty section still encodes
+	// as [] while an unrequested one is left out.
+	Conversation *[]ReportConversation `json:"conversation,omitempty"`
+
+	activity map[string]time.Time
+	status   *Status
+	models   Models
+}
+
+// Models holds the parsed reviews and threads of a pull request before they
+// are filtered and shaped into a report.
+type Models struct {
+	Reviews []Review
+	Threads []Thread
+}
+
+// ThreadActivity returns the latest comment timestamp of every thread included
+// in the report, keyed by thread node ID.
+func (r Report) ThreadActivity() map[string]time.Time {
+	activity := make(map[string]time.Time, len(r.activity))
+	for threadID, at := range r.activity {
+		activity[threadID] = at
+	}
+	return activity
+}
+
+// ReportPullRequest contains the shaped pull request metadata section.
+type ReportPullRequest struct {
+	Number      int      `json:"number"`
+	Title       string   `json:"title"`
+	Body        *string  `json:"body,omitempty"`
+	URL         string   `json:"url"`
+	State       string   `json:"state"`
+	IsDraft     bool     `json:"is_draft"`
+	AuthorLogin string   `json:"author_login,omitempty"`
+	BaseRef     string   `json:"base_ref"`
+	HeadRef     string   `json:"head_ref"`
+	HeadSHA     string   `json:"head_sha"`
+	Mergeable   string   `json:"mergeable,omitempty"`
+	Labels      []string `json:"labels"`
+}
+
+// ReportConversation captures a top-level pull request conversation comment.
+type ReportConversation struct {
+	CommentNodeID string `json:"comment_node_id"`
+	AuthorLogin   string `json:"author_login"`
+	Body          string `json:"body"`
+	CreatedAt     string `json:"created_at"`
 }
 
 // ReportReview aggregates review data and associated thread comments.
@@ -71,22 +164,28 @@ type ReportReview struct {
 
 // ReportComment contains the shaped parent comment for a thread.
 type ReportComment struct {
-	ThreadID       string        `json:"thread_id"`
-	CommentNodeID  *string       `json:"comment_node_id,omitempty"`
-	Path           string        `json:"path"`
-	Line           *int          `json:"line,omitempty"`
-	AuthorLogin    string        `json:"author_login"`
-	Body           string        `json:"body"`
-	CreatedAt      string        `json:"created_at"`
-	IsResolved     bool          `json:"is_resolved"`
-	IsOutdated     bool          `json:"is_outdated"`
-	ThreadComments []ThreadReply `json:"thread_comments"`
+	ThreadID        string        `json:"thread_id"`
+	CommentNodeID   *string       `json:"comment_node_id,omitempty"`
+	Path            string        `json:"path"`
+	Line            *int          `json:"line,omitempty"`
+	AuthorLogin     string        `json:"author_login"`
+	Body            string        `json:"body"`
+	CreatedAt       string        `json:"created_at"`
+	IsResolved      bool          `json:"is_resolved"`
+	IsOutdated      bool          `json:"is_outdated"`
+	IsMinimized     bool          `json:"is_minimized,omitempty"`
+	MinimizedReason string        `json:"minimized_reason,omitempty"`
+	Reactions       []Reaction    `json:"reactions,omitempty"`
+	ThreadComments  []ThreadReply `json:"thread_comments"`
 }
 
 // ThreadReply captures a reply within a thread.
 type ThreadReply struct {
-	CommentNodeID *string `json:"comment_node_id,omitempty"`
-	AuthorLogin   string  `json:"author_login"`
-	Body          string  `json:"body"`
-	CreatedAt     string  `json:"created_at"`
+	CommentNodeID   *string    `json:"comment_node_id,omitempty"`
+	AuthorLogin     string     `json:"author_login"`
+	Body            string     `json:"body"`
+	CreatedAt       string     `json:"created_at"`
+	IsMinimized     bool       `json:"is_minimized,omitempty"`
+	MinimizedReason string     `json:"minimized_reason,omitempty"`
+	Reactions       []Reaction `json:"reactions,omitempty"`
 }
@@ -0,0 +1,257 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type reviewConnection struct {
+	Nodes    []reviewNode `json:"nodes"`
+	PageInfo pageInfo     `json:"pageInfo"`
+}
+
+type reviewNode struct {
+	ID          string  `json:"id"`
+	State       string  `json:"state"`
+	Body        *string `json:"body"`
+	SubmittedAt *string `json:"submittedAt"`
+	DatabaseID  *int    `json:"databaseId"`
+	Author      *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Commit *struct {
+		OID string `json:"oid"`
+	} `json:"commit"`
+}
+
+type threadConnection struct {
+	Nodes    []threadNode `json:"nodes"`
+	PageInfo pageInfo     `json:"pageInfo"`
+}
+
+type threadNode struct {
+	ID         string `json:"id"`
+	Path       string `json:"path"`
+	Line       *int   `json:"line"`
+	IsResolved bool   `json:"isResolved"`
+	IsOutdated bool   `json:"isOutdated"`
+	Comments   struct {
+		Nodes []struct {
+			ID         string `json:"id"`
+			DatabaseID int    `json:"databaseId"`
+			Body       string `json:"body"`
+			CreatedAt  string `json:"createdAt"`
+			UpdatedAt  string `json:"updatedAt"`
+			Author     *struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			PullRequestReview *struct {
+				DatabaseID *int   `json:"databaseId"`
+				State      string `json:"state"`
+				ID         string `json:"id"`
+			} `json:"pullRequestReview"`
+			ReplyTo *struct {
+				ID         string `json:"id"`
+				DatabaseID int    `json:"databaseId"`
+			} `json:"replyTo"`
+			IsMinimized     bool   `json:"isMinimized"`
+			MinimizedReason string `json:"minimizedReason"`
+			ReactionGroups  []struct {
+				Content          string `json:"content"`
+				ViewerHasReacted bool   `json:"viewerHasReacted"`
+				Users            struct {
+					TotalCount int `json:"totalCount"`
+				} `json:"users"`
+			} `json:"reactionGroups"`
+		} `json:"nodes"`
+	} `json:"comments"`
+}
+
+// remainingReviews follows the reviews connection past the first page using
+// the same variables as the report query.
+func (s *Service) remainingReviews(pr resolver.Identity, base map[string]interface{}, page pageInfo) ([]reviewNode, error) {
+	var nodes []reviewNode
+	for page.HasNextPage {
+		if page.EndCursor == "" {
+			return nil, errors.New("reviews pagination missing endCursor")
+		}
+		variables := map[string]interface{}{
+			"owner":        pr.Owner,
+			"name":         pr.Repo,
+			"number":       pr.Number,
+			"firstReviews": base["firstReviews"],
+			"after":        page.EndCursor,
+		}
+		for _, key := range []string{"states", "includeStatus"} {
+			if value, ok := base[key]; ok {
+				variables[key] = value
+			}
+		}
+		var response struct {
+			Repository *struct {
+				PullRequest *struct {
+					Reviews reviewConnection `json:"reviews"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		if err := s.API.GraphQL(reviewsPageQuery, variables, &response); err != nil {
+			return nil, err
+		}
+		if response.Repository == nil || response.Repository.PullRequest == nil {
+			return nil, errors.New("pull request not found or inaccessible")
+		}
+		nodes = append(nodes, response.Repository.PullRequest.Reviews.Nodes...)
+		page = response.Repository.PullRequest.Reviews.PageInfo
+	}
+	return nodes, nil
+}
+
+// remainingThreads follows the review threads connection past the first page
+// using the same variables as the report query.
+func (s *Service) remainingThreads(pr resolver.Identity, base map[string]interface{}, page pageInfo) ([]threadNode, error) {
+	var nodes []threadNode
+	for page.HasNextPage {
+		if page.EndCursor == "" {
+			return nil, errors.New("review threads pagination missing endCursor")
+		}
+		variables := map[string]interface{}{
+			"owner":         pr.Owner,
+			"name":          pr.Repo,
+			"number":        pr.Number,
+			"firstThreads":  base["firstThreads"],
+			"firstComments": base["firstComments"],
+			"after":         page.EndCursor,
+		}
+		if value, ok := base["includeReactions"]; ok {
+			variables["includeReactions"] = value
+		}
+		var response struct {
+			Repository *struct {
+				PullRequest *struct {
+					ReviewThreads threadConnection `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		if err := s.API.GraphQL(threadsPageQuery, variables, &response); err != nil {
+			return nil, err
+		}
+		if response.Repository == nil || response.Repository.PullRequest == nil {
+			return nil, errors.New("pull request not found or inaccessible")
+		}
+		nodes = append(nodes, response.Repository.PullRequest.ReviewThreads.Nodes...)
+		page = response.Repository.PullRequest.ReviewThreads.PageInfo
+	}
+	return nodes, nil
+}
+
+func parseReview(node reviewNode) (Review, error) {
+	if node.DatabaseID == nil {
+		return Review{}, errors.New("review missing databaseId")
+	}
+	// Deleted accounts surface as a null author; report GitHub's ghost login instead.
+	login := "ghost"
+	if node.Author != nil && node.Author.Login != "" {
+		login = node.Author.Login
+	}
+	state, ok := parseState(node.State)
+	if !ok {
+		return Review{}, fmt.Errorf("unknown review state %q", node.State)
+	}
+	review := Review{
+		ID:          node.ID,
+		State:       state,
+		Body:        node.Body,
+		AuthorLogin: login,
+		DatabaseID:  *node.DatabaseID,
+	}
+	if node.Commit != nil {
+		review.CommitOID = node.Commit.OID
+	}
+	if node.SubmittedAt != nil && strings.TrimSpace(*node.SubmittedAt) != "" {
+		parsed, err := time.Parse(time.RFC3339, *node.SubmittedAt)
+		if err != nil {
+			return Review{}, fmt.Errorf("parse review submittedAt: %w", err)
+		}
+		review.SubmittedAt = &parsed
+	}
+	return review, nil
+}
+
+func parseThread(node threadNode) (Thread, error) {
+	thread := Thread{
+		ID:         node.ID,
+		Path:       node.Path,
+		Line:       node.Line,
+		IsResolved: node.IsResolved,
+		IsOutdated: node.IsOutdated,
+		Comments:   make([]ThreadComment, 0, len(node.Comments.Nodes)),
+	}
+
+	for _, comment := range node.Comments.Nodes {
+		if comment.ID == "" {
+			return Thread{}, errors.New("comment missing id")
+		}
+		login := "ghost"
+		if comment.Author != nil && comment.Author.Login != "" {
+			login = comment.Author.Login
+		}
+		createdAt, err := time.Parse(time.RFC3339, comment.CreatedAt)
+		if err != nil {
+			return Thread{}, fmt.Errorf("parse comment createdAt: %w", err)
+		}
+		updatedAt := createdAt
+		if strings.TrimSpace(comment.UpdatedAt) != "" {
+			updatedAt, err = time.Parse(time.RFC3339, comment.UpdatedAt)
+			if err != nil {
+				return Thread{}, fmt.Errorf("parse comment updatedAt: %w", err)
+			}
+		}
+		var reviewDatabaseID *int
+		if comment.PullRequestReview != nil {
+			reviewDatabaseID = comment.PullRequestReview.DatabaseID
+		}
+		var replyTo *int
+		var replyToNode *string
+		if comment.ReplyTo != nil {
+			replyID := comment.ReplyTo.DatabaseID
+			replyTo = &replyID
+			if comment.ReplyTo.ID != "" {
+				replyNode := comment.ReplyTo.ID
+				replyToNode = &replyNode
+			}
+		}
+
+		var reactions []Reaction
+		for _, group := range comment.ReactionGroups {
+			if group.Users.TotalCount == 0 {
+				continue
+			}
+			reactions = append(reactions, Reaction{Content: group.Content, Count: group.Users.TotalCount, ViewerHasReacted: group.ViewerHasReacted})
+		}
+
+		thread.Comments = append(thread.Comments, ThreadComment{
+			NodeID:             comment.ID,
+			DatabaseID:         comment.DatabaseID,
+			Body:               comment.Body,
+			CreatedAt:          createdAt,
+			UpdatedAt:          updatedAt,
+			AuthorLogin:        login,
+			ReviewDatabaseID:   reviewDatabaseID,
+			ReplyToDatabaseID:  replyTo,
+			ReplyToCommentNode: replyToNode,
+			IsMinimized:        comment.IsMinimized,
+			MinimizedReason:    comment.MinimizedReason,
+			Reactions:          reactions,
+		})
+	}
+	return thread, nil
+}
@@ -1,28 +1,19 @@
 package report
 
-const reportQuery = `query Report(
-  $owner: String!,
-  $name: String!,
-  $number: Int!,
-  $states: [PullRequestReviewState!],
-  $firstReviews: Int,
-  $firstThreads: Int,
-  $firstComments: Int
-) {
-  repository(owner: $owner, name: $name) {
-    pullRequest(number: $number) {
-      reviews(first: $firstReviews, states: $states) {
-        nodes {
+// reviewFields selects a review node for the report and its follow-up pages.
+const reviewFields = `
           id
           state
           body
           submittedAt
           databaseId
           author { login }
-        }
-      }
-      reviewThreads(first: $firstThreads) {
-        nodes {
+          commit @include(if: $includeStatus) { oid }
+        `
+
+// threadFields selects a review thread node for the report and its follow-up
+// pages.
+const threadFields = `
           id
           path
           line
@@ -34,6 +25,7 @@ const reportQuery = `query Report(
               databaseId
               body
               createdAt
+              updatedAt
               author { login }
               pullRequestReview {
                 id
@@ -44,8 +36,159 @@ const reportQuery = `query Report(
                 id
                 databaseId
               }
+              isMinimized
+              minimizedReason
+              reactionGroups @include(if: $includeReactions) {
+                content
+                viewerHasReacted
+                users { totalCount }
+              }
             }
           }
+        `
+
+const reportQuery = `query Report(
+  $owner: String!,
+  $name: String!,
+  $number: Int!,
+  $states: [PullRequestReviewState!],
+  $firstReviews: Int,
+  $firstThreads: Int,
+  $firstComments: Int,
+  $includePullRequest: Boolean = false,
+  $includeConversation: Boolean = false,
+  $includeReactions: Boolean = false,
+  $includeStatus: Boolean = false,
+  $firstConversation: Int
+) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      ... @include(if: $includePullRequest) {
+        number
+        title
+        body
+        url
+        state
+        isDraft
+        author { login }
+        baseRefName
+        headRefName
+        headRefOid
+        mergeable
+        labels(first: 100) {
+          nodes { name }
+        }
+      }
+      ... @include(if: $includeStatus) {
+        isDraft
+        headRefOid
+        reviewDecision
+        author { login }
+        reviewRequests(first: 100) {
+          nodes {
+            requestedReviewer {
+              __typename
+              ... on User { login }
+              ... on Mannequin { login }
+              ... on Team { slug organization { login } }
+            }
+          }
+          pageInfo { hasNextPage }
+        }
+      }
+      comments(first: $firstConversation) @include(if: $includeConversation) {
+        nodes {
+          id
+          body
+          createdAt
+          author { login }
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+      reviews(first: $firstReviews, states: $states) {
+        nodes {` + reviewFields + `}
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+      reviewThreads(first: $firstThreads) {
+        nodes {` + threadFields + `}
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}`
+
+const conversationQuery = `query ReportConversation(
+  $owner: String!,
+  $name: String!,
+  $number: Int!,
+  $firstConversation: Int,
+  $after: String
+) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      comments(first: $firstConversation, after: $after) {
+        nodes {
+          id
+          body
+          createdAt
+          author { login }
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}`
+
+const reviewsPageQuery = `query ReportReviews(
+  $owner: String!,
+  $name: String!,
+  $number: Int!,
+  $states: [PullRequestReviewState!],
+  $firstReviews: Int,
+  $includeStatus: Boolean = false,
+  $after: String
+) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviews(first: $firstReviews, after: $after, states: $states) {
+        nodes {` + reviewFields + `}
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}`
+
+const threadsPageQuery = `query ReportThreads(
+  $owner: String!,
+  $name: String!,
+  $number: Int!,
+  $firstThreads: Int,
+  $firstComments: Int,
+  $includeReactions: Boolean = false,
+  $after: String
+) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewThreads(first: $firstThreads, after: $after) {
+        nodes {` + threadFields + `}
+        pageInfo {
+          hasNextPage
+          endCursor
         }
       }
     }
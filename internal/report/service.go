@@ -11,9 +11,10 @@ import (
 )
 
 const (
-	defaultFirstReviews  = 100
-	defaultFirstThreads  = 100
-	defaultFirstComments = 100
+	defaultFirstReviews      = 100
+	defaultFirstThreads      = 100
+	defaultFirstComments     = 100
+	defaultFirstConversation = 100
 )
 
 // Service fetches and shapes pull request review reports.
@@ -30,6 +31,30 @@ type Options struct {
 	RequireNotOutdated   bool
 	TailReplies          int
 	IncludeCommentNodeID bool
+	IncludePullRequest   bool
+	IncludeConversation  bool
+	IncludeReactions     bool
+	HideMinimized        bool
+	// LastSeen, when non-nil, keeps only threads with comment activity after
+	// the recorded timestamp; threads missing from the map count as new.
+	LastSeen map[string]time.Time
+
+	includeStatus bool
+}
+
+type conversationConnection struct {
+	Nodes []struct {
+		ID        string `json:"id"`
+		Body      string `json:"body"`
+		CreatedAt string `json:"createdAt"`
+		Author    *struct {
+			Login string `json:"login"`
+		} `json:"author"`
+	} `json:"nodes"`
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
 }
 
 // NewService constructs a report service using the provided GraphQL API client.
@@ -47,6 +72,19 @@ func (s *Service) Fetch(pr resolver.Identity, opts Options) (Report, error) {
 		"firstThreads":  defaultFirstThreads,
 		"firstComments": defaultFirstComments,
 	}
+	if opts.IncludePullRequest {
+		variables["includePullRequest"] = true
+	}
+	if opts.IncludeConversation {
+		variables["includeConversation"] = true
+		variables["firstConversation"] = defaultFirstConversation
+	}
+	if opts.IncludeReactions {
+		variables["includeReactions"] = true
+	}
+	if opts.includeStatus {
+		variables["includeStatus"] = true
+	}
 	if opts.StatesProvided {
 		states := make([]string, len(opts.States))
 		for i, st := range opts.States {
@@ -58,47 +96,43 @@ func (s *Service) Fetch(pr resolver.Identity, opts Options) (Report, error) {
 	var response struct {
 		Repository *struct {
 			PullRequest *struct {
-				Reviews struct {
+				Number      int    `json:"number"`
+				Title       string `json:"title"`
+				Body        string `json:"body"`
+				URL         string `json:"url"`
+				State       string `json:"state"`
+				IsDraft     bool   `json:"isDraft"`
+				BaseRefName string `json:"baseRefName"`
+				HeadRefName string `json:"headRefName"`
+				HeadRefOID  string `json:"headRefOid"`
+				Mergeable   string `json:"mergeable"`
+				Author      *struct {
+					Login string `json:"login"`
+				} `json:"author"`
+				Labels *struct {
 					Nodes []struct {
-						ID          string  `json:"id"`
-						State       string  `json:"state"`
-						Body        *string `json:"body"`
-						SubmittedAt *string `json:"submittedAt"`
-						DatabaseID  *int    `json:"databaseId"`
-						Author      *struct {
-							Login string `json:"login"`
-						} `json:"author"`
+						Name string `json:"name"`
 					} `json:"nodes"`
-				} `json:"reviews"`
-				ReviewThreads struct {
+				} `json:"labels"`
+				ReviewDecision string `json:"reviewDecision"`
+				ReviewRequests *struct {
 					Nodes []struct {
-						ID         string `json:"id"`
-						Path       string `json:"path"`
-						Line       *int   `json:"line"`
-						IsResolved bool   `json:"isResolved"`
-						IsOutdated bool   `json:"isOutdated"`
-						Comments   struct {
-							Nodes []struct {
-								ID         string `json:"id"`
-								DatabaseID int    `json:"databaseId"`
-								Body       string `json:"body"`
-								CreatedAt  string `json:"createdAt"`
-								Author     *struct {
-									Login string `json:"login"`
-								} `json:"author"`
-								PullRequestReview *struct {
-									DatabaseID *int   `json:"databaseId"`
-									State      string `json:"state"`
-									ID         string `json:"id"`
-								} `json:"pullRequestReview"`
-								ReplyTo *struct {
-									ID         string `json:"id"`
-									DatabaseID int    `json:"databaseId"`
-								} `json:"replyTo"`
-							} `json:"nodes"`
-						} `json:"comments"`
+						RequestedReviewer *struct {
+							Typename     string `json:"__typename"`
+							Login        string `json:"login"`
+							Slug         string `json:"slug"`
+							Organization *struct {
+								Login string `json:"login"`
+							} `json:"organization"`
+						} `json:"requestedReviewer"`
 					} `json:"nodes"`
-				} `json:"reviewThreads"`
+					PageInfo struct {
+						HasNextPage bool `json:"hasNextPage"`
+					} `json:"pageInfo"`
+				} `json:"reviewRequests"`
+				Comments      *conversationConnection `json:"comments"`
+				Reviews       reviewConnection        `json:"reviews"`
+				ReviewThreads threadConnection        `json:"reviewThreads"`
 			} `json:"pullRequest"`
 		} `json:"repository"`
 	}
@@ -112,98 +146,222 @@ func (s *Service) Fetch(pr resolver.Identity, opts Options) (Report, error) {
 	}
 
 	prData := response.Repository.PullRequest
-	reviews := make([]Review, 0, len(prData.Reviews.Nodes))
+	// Reviews and threads are paged until exhausted so no caller works from a
+	// truncated list.
+	moreReviews, err := s.remainingReviews(pr, variables, prData.Reviews.PageInfo)
+	if err != nil {
+		return Report{}, err
+	}
+	reviewNodes := append(prData.Reviews.Nodes, moreReviews...)
+	moreThreads, err := s.remainingThreads(pr, variables, prData.ReviewThreads.PageInfo)
+	if err != nil {
+		return Report{}, err
+	}
+	threadNodes := append(prData.ReviewThreads.Nodes, moreThreads...)
 
-	for _, node := range prData.Reviews.Nodes {
-		if node.DatabaseID == nil {
-			return Report{}, errors.New("review missing databaseId")
+	reviews := make([]Review, 0, len(reviewNodes))
+	for _, node := range reviewNodes {
+		review, err := parseReview(node)
+		if err != nil {
+			return Report{}, err
 		}
-		if node.Author == nil || node.Author.Login == "" {
-			return Report{}, errors.New("review missing author login")
+		reviews = append(reviews, review)
+	}
+
+	threads := make([]Thread, 0, len(threadNodes))
+	for _, node := range threadNodes {
+		thread, err := parseThread(node)
+		if err != nil {
+			return Report{}, err
 		}
-		state, ok := parseState(node.State)
-		if !ok {
-			return Report{}, fmt.Errorf("unknown review state %q", node.State)
+		threads = append(threads, thread)
+	}
+
+	filters := FilterOptions{
+		Reviewer:             opts.Reviewer,
+		States:               opts.States,
+		RequireUnresolved:    opts.RequireUnresolved,
+		RequireNotOutdated:   opts.RequireNotOutdated,
+		TailReplies:          opts.TailReplies,
+		IncludeCommentNodeID: opts.IncludeCommentNodeID,
+		HideMinimized:        opts.HideMinimized,
+		LastSeen:             opts.LastSeen,
+	}
+
+	result := BuildReport(reviews, threads, filters)
+	result.models = Models{Reviews: reviews, Threads: threads}
+
+	if opts.IncludePullRequest {
+		pull := PullRequest{
+			Number:    prData.Number,
+			Title:     prData.Title,
+			Body:      prData.Body,
+			URL:       prData.URL,
+			State:     prData.State,
+			IsDraft:   prData.IsDraft,
+			BaseRef:   prData.BaseRefName,
+			HeadRef:   prData.HeadRefName,
+			HeadSHA:   prData.HeadRefOID,
+			Mergeable: prData.Mergeable,
 		}
-		review := Review{
-			ID:          node.ID,
-			State:       state,
-			Body:        node.Body,
-			AuthorLogin: node.Author.Login,
-			DatabaseID:  *node.DatabaseID,
+		if prData.Author != nil {
+			pull.AuthorLogin = prData.Author.Login
 		}
-		if node.SubmittedAt != nil && strings.TrimSpace(*node.SubmittedAt) != "" {
-			parsed, err := time.Parse(time.RFC3339, *node.SubmittedAt)
-			if err != nil {
-				return Report{}, fmt.Errorf("parse review submittedAt: %w", err)
+		if prData.Labels != nil {
+			for _, label := range prData.Labels.Nodes {
+				pull.Labels = append(pull.Labels, label.Name)
 			}
-			review.SubmittedAt = &parsed
 		}
-		reviews = append(reviews, review)
+		result.PullRequest = BuildPullRequest(pull)
 	}
 
-	threads := make([]Thread, 0, len(prData.ReviewThreads.Nodes))
-	for _, node := range prData.ReviewThreads.Nodes {
-		thread := Thread{
-			ID:         node.ID,
-			Path:       node.Path,
-			Line:       node.Line,
-			IsResolved: node.IsResolved,
-			IsOutdated: node.IsOutdated,
-			Comments:   make([]ThreadComment, 0, len(node.Comments.Nodes)),
+	if opts.includeStatus {
+		pull := StatusInput{
+			HeadSHA:        prData.HeadRefOID,
+			IsDraft:        prData.IsDraft,
+			ReviewDecision: prData.ReviewDecision,
 		}
-
-		for _, comment := range node.Comments.Nodes {
-			if comment.ID == "" {
-				return Report{}, errors.New("comment missing id")
+		if prData.Author != nil {
+			pull.AuthorLogin = prData.Author.Login
+		}
+		if prData.ReviewRequests != nil {
+			if prData.ReviewRequests.PageInfo.HasNextPage {
+				return Report{}, errors.New("pull request has more than 100 review requests; status cannot be computed")
 			}
-			if comment.Author == nil || comment.Author.Login == "" {
-				return Report{}, errors.New("comment missing author login")
+			for _, node := range prData.ReviewRequests.Nodes {
+				requested := node.RequestedReviewer
+				switch {
+				case requested == nil:
+				case requested.Typename == "Team":
+					org := ""
+					if requested.Organization != nil {
+						org = requested.Organization.Login
+					}
+					pull.RequestedTeams = append(pull.RequestedTeams, org+"/"+requested.Slug)
+				default:
+					pull.RequestedReviewers = append(pull.RequestedReviewers, requested.Login)
+				}
 			}
-			createdAt, err := time.Parse(time.RFC3339, comment.CreatedAt)
-			if err != nil {
-				return Report{}, fmt.Errorf("parse comment createdAt: %w", err)
+		}
+		status := BuildStatus(reviews, threads, pull)
+		result.status = &status
+	}
+
+	if opts.IncludeConversation {
+		conversation, err := s.collectConversation(pr, prData.Comments)
+		if err != nil {
+			return Report{}, err
+		}
+		shaped := BuildConversation(conversation)
+		result.Conversation = &shaped
+	}
+
+	return result, nil
+}
+
+// Models fetches the unfiltered reviews and threads of the pull request,
+// including the commit each review was submitted against.
+func (s *Service) Models(pr resolver.Identity) (Models, error) {
+	result, err := s.Fetch(pr, Options{includeStatus: true})
+	if err != nil {
+		return Models{}, err
+	}
+	return result.models, nil
+}
+
+// Conversation returns every top-level conversation comment on the pull
+// request, sorted by created_at ascending.
+func (s *Service) Conversation(pr resolver.Identity) ([]ReportConversation, error) {
+	variables := map[string]interface{}{
+		"owner":             pr.Owner,
+		"name":              pr.Repo,
+		"number":            pr.Number,
+		"firstConversation": defaultFirstConversation,
+	}
+	var response struct {
+		Repository *struct {
+			PullRequest *struct {
+				Comments *conversationConnection `json:"comments"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	if err := s.API.GraphQL(conversationQuery, variables, &response); err != nil {
+		return nil, err
+	}
+	if response.Repository == nil || response.Repository.PullRequest == nil {
+		return nil, errors.New("pull request not found or inaccessible")
+	}
+
+	conversation, err := s.collectConversation(pr, response.Repository.PullRequest.Comments)
+	if err != nil {
+		return nil, err
+	}
+	return BuildConversation(conversation), nil
+}
+
+// collectConversation converts the first page of conversation comments and
+// follows pagination until all comments have been retrieved.
+func (s *Service) collectConversation(pr resolver.Identity, first *conversationConnection) ([]ConversationComment, error) {
+	if first == nil {
+		return nil, errors.New("conversation comments missing from response")
+	}
+
+	comments := make([]ConversationComment, 0, len(first.Nodes))
+	page := first
+	for {
+		for _, node := range page.Nodes {
+			if node.ID == "" {
+				return nil, errors.New("conversation comment missing id")
 			}
-			var reviewDatabaseID *int
-			if comment.PullRequestReview != nil {
-				reviewDatabaseID = comment.PullRequestReview.DatabaseID
+			createdAt, err := time.Parse(time.RFC3339, node.CreatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("parse conversation createdAt: %w", err)
 			}
-			var replyTo *int
-			var replyToNode *string
-			if comment.ReplyTo != nil {
-				replyID := comment.ReplyTo.DatabaseID
-				replyTo = &replyID
-				if comment.ReplyTo.ID != "" {
-					replyNode := comment.ReplyTo.ID
-					replyToNode = &replyNode
-				}
+			// Deleted accounts surface as a null author; keep the comment with GitHub's ghost login.
+			login := "ghost"
+			if node.Author != nil && node.Author.Login != "" {
+				login = node.Author.Login
 			}
-
-			thread.Comments = append(thread.Comments, ThreadComment{
-				NodeID:             comment.ID,
-				DatabaseID:         comment.DatabaseID,
-				Body:               comment.Body,
-				CreatedAt:          createdAt,
-				AuthorLogin:        comment.Author.Login,
-				ReviewDatabaseID:   reviewDatabaseID,
-				ReplyToDatabaseID:  replyTo,
-				ReplyToCommentNode: replyToNode,
+			comments = append(comments, ConversationComment{
+				NodeID:      node.ID,
+				Body:        node.Body,
+				CreatedAt:   createdAt,
+				AuthorLogin: login,
 			})
 		}
 
-		threads = append(threads, thread)
-	}
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		cursor := strings.TrimSpace(page.PageInfo.EndCursor)
+		if cursor == "" {
+			return nil, errors.New("conversation pagination cursor missing")
+		}
 
-	filters := FilterOptions{
-		Reviewer:             opts.Reviewer,
-		States:               opts.States,
-		RequireUnresolved:    opts.RequireUnresolved,
-		RequireNotOutdated:   opts.RequireNotOutdated,
-		TailReplies:          opts.TailReplies,
-		IncludeCommentNodeID: opts.IncludeCommentNodeID,
+		variables := map[string]interface{}{
+			"owner":             pr.Owner,
+			"name":              pr.Repo,
+			"number":            pr.Number,
+			"firstConversation": defaultFirstConversation,
+			"after":             cursor,
+		}
+		var response struct {
+			Repository *struct {
+				PullRequest *struct {
+					Comments *conversationConnection `json:"comments"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		if err := s.API.GraphQL(conversationQuery, variables, &response); err != nil {
+			return nil, err
+		}
+		if response.Repository == nil || response.Repository.PullRequest == nil || response.Repository.PullRequest.Comments == nil {
+			return nil, errors.New("pull request not found or inaccessible")
+		}
+		page = response.Repository.PullRequest.Comments
 	}
 
-	return BuildReport(reviews, threads, filters), nil
+	return comments, nil
 }
 
 func parseState(raw string) (State, bool) {
@@ -216,6 +374,8 @@ func parseState(raw string) (State, bool) {
 		return StateCommented, true
 	case string(StateDismissed):
 		return StateDismissed, true
+	case string(StatePending):
+		return StatePending, true
 	default:
 		return "", false
 	}
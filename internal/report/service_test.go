@@ -124,11 +124,331 @@ func TestServiceFetchErrorsOnMissingReviewDBID(t *testing.T) {
 	}
 }
 
+func TestServiceFetchMapsDeletedAuthorsToGhost(t *testing.T) {
+	payload := map[string]any{}
+	if err := json.Unmarshal(reportResponseFixture, &payload); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+	pr := payload["repository"].(map[string]any)["pullRequest"].(map[string]any)
+	review := pr["reviews"].(map[string]any)["nodes"].([]any)[0].(map[string]any)
+	review["author"] = nil
+	thread := pr["reviewThreads"].(map[string]any)["nodes"].([]any)[0].(map[string]any)
+	comments := thread["comments"].(map[string]any)["nodes"].([]any)
+	comments[0].(map[string]any)["author"] = nil
+	modified, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal modified: %v", err)
+	}
+
+	models, err := NewService(&stubAPI{t: t, payload: modified}).Models(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51})
+	if err != nil {
+		t.Fatalf("fetch models: %v", err)
+	}
+	if models.Reviews[0].AuthorLogin != "ghost" {
+		t.Fatalf("expected ghost review author, got %q", models.Reviews[0].AuthorLogin)
+	}
+	if models.Threads[0].Comments[0].AuthorLogin != "ghost" {
+		t.Fatalf("expected ghost comment author, got %q", models.Threads[0].Comments[0].AuthorLogin)
+	}
+}
+
+func TestServiceFetchIncludesPullRequestAndConversation(t *testing.T) {
+	payload := map[string]any{}
+	if err := json.Unmarshal(reportResponseFixture, &payload); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+	pr := payload["repository"].(map[string]any)["pullRequest"].(map[string]any)
+	pr["number"] = 51
+	pr["title"] = "Add feature"
+	pr["body"] = "  Implements the feature.  "
+	pr["url"] = "https://github.com/agyn/sandbox/pull/51"
+	pr["state"] = "OPEN"
+	pr["isDraft"] = false
+	pr["author"] = map[string]any{"login": "carol"}
+	pr["baseRefName"] = "main"
+	pr["headRefName"] = "feature"
+	pr["headRefOid"] = "abc123"
+	pr["mergeable"] = "MERGEABLE"
+	pr["labels"] = map[string]any{"nodes": []any{map[string]any{"name": "enhancement"}}}
+	pr["comments"] = map[string]any{
+		"nodes": []any{
+			map[string]any{"id": "IC2", "body": "Second", "createdAt": "2025-12-03T11:00:00Z", "author": map[string]any{"login": "bob"}},
+		},
+		"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "CURSOR1"},
+	}
+	modified, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal modified: %v", err)
+	}
+
+	nextPage := map[string]any{
+		"repository": map[string]any{
+			"pullRequest": map[string]any{
+				"comments": map[string]any{
+					"nodes": []any{
+						map[string]any{"id": "IC1", "body": "First", "createdAt": "2025-12-03T09:00:00Z", "author": nil},
+					},
+					"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+				},
+			},
+		},
+	}
+	pageData, err := json.Marshal(nextPage)
+	if err != nil {
+		t.Fatalf("marshal page: %v", err)
+	}
+
+	fake := &stubAPI{t: t, payload: modified, conversationPages: [][]byte{pageData}}
+	svc := NewService(fake)
+
+	result, err := svc.Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{
+		IncludePullRequest:  true,
+		IncludeConversation: true,
+	})
+	if err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+
+	if fake.reportVariables["includePullRequest"] != true || fake.reportVariables["includeConversation"] != true {
+		t.Fatalf("expected include variables set, got %#v", fake.reportVariables)
+	}
+	if fake.lastVariables["after"] != "CURSOR1" {
+		t.Fatalf("expected conversation cursor propagated, got %#v", fake.lastVariables["after"])
+	}
+
+	if result.PullRequest == nil {
+		t.Fatal("expected pull_request section")
+	}
+	if result.PullRequest.Title != "Add feature" || result.PullRequest.HeadSHA != "abc123" || result.PullRequest.BaseRef != "main" {
+		t.Fatalf("unexpected pull request metadata: %#v", result.PullRequest)
+	}
+	if result.PullRequest.Body == nil || *result.PullRequest.Body != "Implements the feature." {
+		t.Fatalf("expected trimmed body, got %v", result.PullRequest.Body)
+	}
+	if len(result.PullRequest.Labels) != 1 || result.PullRequest.Labels[0] != "enhancement" {
+		t.Fatalf("unexpected labels: %v", result.PullRequest.Labels)
+	}
+
+	if result.Conversation == nil || len(*result.Conversation) != 2 {
+		t.Fatalf("expected 2 conversation comments, got %#v", result.Conversation)
+	}
+	conversation := *result.Conversation
+	if conversation[0].CommentNodeID != "IC1" || conversation[0].AuthorLogin != "ghost" {
+		t.Fatalf("expected oldest comment first with ghost author, got %#v", conversation[0])
+	}
+	if conversation[1].CommentNodeID != "IC2" {
+		t.Fatalf("expected IC2 second, got %#v", conversation[1])
+	}
+}
+
+func TestServiceFetchReadsEveryPage(t *testing.T) {
+	first := `{"repository":{"pullRequest":{
+		"reviews":{"nodes":[
+			{"id":"R1","state":"COMMENTED","body":"First","submittedAt":"2025-12-03T10:00:00Z","databaseId":1,"author":{"login":"alice"}}
+		],"pageInfo":{"hasNextPage":true,"endCursor":"r1"}},
+		"reviewThreads":{"nodes":[],"pageInfo":{"hasNextPage":true,"endCursor":"t1"}}
+	}}}`
+	reviews := `{"repository":{"pullRequest":{"reviews":{"nodes":[
+		{"id":"R2","state":"COMMENTED","body":"Second","submittedAt":"2025-12-03T11:00:00Z","databaseId":2,"author":{"login":"bob"}}
+	],"pageInfo":{"hasNextPage":false}}}}}`
+	threads := `{"repository":{"pullRequest":{"reviewThreads":{"nodes":[
+		{"id":"T1","path":"main.go","isResolved":false,"isOutdated":false,"comments":{"nodes":[
+			{"id":"C1","databaseId":10,"body":"nit","createdAt":"2025-12-03T11:00:00Z","author":{"login":"bob"},"pullRequestReview":{"id":"R2","state":"COMMENTED","databaseId":2}}
+		]}}
+	],"pageInfo":{"hasNextPage":false}}}}}`
+
+	fake := &stubAPI{t: t, payload: []byte(first), reviewPages: [][]byte{[]byte(reviews)}, threadPages: [][]byte{[]byte(threads)}}
+	result, err := NewService(fake).Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{})
+	if err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	if len(fake.reviewPages) != 0 || len(fake.threadPages) != 0 {
+		t.Fatalf("expected every page read, %d review and %d thread pages left", len(fake.reviewPages), len(fake.threadPages))
+	}
+	if len(result.Reviews) != 2 || result.Reviews[1].ID != "R2" || len(result.Reviews[1].Comments) != 1 {
+		t.Fatalf("later pages ignored: %#v", result.Reviews)
+	}
+}
+
+func TestServiceFetchEncodesEmptyRequestedConversation(t *testing.T) {
+	payload := map[string]any{}
+	if err := json.Unmarshal(reportResponseFixture, &payload); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+	pr := payload["repository"].(map[string]any)["pullRequest"].(map[string]any)
+	pr["comments"] = map[string]any{"nodes": []any{}, "pageInfo": map[string]any{"hasNextPage": false}}
+	modified, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal modified: %v", err)
+	}
+
+	svc := NewService(&stubAPI{t: t, payload: modified})
+	identity := resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}
+	for _, tc := range []struct {
+		include bool
+		want    string
+	}{
+		{include: true, want: `"conversation":[]`},
+		{include: false, want: ""},
+	} {
+		result, err := svc.Fetch(identity, Options{IncludeConversation: tc.include})
+		if err != nil {
+			t.Fatalf("fetch report: %v", err)
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			t.Fatalf("marshal report: %v", err)
+		}
+		if got := strings.Contains(string(data), `"conversation"`); got != (tc.want != "") {
+			t.Fatalf("include=%v: unexpected conversation key in %s", tc.include, data)
+		}
+		if tc.want != "" && !strings.Contains(string(data), tc.want) {
+			t.Fatalf("include=%v: expected %s in %s", tc.include, tc.want, data)
+		}
+	}
+}
+
+func TestServiceFetchOmitsOptionalSectionsByDefault(t *testing.T) {
+	fake := &stubAPI{t: t, payload: reportResponseFixture}
+	svc := NewService(fake)
+
+	result, err := svc.Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{})
+	if err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	if result.PullRequest != nil || result.Conversation != nil {
+		t.Fatalf("expected optional sections omitted, got %#v / %#v", result.PullRequest, result.Conversation)
+	}
+	if _, ok := fake.lastVariables["includeConversation"]; ok {
+		t.Fatalf("expected includeConversation unset, got %#v", fake.lastVariables)
+	}
+	if _, ok := fake.lastVariables["includeReactions"]; ok {
+		t.Fatalf("expected includeReactions unset, got %#v", fake.lastVariables)
+	}
+}
+
+func TestServiceFetchIncludesReactions(t *testing.T) {
+	payload := map[string]any{}
+	if err := json.Unmarshal(reportResponseFixture, &payload); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+	pr := payload["repository"].(map[string]any)["pullRequest"].(map[string]any)
+	thread := pr["reviewThreads"].(map[string]any)["nodes"].([]any)[0].(map[string]any)
+	comments := thread["comments"].(map[string]any)["nodes"].([]any)
+	comments[0].(map[string]any)["reactionGroups"] = []any{
+		map[string]any{"content": "THUMBS_UP", "viewerHasReacted": true, "users": map[string]any{"totalCount": 2}},
+		map[string]any{"content": "HEART", "viewerHasReacted": false, "users": map[string]any{"totalCount": 0}},
+	}
+	comments[1].(map[string]any)["reactionGroups"] = []any{
+		map[string]any{"content": "EYES", "viewerHasReacted": false, "users": map[string]any{"totalCount": 1}},
+	}
+	modified, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal modified: %v", err)
+	}
+
+	fake := &stubAPI{t: t, payload: modified}
+	result, err := NewService(fake).Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{IncludeReactions: true})
+	if err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	if fake.reportVariables["includeReactions"] != true {
+		t.Fatalf("expected includeReactions set, got %#v", fake.reportVariables)
+	}
+
+	parent := result.Reviews[0].Comments[0]
+	if len(parent.Reactions) != 1 || parent.Reactions[0] != (Reaction{Content: "THUMBS_UP", Count: 2, ViewerHasReacted: true}) {
+		t.Fatalf("unexpected parent reactions: %#v", parent.Reactions)
+	}
+	if len(parent.ThreadComments) == 0 || len(parent.ThreadComments[0].Reactions) != 1 || parent.ThreadComments[0].Reactions[0].Content != "EYES" {
+		t.Fatalf("unexpected reply reactions: %#v", parent.ThreadComments)
+	}
+
+	data, err := json.Marshal(parent)
+	if err != nil {
+		t.Fatalf("marshal comment: %v", err)
+	}
+	if !strings.Contains(string(data), `"reactions":[{"content":"THUMBS_UP","count":2,"viewer_has_reacted":true}]`) {
+		t.Fatalf("unexpected serialized reactions: %s", data)
+	}
+}
+
+func TestServiceFetchIncludesPendingReviewDrafts(t *testing.T) {
+	payload := map[string]any{}
+	if err := json.Unmarshal(reportResponseFixture, &payload); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+	pr := payload["repository"].(map[string]any)["pullRequest"].(map[string]any)
+	reviews := pr["reviews"].(map[string]any)
+	reviews["nodes"] = append(reviews["nodes"].([]any), map[string]any{
+		"id":          "R3",
+		"state":       "PENDING",
+		"body":        "",
+		"submittedAt": nil,
+		"databaseId":  303,
+		"author":      map[string]any{"login": "viewer"},
+	})
+	threads := pr["reviewThreads"].(map[string]any)
+	threads["nodes"] = append(threads["nodes"].([]any), map[string]any{
+		"id":         "T3",
+		"path":       "draft.go",
+		"line":       7,
+		"isResolved": false,
+		"isOutdated": false,
+		"comments": map[string]any{
+			"nodes": []any{
+				map[string]any{
+					"id":                "C501",
+					"databaseId":        501,
+					"body":              "Draft comment",
+					"createdAt":         "2025-12-03T12:00:00Z",
+					"author":            map[string]any{"login": "viewer"},
+					"pullRequestReview": map[string]any{"id": "R3", "state": "PENDING", "databaseId": 303},
+					"replyTo":           nil,
+				},
+			},
+		},
+	})
+	modified, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal modified: %v", err)
+	}
+
+	fake := &stubAPI{t: t, payload: modified}
+	svc := NewService(fake)
+
+	result, err := svc.Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{
+		States:         []State{StatePending},
+		StatesProvided: true,
+	})
+	if err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	if len(result.Reviews) != 1 {
+		t.Fatalf("expected only the pending review, got %d", len(result.Reviews))
+	}
+	review := result.Reviews[0]
+	if review.ID != "R3" || review.State != StatePending {
+		t.Fatalf("unexpected review: %#v", review)
+	}
+	if review.SubmittedAt != nil {
+		t.Fatalf("expected submitted_at omitted for pending review, got %v", *review.SubmittedAt)
+	}
+	if len(review.Comments) != 1 || review.Comments[0].ThreadID != "T3" {
+		t.Fatalf("expected draft thread T3, got %#v", review.Comments)
+	}
+}
+
 type stubAPI struct {
 	t             *testing.T
 	payload       []byte
 	lastQuery     string
 	lastVariables map[string]interface{}
+
+	reportVariables   map[string]interface{}
+	conversationPages [][]byte
+	reviewPages       [][]byte
+	threadPages       [][]byte
 }
 
 func (s *stubAPI) REST(string, string, map[string]string, interface{}, interface{}) error {
@@ -139,8 +459,33 @@ func (s *stubAPI) REST(string, string, map[string]string, interface{}, interface
 func (s *stubAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
 	s.lastQuery = query
 	s.lastVariables = variables
-	if query != reportQuery {
+	switch query {
+	case reportQuery:
+		s.reportVariables = variables
+		return json.Unmarshal(s.payload, result)
+	case conversationQuery:
+		if len(s.conversationPages) == 0 {
+			s.t.Fatalf("unexpected conversation page request")
+		}
+		page := s.conversationPages[0]
+		s.conversationPages = s.conversationPages[1:]
+		return json.Unmarshal(page, result)
+	case reviewsPageQuery:
+		if len(s.reviewPages) == 0 {
+			s.t.Fatalf("unexpected reviews page request")
+		}
+		page := s.reviewPages[0]
+		s.reviewPages = s.reviewPages[1:]
+		return json.Unmarshal(page, result)
+	case threadsPageQuery:
+		if len(s.threadPages) == 0 {
+			s.t.Fatalf("unexpected review threads page request")
+		}
+		page := s.threadPages[0]
+		s.threadPages = s.threadPages[1:]
+		return json.Unmarshal(page, result)
+	default:
 		s.t.Fatalf("unexpected query: %s", query)
+		return nil
 	}
-	return json.Unmarshal(s.payload, result)
 }
@@ -0,0 +1,195 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+// StatusInput carries the pull request fields BuildStatus needs beyond the
+// reviews and threads.
+type StatusInput struct {
+	HeadSHA            string
+	IsDraft            bool
+	AuthorLogin        string
+	ReviewDecision     string
+	RequestedReviewers []string
+	RequestedTeams     []string
+}
+
+// Status summarizes whether a pull request is ready to merge from a review
+// standpoint.
+type Status struct {
+	Ready bool `json:"ready"`
+	// Reasons lists why the pull request is not ready; empty when Ready.
+	Reasons []string `json:"reasons"`
+	// Decision is computed from the reviewer states below.
+	Decision string `json:"decision"`
+	// ReviewDecision is GitHub's own decision; empty when the base branch
+	// does not require reviews.
+	ReviewDecision     string           `json:"review_decision,omitempty"`
+	HeadSHA            string           `json:"head_sha"`
+	IsDraft            bool             `json:"is_draft"`
+	Reviewers          []ReviewerStatus `json:"reviewers"`
+	RequestedReviewers []string         `json:"requested_reviewers"`
+	RequestedTeams     []string         `json:"requested_teams"`
+	Threads            ThreadCounts     `json:"threads"`
+}
+
+// ReviewerStatus is the latest effective review state of one reviewer.
+type ReviewerStatus struct {
+	Login       string `json:"login"`
+	State       State  `json:"state"`
+	ReviewID    string `json:"review_id"`
+	SubmittedAt string `json:"submitted_at,omitempty"`
+	CommitSHA   string `json:"commit_sha,omitempty"`
+	// OnHead reports whether the review was submitted against the current
+	// head commit.
+	OnHead bool `json:"on_head"`
+}
+
+// ThreadCounts counts the review threads on the pull request.
+type ThreadCounts struct {
+	Total      int `json:"total"`
+	Unresolved int `json:"unresolved"`
+	// UnresolvedCurrent counts unresolved threads that are not outdated; these
+	// block readiness.
+	UnresolvedCurrent int `json:"unresolved_current"`
+}
+
+// Decisions computed by BuildStatus, matching GitHub's reviewDecision values.
+const (
+	DecisionApproved         = "APPROVED"
+	DecisionChangesRequested = "CHANGES_REQUESTED"
+	DecisionReviewRequired   = "REVIEW_REQUIRED"
+)
+
+// Status fetches the pull request and computes its review status.
+func (s *Service) Status(pr resolver.Identity) (Status, error) {
+	result, err := s.Fetch(pr, Options{includeStatus: true})
+	if err != nil {
+		return Status{}, err
+	}
+	if result.status == nil {
+		return Status{}, errors.New("status missing from report")
+	}
+	return *result.status, nil
+}
+
+// BuildStatus computes the review status. Each reviewer's state is their
+// latest submitted review, ignoring dismissed reviews and COMMENTED reviews
+// that follow an APPROVED or CHANGES_REQUESTED one. The pull request is ready
+// when it is not a draft, has at least one approval and no requested changes,
+// has no outstanding review requests or unresolved current threads, and
+// GitHub's reviewDecision, when set, is APPROVED.
+func BuildStatus(reviews []Review, threads []Thread, pull StatusInput) Status {
+	submitted := make([]Review, 0, len(reviews))
+	for _, review := range reviews {
+		if review.SubmittedAt == nil || review.State == StatePending || review.State == StateDismissed {
+			continue
+		}
+		if strings.EqualFold(review.AuthorLogin, pull.AuthorLogin) {
+			continue
+		}
+		submitted = append(submitted, review)
+	}
+	sort.SliceStable(submitted, func(i, j int) bool {
+		return submitted[i].SubmittedAt.Before(*submitted[j].SubmittedAt)
+	})
+
+	latest := make(map[string]Review)
+	order := make([]string, 0)
+	for _, review := range submitted {
+		key := strings.ToLower(review.AuthorLogin)
+		previous, seen := latest[key]
+		if !seen {
+			order = append(order, key)
+		}
+		if seen && review.State == StateCommented && (previous.State == StateApproved || previous.State == StateChangesRequested) {
+			continue
+		}
+		latest[key] = review
+	}
+
+	status := Status{
+		Reasons:            make([]string, 0),
+		ReviewDecision:     pull.ReviewDecision,
+		HeadSHA:            pull.HeadSHA,
+		IsDraft:            pull.IsDraft,
+		Reviewers:          make([]ReviewerStatus, 0, len(order)),
+		RequestedReviewers: nonNil(pull.RequestedReviewers),
+		RequestedTeams:     nonNil(pull.RequestedTeams),
+	}
+
+	approvals, changes := 0, 0
+	for _, key := range order {
+		review := latest[key]
+		reviewer := ReviewerStatus{
+			Login:     review.AuthorLogin,
+			State:     review.State,
+			ReviewID:  review.ID,
+			CommitSHA: review.CommitOID,
+			OnHead:    review.CommitOID != "" && review.CommitOID == pull.HeadSHA,
+		}
+		reviewer.SubmittedAt = review.SubmittedAt.UTC().Format(time.RFC3339)
+		status.Reviewers = append(status.Reviewers, reviewer)
+		switch review.State {
+		case StateApproved:
+			approvals++
+		case StateChangesRequested:
+			changes++
+		}
+	}
+
+	for _, thread := range threads {
+		status.Threads.Total++
+		if thread.IsResolved {
+			continue
+		}
+		status.Threads.Unresolved++
+		if !thread.IsOutdated {
+			status.Threads.UnresolvedCurrent++
+		}
+	}
+
+	switch {
+	case changes > 0:
+		status.Decision = DecisionChangesRequested
+	case approvals > 0:
+		status.Decision = DecisionApproved
+	default:
+		status.Decision = DecisionReviewRequired
+	}
+
+	if pull.IsDraft {
+		status.Reasons = append(status.Reasons, "pull request is a draft")
+	}
+	switch status.Decision {
+	case DecisionChangesRequested:
+		status.Reasons = append(status.Reasons, fmt.Sprintf("%d reviewer(s) requested changes", changes))
+	case DecisionReviewRequired:
+		status.Reasons = append(status.Reasons, "no approving review")
+	}
+	if pull.ReviewDecision != "" && pull.ReviewDecision != DecisionApproved {
+		status.Reasons = append(status.Reasons, "GitHub review decision is "+pull.ReviewDecision)
+	}
+	if pending := len(status.RequestedReviewers) + len(status.RequestedTeams); pending > 0 {
+		status.Reasons = append(status.Reasons, fmt.Sprintf("%d review request(s) outstanding", pending))
+	}
+	if status.Threads.UnresolvedCurrent > 0 {
+		status.Reasons = append(status.Reasons, fmt.Sprintf("%d unresolved thread(s) that are not outdated", status.Threads.UnresolvedCurrent))
+	}
+	status.Ready = len(status.Reasons) == 0
+	return status
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
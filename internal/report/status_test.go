@@ -0,0 +1,149 @@
+package report
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+func TestBuildStatusLatestStatePerReviewer(t *testing.T) {
+	at := func(minute int) *time.Time {
+		ts := time.Date(2025, 12, 3, 10, minute, 0, 0, time.UTC)
+		return &ts
+	}
+	reviews := []Review{
+		{ID: "R1", State: StateApproved, AuthorLogin: "alice", SubmittedAt: at(1), CommitOID: "old"},
+		{ID: "R2", State: StateCommented, AuthorLogin: "alice", SubmittedAt: at(2), CommitOID: "head"},
+		{ID: "R3", State: StateChangesRequested, AuthorLogin: "bob", SubmittedAt: at(3), CommitOID: "head"},
+		{ID: "R4", State: StateDismissed, AuthorLogin: "bob", SubmittedAt: at(4), CommitOID: "head"},
+		{ID: "R5", State: StateApproved, AuthorLogin: "carol", SubmittedAt: at(0), CommitOID: "head"},
+		{ID: "R6", State: StateCommented, AuthorLogin: "octocat", SubmittedAt: at(5), CommitOID: "head"},
+		{ID: "R7", State: StatePending, AuthorLogin: "dave"},
+	}
+	threads := []Thread{
+		{ID: "T1"},
+		{ID: "T2", IsOutdated: true},
+		{ID: "T3", IsResolved: true},
+	}
+
+	status := BuildStatus(reviews, threads, StatusInput{
+		HeadSHA:        "head",
+		AuthorLogin:    "octocat",
+		ReviewDecision: "CHANGES_REQUESTED",
+		RequestedTeams: []string{"octo/core"},
+	})
+
+	want := []ReviewerStatus{
+		{Login: "carol", State: StateApproved, ReviewID: "R5", SubmittedAt: "2025-12-03T10:00:00Z", CommitSHA: "head", OnHead: true},
+		{Login: "alice", State: StateApproved, ReviewID: "R1", SubmittedAt: "2025-12-03T10:01:00Z", CommitSHA: "old"},
+		{Login: "bob", State: StateChangesRequested, ReviewID: "R3", SubmittedAt: "2025-12-03T10:03:00Z", CommitSHA: "head", OnHead: true},
+	}
+	if !reflect.DeepEqual(status.Reviewers, want) {
+		t.Fatalf("unexpected reviewers:\n%#v", status.Reviewers)
+	}
+	if status.Decision != DecisionChangesRequested {
+		t.Fatalf("expected CHANGES_REQUESTED, got %s", status.Decision)
+	}
+	if status.Threads != (ThreadCounts{Total: 3, Unresolved: 2, UnresolvedCurrent: 1}) {
+		t.Fatalf("unexpected thread counts: %#v", status.Threads)
+	}
+	wantReasons := []string{
+		"1 reviewer(s) requested changes",
+		"GitHub review decision is CHANGES_REQUESTED",
+		"1 review request(s) outstanding",
+		"1 unresolved thread(s) that are not outdated",
+	}
+	if status.Ready || !reflect.DeepEqual(status.Reasons, wantReasons) {
+		t.Fatalf("unexpected readiness %v: %#v", status.Ready, status.Reasons)
+	}
+
+	ready := BuildStatus(reviews[:2], threads[1:], StatusInput{HeadSHA: "head"})
+	if !ready.Ready || ready.Decision != DecisionApproved || len(ready.Reasons) != 0 {
+		t.Fatalf("expected ready status, got %#v", ready)
+	}
+	if ready.RequestedReviewers == nil || ready.RequestedTeams == nil {
+		t.Fatal("expected empty request lists, not nil")
+	}
+}
+
+func TestServiceStatus(t *testing.T) {
+	payload := map[string]any{}
+	if err := json.Unmarshal(reportResponseFixture, &payload); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+	pr := payload["repository"].(map[string]any)["pullRequest"].(map[string]any)
+	pr["headRefOid"] = "abc123"
+	pr["reviewDecision"] = "APPROVED"
+	pr["author"] = map[string]any{"login": "octocat"}
+	pr["reviewRequests"] = map[string]any{"nodes": []any{
+		map[string]any{"requestedReviewer": map[string]any{"__typename": "User", "login": "bob"}},
+		map[string]any{"requestedReviewer": map[string]any{"__typename": "Team", "slug": "core", "organization": map[string]any{"login": "agyn"}}},
+	}}
+	review := pr["reviews"].(map[string]any)["nodes"].([]any)[0].(map[string]any)
+	review["commit"] = map[string]any{"oid": "abc123"}
+	modified, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal modified: %v", err)
+	}
+
+	fake := &stubAPI{t: t, payload: modified}
+	status, err := NewService(fake).Status(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51})
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if fake.reportVariables["includeStatus"] != true {
+		t.Fatalf("expected includeStatus set, got %#v", fake.reportVariables)
+	}
+	if len(status.Reviewers) != 1 || !status.Reviewers[0].OnHead || status.Reviewers[0].Login != "alice" {
+		t.Fatalf("unexpected reviewers: %#v", status.Reviewers)
+	}
+	if !reflect.DeepEqual(status.RequestedReviewers, []string{"bob"}) || !reflect.DeepEqual(status.RequestedTeams, []string{"agyn/core"}) {
+		t.Fatalf("unexpected requests: %#v %#v", status.RequestedReviewers, status.RequestedTeams)
+	}
+	if status.ReviewDecision != "APPROVED" || status.HeadSHA != "abc123" || status.Ready {
+		t.Fatalf("unexpected status: %#v", status)
+	}
+}
+
+func TestServiceStatusReadsEveryPage(t *testing.T) {
+	first := `{"repository":{"pullRequest":{
+		"headRefOid":"head","author":{"login":"octocat"},"reviewRequests":{"nodes":[]},
+		"reviews":{"nodes":[
+			{"id":"R1","state":"APPROVED","submittedAt":"2025-12-03T10:00:00Z","databaseId":1,"author":{"login":"alice"},"commit":{"oid":"head"}}
+		],"pageInfo":{"hasNextPage":true,"endCursor":"r1"}},
+		"reviewThreads":{"nodes":[],"pageInfo":{"hasNextPage":true,"endCursor":"t1"}}
+	}}}`
+	reviews := `{"repository":{"pullRequest":{"reviews":{"nodes":[
+		{"id":"R2","state":"CHANGES_REQUESTED","submittedAt":"2025-12-03T11:00:00Z","databaseId":2,"author":{"login":"bob"},"commit":{"oid":"head"}}
+	],"pageInfo":{"hasNextPage":false}}}}}`
+	threads := `{"repository":{"pullRequest":{"reviewThreads":{"nodes":[
+		{"id":"T1","path":"main.go","isResolved":false,"isOutdated":false,"comments":{"nodes":[]}}
+	],"pageInfo":{"hasNextPage":false}}}}}`
+
+	fake := &stubAPI{t: t, payload: []byte(first), reviewPages: [][]byte{[]byte(reviews)}, threadPages: [][]byte{[]byte(threads)}}
+	status, err := NewService(fake).Status(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51})
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if fake.lastVariables["after"] != "t1" {
+		t.Fatalf("expected the threads cursor, got %#v", fake.lastVariables)
+	}
+	if status.Ready || status.Decision != DecisionChangesRequested || status.Threads.UnresolvedCurrent != 1 {
+		t.Fatalf("later pages ignored: %#v", status)
+	}
+}
+
+func TestServiceStatusRejectsTruncatedReviewRequests(t *testing.T) {
+	payload := `{"repository":{"pullRequest":{
+		"headRefOid":"head","author":{"login":"octocat"},
+		"reviewRequests":{"nodes":[],"pageInfo":{"hasNextPage":true}},
+		"reviews":{"nodes":[]},"reviewThreads":{"nodes":[]}
+	}}}`
+	_, err := NewService(&stubAPI{t: t, payload: []byte(payload)}).Status(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51})
+	if err == nil {
+		t.Fatal("expected an error for truncated review requests")
+	}
+}
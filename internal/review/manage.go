@@ -0,0 +1,407 @@
+package review
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+const dismissTargetQuery = `query DismissTarget($id: ID!) {
+  node(id: $id) {
+    ... on PullRequestReview {
+      id
+      state
+      author { login }
+      pullRequest {
+        number
+        repository {
+          name
+          owner { login }
+          viewerPermission
+        }
+      }
+    }
+  }
+}`
+
+const dismissReviewMutation = `mutation DismissPullRequestReview($input: DismissPullRequestReviewInput!) {
+  dismissPullRequestReview(input: $input) {
+    pullRequestReview {
+      id
+      state
+      author { login }
+    }
+  }
+}`
+
+const requestTargetQuery = `query ReviewRequestTarget($owner: String!, $name: String!, $number: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    viewerPermission
+    pullRequest(number: $number) {
+      id
+      author { login }
+      reviews(first: 100, after: $after, states: [APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED]) {
+        nodes { author { __typename login } }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}`
+
+// requestTarget is the response of requestTargetQuery.
+type requestTarget struct {
+	Repository *struct {
+		ViewerPermission string `json:"viewerPermission"`
+		PullRequest      *struct {
+			ID     string `json:"id"`
+			Author *struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			Reviews struct {
+				Nodes []struct {
+					Author *struct {
+						Typename string `json:"__typename"`
+						Login    string `json:"login"`
+					} `json:"author"`
+				} `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"reviews"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+const reviewerUserQuery = `query ReviewerUser($login: String!) {
+  user(login: $login) { id }
+}`
+
+const reviewerTeamQuery = `query ReviewerTeam($org: String!, $slug: String!) {
+  organization(login: $org) {
+    team(slug: $slug) { id }
+  }
+}`
+
+const requestReviewsMutation = `mutation RequestReviews($input: RequestReviewsInput!) {
+  requestReviews(input: $input) {
+    pullRequest {
+      reviewRequests(first: 100) {
+        nodes {
+          requestedReviewer {
+            __typename
+            ... on User { login }
+            ... on Team { slug organization { login } }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// DismissInput identifies a submitted review and the reason for dismissing it.
+type DismissInput struct {
+	ReviewID string
+	Message  string
+}
+
+// Dismissal is the state of a review after dismissing it.
+type Dismissal struct {
+	ID          string `json:"id"`
+	State       string `json:"state"`
+	AuthorLogin string `json:"author_login,omitempty"`
+	Message     string `json:"message"`
+}
+
+// RequestInput lists the reviewers to request. Teams are written org/slug.
+type RequestInput struct {
+	Reviewers []string
+	// ReRequest adds everyone who already reviewed the pull request, except
+	// its author and bots.
+	ReRequest bool
+}
+
+// ReviewRequests is the pull request's pending review requests after a
+// request.
+type ReviewRequests struct {
+	Requested      []string         `json:"requested"`
+	ReviewRequests []RequestedState `json:"review_requests"`
+}
+
+// RequestedState is one pending review request.
+type RequestedState struct {
+	Reviewer string `json:"reviewer"`
+	Type     string `json:"type"`
+}
+
+// Dismiss dismisses an approving or change-requesting review on the pull
+// request. The viewer needs write access to the repository.
+func (s *Service) Dismiss(pr resolver.Identity, input DismissInput) (*Dismissal, error) {
+	id := strings.TrimSpace(input.ReviewID)
+	if id == "" {
+		return nil, errors.New("review id is required")
+	}
+	if strings.TrimSpace(input.Message) == "" {
+		return nil, errors.New("dismissal message is required")
+	}
+	message, err := s.Secrets.Check(input.Message)
+	if err != nil {
+		return nil, err
+	}
+	if err := ghcli.CheckBodyLength(message); err != nil {
+		return nil, err
+	}
+
+	var target struct {
+		Node *struct {
+			ID     string `json:"id"`
+			State  string `json:"state"`
+			Author *struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			PullRequest *struct {
+				Number     int `json:"number"`
+				Repository struct {
+					Name  string `json:"name"`
+					Owner struct {
+						Login string `json:"login"`
+					} `json:"owner"`
+					ViewerPermission string `json:"viewerPermission"`
+				} `json:"repository"`
+			} `json:"pullRequest"`
+		} `json:"node"`
+	}
+	if err := s.API.GraphQL(dismissTargetQuery, map[string]interface{}{"id": id}, &target); err != nil {
+		return nil, err
+	}
+	review := target.Node
+	if review == nil || review.ID == "" || review.PullRequest == nil {
+		return nil, fmt.Errorf("review %s not found", id)
+	}
+	repo := review.PullRequest.Repository
+	if !strings.EqualFold(repo.Owner.Login, pr.Owner) || !strings.EqualFold(repo.Name, pr.Repo) || review.PullRequest.Number != pr.Number {
+		return nil, fmt.Errorf("review %s belongs to %s/%s#%d, not %s/%s#%d", id, repo.Owner.Login, repo.Name, review.PullRequest.Number, pr.Owner, pr.Repo, pr.Number)
+	}
+	if err := requirePermission(repo.ViewerPermission, "WRITE", "dismissing reviews", pr); err != nil {
+		return nil, err
+	}
+	if review.State != "APPROVED" && review.State != "CHANGES_REQUESTED" {
+		return nil, fmt.Errorf("review %s is %s; only APPROVED or CHANGES_REQUESTED reviews can be dismissed", id, review.State)
+	}
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{"pullRequestReviewId": id, "message": message},
+	}
+	var resp struct {
+		DismissPullRequestReview struct {
+			PullRequestReview *struct {
+				ID     string `json:"id"`
+				State  string `json:"state"`
+				Author *struct {
+					Login string `json:"login"`
+				} `json:"author"`
+			} `json:"pullRequestReview"`
+		} `json:"dismissPullRequestReview"`
+	}
+	if err := s.API.GraphQL(dismissReviewMutation, variables, &resp); err != nil {
+		return nil, err
+	}
+	dismissed := resp.DismissPullRequestReview.PullRequestReview
+	if dismissed == nil || strings.TrimSpace(dismissed.ID) == "" {
+		return nil, errors.New("dismissPullRequestReview returned no review")
+	}
+
+	result := &Dismissal{ID: dismissed.ID, State: dismissed.State, Message: message}
+	if dismissed.Author != nil {
+		result.AuthorLogin = dismissed.Author.Login
+	}
+	return result, nil
+}
+
+// RequestReviews requests reviews from users and teams, keeping existing
+// requests. Requesting someone who already reviewed asks them to review
+// again. The viewer needs triage access to the repository.
+func (s *Service) RequestReviews(pr resolver.Identity, input RequestInput) (*ReviewRequests, error) {
+	var target requestTarget
+	variables := map[string]interface{}{"owner": pr.Owner, "name": pr.Repo, "number": pr.Number}
+	if err := s.API.GraphQL(requestTargetQuery, variables, &target); err != nil {
+		return nil, err
+	}
+	if target.Repository == nil || target.Repository.PullRequest == nil || target.Repository.PullRequest.ID == "" {
+		return nil, fmt.Errorf("pull request %s/%s#%d not found", pr.Owner, pr.Repo, pr.Number)
+	}
+	if err := requirePermission(target.Repository.ViewerPermission, "TRIAGE", "requesting reviews", pr); err != nil {
+		return nil, err
+	}
+	pull := target.Repository.PullRequest
+
+	reviewers := make([]string, 0, len(input.Reviewers))
+	seen := make(map[string]bool)
+	add := func(reviewer string) {
+		reviewer = strings.TrimPrefix(strings.TrimSpace(reviewer), "@")
+		if reviewer != "" && !seen[strings.ToLower(reviewer)] {
+			seen[strings.ToLower(reviewer)] = true
+			reviewers = append(reviewers, reviewer)
+		}
+	}
+	for _, reviewer := range input.Reviewers {
+		add(reviewer)
+	}
+	if input.ReRequest {
+		author := ""
+		if pull.Author != nil {
+			author = pull.Author.Login
+		}
+		reviews := pull.Reviews
+		for {
+			for _, review := range reviews.Nodes {
+				// Only users can be requested; bots and mannequins are skipped.
+				if review.Author == nil || review.Author.Typename != "User" || strings.EqualFold(review.Author.Login, author) {
+					continue
+				}
+				add(review.Author.Login)
+			}
+			if !reviews.PageInfo.HasNextPage || reviews.PageInfo.EndCursor == "" {
+				break
+			}
+			variables["after"] = reviews.PageInfo.EndCursor
+			var page requestTarget
+			if err := s.API.GraphQL(requestTargetQuery, variables, &page); err != nil {
+				return nil, err
+			}
+			if page.Repository == nil || page.Repository.PullRequest == nil {
+				return nil, fmt.Errorf("pull request %s/%s#%d not found", pr.Owner, pr.Repo, pr.Number)
+			}
+			reviews = page.Repository.PullRequest.Reviews
+		}
+	}
+	if len(reviewers) == 0 {
+		if input.ReRequest {
+			return nil, errors.New("no previous reviewers to re-request; pass --reviewer")
+		}
+		return nil, errors.New("at least one reviewer is required")
+	}
+
+	userIDs := make([]string, 0, len(reviewers))
+	teamIDs := make([]string, 0)
+	for _, reviewer := range reviewers {
+		if org, slug, ok := strings.Cut(reviewer, "/"); ok {
+			id, err := s.teamID(org, slug)
+			if err != nil {
+				return nil, err
+			}
+			teamIDs = append(teamIDs, id)
+			continue
+		}
+		id, err := s.userID(reviewer)
+		if err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	mutationInput := map[string]interface{}{"pullRequestId": pull.ID, "union": true}
+	if len(userIDs) > 0 {
+		mutationInput["userIds"] = userIDs
+	}
+	if len(teamIDs) > 0 {
+		mutationInput["teamIds"] = teamIDs
+	}
+	var resp struct {
+		RequestReviews struct {
+			PullRequest *struct {
+				ReviewRequests struct {
+					Nodes []struct {
+						RequestedReviewer *struct {
+							Typename     string `json:"__typename"`
+							Login        string `json:"login"`
+							Slug         string `json:"slug"`
+							Organization *struct {
+								Login string `json:"login"`
+							} `json:"organization"`
+						} `json:"requestedReviewer"`
+					} `json:"nodes"`
+				} `json:"reviewRequests"`
+			} `json:"pullRequest"`
+		} `json:"requestReviews"`
+	}
+	if err := s.API.GraphQL(requestReviewsMutation, map[string]interface{}{"input": mutationInput}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.RequestReviews.PullRequest == nil {
+		return nil, errors.New("requestReviews returned no pull request")
+	}
+
+	result := &ReviewRequests{Requested: reviewers, ReviewRequests: make([]RequestedState, 0)}
+	for _, node := range resp.RequestReviews.PullRequest.ReviewRequests.Nodes {
+		requested := node.RequestedReviewer
+		if requested == nil {
+			continue
+		}
+		if requested.Typename == "Team" {
+			org := ""
+			if requested.Organization != nil {
+				org = requested.Organization.Login
+			}
+			result.ReviewRequests = append(result.ReviewRequests, RequestedState{Reviewer: org + "/" + requested.Slug, Type: "team"})
+			continue
+		}
+		result.ReviewRequests = append(result.ReviewRequests, RequestedState{Reviewer: requested.Login, Type: "user"})
+	}
+	sort.SliceStable(result.ReviewRequests, func(i, j int) bool {
+		return result.ReviewRequests[i].Reviewer < result.ReviewRequests[j].Reviewer
+	})
+	return result, nil
+}
+
+func (s *Service) userID(login string) (string, error) {
+	var resp struct {
+		User *struct {
+			ID string `json:"id"`
+		} `json:"user"`
+	}
+	if err := s.API.GraphQL(reviewerUserQuery, map[string]interface{}{"login": login}, &resp); err != nil {
+		return "", fmt.Errorf("look up reviewer %s: %w", login, err)
+	}
+	if resp.User == nil || resp.User.ID == "" {
+		return "", fmt.Errorf("reviewer %s not found", login)
+	}
+	return resp.User.ID, nil
+}
+
+func (s *Service) teamID(org, slug string) (string, error) {
+	var resp struct {
+		Organization *struct {
+			Team *struct {
+				ID string `json:"id"`
+			} `json:"team"`
+		} `json:"organization"`
+	}
+	if err := s.API.GraphQL(reviewerTeamQuery, map[string]interface{}{"org": org, "slug": slug}, &resp); err != nil {
+		return "", fmt.Errorf("look up team %s/%s: %w", org, slug, err)
+	}
+	if resp.Organization == nil || resp.Organization.Team == nil || resp.Organization.Team.ID == "" {
+		return "", fmt.Errorf("team %s/%s not found", org, slug)
+	}
+	return resp.Organization.Team.ID, nil
+}
+
+// permissionRank orders GitHub RepositoryPermission values.
+var permissionRank = map[string]int{"READ": 1, "TRIAGE": 2, "WRITE": 3, "MAINTAIN": 4, "ADMIN": 5}
+
+func requirePermission(have, need, action string, pr resolver.Identity) error {
+	if permissionRank[strings.ToUpper(have)] >= permissionRank[need] {
+		return nil
+	}
+	if have == "" {
+		have = "no access"
+	}
+	return fmt.Errorf("%s requires %s access to %s/%s (you have %s)", action, need, pr.Owner, pr.Repo, have)
+}
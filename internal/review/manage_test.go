@@ -0,0 +1,131 @@
+package review
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDismissChecksTargetAndPermission(t *testing.T) {
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}
+	state, permission, number := "APPROVED", "WRITE", 7
+	var dismissed []string
+
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		if strings.Contains(query, "DismissTarget") {
+			return assign(result, map[string]interface{}{"node": map[string]interface{}{
+				"id": "PRR_1", "state": state, "author": map[string]interface{}{"login": "alice"},
+				"pullRequest": map[string]interface{}{"number": number, "repository": map[string]interface{}{
+					"name": "demo", "owner": map[string]interface{}{"login": "Octo"}, "viewerPermission": permission,
+				}},
+			}})
+		}
+		input := variables["input"].(map[string]interface{})
+		dismissed = append(dismissed, input["message"].(string))
+		return assign(result, map[string]interface{}{"dismissPullRequestReview": map[string]interface{}{"pullRequestReview": map[string]interface{}{
+			"id": "PRR_1", "state": "DISMISSED", "author": map[string]interface{}{"login": "alice"},
+		}}})
+	}
+	svc := NewService(api)
+
+	result, err := svc.Dismiss(pr, DismissInput{ReviewID: "PRR_1", Message: "Stale after force push"})
+	require.NoError(t, err)
+	assert.Equal(t, &Dismissal{ID: "PRR_1", State: "DISMISSED", AuthorLogin: "alice", Message: "Stale after force push"}, result)
+
+	permission = "TRIAGE"
+	_, err = svc.Dismiss(pr, DismissInput{ReviewID: "PRR_1", Message: "x"})
+	require.EqualError(t, err, "dismissing reviews requires WRITE access to octo/demo (you have TRIAGE)")
+
+	permission, state = "ADMIN", "COMMENTED"
+	_, err = svc.Dismiss(pr, DismissInput{ReviewID: "PRR_1", Message: "x"})
+	require.EqualError(t, err, "review PRR_1 is COMMENTED; only APPROVED or CHANGES_REQUESTED reviews can be dismissed")
+
+	state, number = "APPROVED", 8
+	_, err = svc.Dismiss(pr, DismissInput{ReviewID: "PRR_1", Message: "x"})
+	require.EqualError(t, err, "review PRR_1 belongs to Octo/demo#8, not octo/demo#7")
+
+	_, err = svc.Dismiss(pr, DismissInput{ReviewID: "PRR_1", Message: " "})
+	require.EqualError(t, err, "dismissal message is required")
+	assert.Len(t, dismissed, 1)
+}
+
+func TestRequestReviewsReRequestsPreviousReviewers(t *testing.T) {
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}
+	permission := "WRITE"
+	var input map[string]interface{}
+
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "ReviewRequestTarget"):
+			user := func(login string) map[string]interface{} {
+				return map[string]interface{}{"author": map[string]interface{}{"__typename": "User", "login": login}}
+			}
+			reviews := map[string]interface{}{
+				"nodes": []map[string]interface{}{
+					user("alice"),
+					user("octocat"),
+					{"author": map[string]interface{}{"__typename": "Bot", "login": "dependabot"}},
+					{"author": nil},
+				},
+				"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "r1"},
+			}
+			if variables["after"] == "r1" {
+				reviews = map[string]interface{}{
+					"nodes":    []map[string]interface{}{user("bob")},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				}
+			}
+			return assign(result, map[string]interface{}{"repository": map[string]interface{}{
+				"viewerPermission": permission,
+				"pullRequest": map[string]interface{}{
+					"id":      "PR_7",
+					"author":  map[string]interface{}{"login": "octocat"},
+					"reviews": reviews,
+				},
+			}})
+		case strings.Contains(query, "ReviewerUser"):
+			return assign(result, map[string]interface{}{"user": map[string]interface{}{"id": "U_" + variables["login"].(string)}})
+		case strings.Contains(query, "ReviewerTeam"):
+			assert.Equal(t, "octo", variables["org"])
+			assert.Equal(t, "core", variables["slug"])
+			return assign(result, map[string]interface{}{"organization": map[string]interface{}{"team": map[string]interface{}{"id": "T_core"}}})
+		default:
+			input = variables["input"].(map[string]interface{})
+			return assign(result, map[string]interface{}{"requestReviews": map[string]interface{}{"pullRequest": map[string]interface{}{
+				"reviewRequests": map[string]interface{}{"nodes": []map[string]interface{}{
+					{"requestedReviewer": map[string]interface{}{"__typename": "User", "login": "bob"}},
+					{"requestedReviewer": map[string]interface{}{"__typename": "Team", "slug": "core", "organization": map[string]interface{}{"login": "octo"}}},
+					{"requestedReviewer": map[string]interface{}{"__typename": "User", "login": "alice"}},
+				}},
+			}}})
+		}
+	}
+	svc := NewService(api)
+
+	result, err := svc.RequestReviews(pr, RequestInput{Reviewers: []string{"octo/core", "@Alice"}, ReRequest: true})
+	require.NoError(t, err)
+	assert.Equal(t, &ReviewRequests{
+		Requested: []string{"octo/core", "Alice", "bob"},
+		ReviewRequests: []RequestedState{
+			{Reviewer: "alice", Type: "user"},
+			{Reviewer: "bob", Type: "user"},
+			{Reviewer: "octo/core", Type: "team"},
+		},
+	}, result)
+	assert.Equal(t, "PR_7", input["pullRequestId"])
+	assert.Equal(t, true, input["union"])
+	assert.Equal(t, []string{"U_Alice", "U_bob"}, input["userIds"])
+	assert.Equal(t, []string{"T_core"}, input["teamIds"])
+
+	_, err = svc.RequestReviews(pr, RequestInput{})
+	require.EqualError(t, err, "at least one reviewer is required")
+
+	permission = "READ"
+	_, err = svc.RequestReviews(pr, RequestInput{Reviewers: []string{"alice"}})
+	require.EqualError(t, err, "requesting reviews requires TRIAGE access to octo/demo (you have READ)")
+}
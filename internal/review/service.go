@@ -7,11 +7,14 @@ import (
 
 	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
 	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/secrets"
 )
 
 // Service coordinates review GraphQL operations through the gh CLI.
 type Service struct {
 	API ghcli.API
+	// Secrets, when set, checks every body before it is posted.
+	Secrets *secrets.Scanner
 }
 
 // ErrViewerLoginUnavailable indicates the authenticated viewer login could not be resolved via GraphQL.
@@ -22,6 +25,14 @@ type ReviewState struct {
 	ID          string  `json:"id"`
 	State       string  `json:"state"`
 	SubmittedAt *string `json:"submitted_at,omitempty"`
+
+	viewer string
+}
+
+// Viewer returns the login that opened the review when Start could determine
+// it, or "" otherwise.
+func (r ReviewState) Viewer() string {
+	return r.viewer
 }
 
 // SubmitStatus represents the outcome of a review submission mutation.
@@ -63,7 +74,7 @@ func NewService(api ghcli.API) *Service {
 
 // Start opens a pending review for the specified pull request.
 func (s *Service) Start(pr resolver.Identity, commitOID string) (*ReviewState, error) {
-	nodeID, headSHA, err := s.pullRequestIdentifiers(pr)
+	nodeID, headSHA, viewer, err := s.pullRequestIdentifiers(pr)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +120,7 @@ func (s *Service) Start(pr resolver.Identity, commitOID string) (*ReviewState, e
 	if trimmedState == "" {
 		return nil, errors.New("addPullRequestReview returned empty state")
 	}
-	state := ReviewState{ID: trimmedID, State: trimmedState}
+	state := ReviewState{ID: trimmedID, State: trimmedState, viewer: viewer}
 
 	if prr.SubmittedAt != nil {
 		trimmed := strings.TrimSpace(*prr.SubmittedAt)
@@ -143,6 +154,13 @@ func (s *Service) AddThread(pr resolver.Identity, input ThreadInput) (*ReviewThr
 	if trimmedBody == "" {
 		return nil, errors.New("body is required")
 	}
+	trimmedBody, err := s.Secrets.Check(trimmedBody)
+	if err != nil {
+		return nil, err
+	}
+	if err := ghcli.CheckBodyLength(trimmedBody); err != nil {
+		return nil, err
+	}
 
 	const mutation = `mutation($input:AddPullRequestReviewThreadInput!){
   addPullRequestReviewThread(input:$input){
@@ -215,6 +233,13 @@ func (s *Service) Submit(_ resolver.Identity, input SubmitInput) (*SubmitStatus,
 		"event":               input.Event,
 	}
 	if trimmed := strings.TrimSpace(input.Body); trimmed != "" {
+		trimmed, err := s.Secrets.Check(trimmed)
+		if err != nil {
+			return nil, err
+		}
+		if err := ghcli.CheckBodyLength(trimmed); err != nil {
+			return nil, err
+		}
 		graphqlInput["body"] = trimmed
 	}
 
@@ -255,8 +280,11 @@ func (s *Service) currentViewer() (string, error) {
 	return login, nil
 }
 
-func (s *Service) pullRequestIdentifiers(pr resolver.Identity) (string, string, error) {
+// pullRequestIdentifiers returns the pull request node id, head commit, and
+// the viewer login ("" when the response omits it).
+func (s *Service) pullRequestIdentifiers(pr resolver.Identity) (string, string, string, error) {
 	const query = `query($owner:String!,$name:String!,$number:Int!){
+  viewer { login }
   repository(owner:$owner,name:$name){
     pullRequest(number:$number){ id headRefOid }
   }
@@ -269,6 +297,9 @@ func (s *Service) pullRequestIdentifiers(pr resolver.Identity) (string, string,
 	}
 
 	var resp struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
 		Repository struct {
 			PullRequest struct {
 				ID         string `json:"id"`
@@ -278,14 +309,14 @@ func (s *Service) pullRequestIdentifiers(pr resolver.Identity) (string, string,
 	}
 
 	if err := s.API.GraphQL(query, variables, &resp); err != nil {
-		return "", "", err
+		return "", "", "", err
 	}
 
 	nodeID := strings.TrimSpace(resp.Repository.PullRequest.ID)
 	headSHA := strings.TrimSpace(resp.Repository.PullRequest.HeadRefOID)
 	if nodeID == "" || headSHA == "" {
-		return "", "", errors.New("pull request metadata incomplete")
+		return "", "", "", errors.New("pull request metadata incomplete")
 	}
 
-	return nodeID, headSHA, nil
+	return nodeID, headSHA, strings.TrimSpace(resp.Viewer.Login), nil
 }
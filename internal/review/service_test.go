@@ -7,6 +7,7 @@ import (
 
 	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
 	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/secrets"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -233,6 +234,30 @@ func TestServiceAddThreadRequiresPath(t *testing.T) {
 	assert.Contains(t, err.Error(), "path is required")
 }
 
+func TestServiceAddThreadScansForSecrets(t *testing.T) {
+	api := &fakeAPI{}
+	scanner, err := secrets.New(map[string]string{"ticket": `SEC-[0-9]+`}, false)
+	require.NoError(t, err)
+	svc := &Service{API: api, Secrets: scanner}
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+
+	_, err = svc.AddThread(pr, ThreadInput{ReviewID: "PRR_review", Path: "file.go", Line: 10, Side: "RIGHT", Body: "see SEC-42"})
+	var found *secrets.Error
+	require.ErrorAs(t, err, &found)
+	assert.Equal(t, "ticket", found.Findings[0].Rule)
+
+	scanner.Redact = true
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		input := variables["input"].(map[string]interface{})
+		assert.Equal(t, "see [REDACTED:ticket]", input["body"])
+		return assign(result, map[string]interface{}{
+			"addPullRequestReviewThread": map[string]interface{}{"thread": map[string]interface{}{"id": "THR1", "path": "file.go"}},
+		})
+	}
+	_, err = svc.AddThread(pr, ThreadInput{ReviewID: "PRR_review", Path: "file.go", Line: 10, Side: "RIGHT", Body: "see SEC-42"})
+	require.NoError(t, err)
+}
+
 func TestServiceSubmit(t *testing.T) {
 	api := &fakeAPI{}
 	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
@@ -330,3 +355,53 @@ func assign(result interface{}, payload interface{}) error {
 	}
 	return json.Unmarshal(data, result)
 }
+
+func TestServiceReviewStatusTreatsMissingNodeAsGone(t *testing.T) {
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		assert.Equal(t, "PRR_gone", variables["id"])
+		return &ghcli.GraphQLError{Errors: []ghcli.GraphQLErrorEntry{{Message: "Could not resolve to a node with the global id of 'PRR_gone'"}}}
+	}
+
+	status, err := NewService(api).ReviewStatus("PRR_gone")
+	require.NoError(t, err)
+	assert.Nil(t, status)
+}
+
+func TestServicePullRequestAuthor(t *testing.T) {
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		assert.Contains(t, query, "PullRequestAuthor")
+		assert.Equal(t, map[string]interface{}{"owner": "octo", "name": "demo", "number": 7}, variables)
+		return assign(result, map[string]interface{}{
+			"repository": map[string]interface{}{
+				"pullRequest": map[string]interface{}{"author": map[string]interface{}{"login": "alice"}},
+			},
+		})
+	}
+
+	author, err := NewService(api).PullRequestAuthor(resolver.Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 7})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", author)
+}
+
+func TestServiceDiscard(t *testing.T) {
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		assert.Contains(t, query, "deletePullRequestReview")
+		assert.Equal(t, map[string]interface{}{"pullRequestReviewId": "PRR_review"}, variables["input"])
+		payload := map[string]interface{}{
+			"deletePullRequestReview": map[string]interface{}{
+				"pullRequestReview": map[string]interface{}{"id": "PRR_review", "state": "PENDING"},
+			},
+		}
+		data, err := json.Marshal(payload)
+		require.NoError(t, err)
+		return json.Unmarshal(data, result)
+	}
+
+	state, err := NewService(api).Discard("PRR_review")
+	require.NoError(t, err)
+	assert.Equal(t, "PRR_review", state.ID)
+	assert.Equal(t, "PENDING", state.State)
+}
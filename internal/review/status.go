@@ -0,0 +1,139 @@
+package review
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+// ViewerLogin returns the login of the authenticated user.
+func (s *Service) ViewerLogin() (string, error) {
+	const query = `query ViewerLogin { viewer { login } }`
+
+	var resp struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}
+	if err := s.API.GraphQL(query, nil, &resp); err != nil {
+		return "", err
+	}
+	login := strings.TrimSpace(resp.Viewer.Login)
+	if login == "" {
+		return "", ErrViewerLoginUnavailable
+	}
+	return login, nil
+}
+
+// PullRequestAuthor returns the login of the pull request author, or "" for
+// deleted accounts.
+func (s *Service) PullRequestAuthor(pr resolver.Identity) (string, error) {
+	const query = `query PullRequestAuthor($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) { author { login } }
+  }
+}`
+
+	variables := map[string]interface{}{"owner": pr.Owner, "name": pr.Repo, "number": pr.Number}
+	var resp struct {
+		Repository *struct {
+			PullRequest *struct {
+				Author *struct {
+					Login string `json:"login"`
+				} `json:"author"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	if err := s.API.GraphQL(query, variables, &resp); err != nil {
+		return "", err
+	}
+	if resp.Repository == nil || resp.Repository.PullRequest == nil {
+		return "", fmt.Errorf("pull request %s/%s#%d not found", pr.Owner, pr.Repo, pr.Number)
+	}
+	if resp.Repository.PullRequest.Author == nil {
+		return "", nil
+	}
+	return resp.Repository.PullRequest.Author.Login, nil
+}
+
+// ReviewStatus loads the current state of a review by GraphQL node id. It
+// returns nil when the review no longer exists.
+func (s *Service) ReviewStatus(reviewID string) (*ReviewState, error) {
+	const query = `query ReviewStatus($id: ID!) {
+  node(id: $id) {
+    ... on PullRequestReview { id state submittedAt }
+  }
+}`
+
+	id := strings.TrimSpace(reviewID)
+	if id == "" {
+		return nil, errors.New("review id is required")
+	}
+
+	var resp struct {
+		Node *struct {
+			ID          string  `json:"id"`
+			State       string  `json:"state"`
+			SubmittedAt *string `json:"submittedAt"`
+		} `json:"node"`
+	}
+	if err := s.API.GraphQL(query, map[string]interface{}{"id": id}, &resp); err != nil {
+		if isUnresolvedNode(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if resp.Node == nil || strings.TrimSpace(resp.Node.ID) == "" {
+		return nil, nil
+	}
+	return &ReviewState{ID: resp.Node.ID, State: resp.Node.State, SubmittedAt: resp.Node.SubmittedAt}, nil
+}
+
+// Discard deletes a pending review together with its unsubmitted comments.
+func (s *Service) Discard(reviewID string) (*ReviewState, error) {
+	const mutation = `mutation DeletePullRequestReview($input: DeletePullRequestReviewInput!) {
+  deletePullRequestReview(input: $input) {
+    pullRequestReview { id state }
+  }
+}`
+
+	id := strings.TrimSpace(reviewID)
+	if id == "" {
+		return nil, errors.New("review id is required")
+	}
+
+	var resp struct {
+		DeletePullRequestReview struct {
+			PullRequestReview *struct {
+				ID    string `json:"id"`
+				State string `json:"state"`
+			} `json:"pullRequestReview"`
+		} `json:"deletePullRequestReview"`
+	}
+	variables := map[string]interface{}{"input": map[string]interface{}{"pullRequestReviewId": id}}
+	if err := s.API.GraphQL(mutation, variables, &resp); err != nil {
+		return nil, err
+	}
+	deleted := resp.DeletePullRequestReview.PullRequestReview
+	if deleted == nil || strings.TrimSpace(deleted.ID) == "" {
+		return nil, errors.New("deletePullRequestReview returned no review")
+	}
+	return &ReviewState{ID: deleted.ID, State: deleted.State}, nil
+}
+
+// isUnresolvedNode reports whether GitHub rejected a node id that no longer exists.
+func isUnresolvedNode(err error) bool {
+	var gqlErr *ghcli.GraphQLError
+	if !errors.As(err, &gqlErr) {
+		return false
+	}
+	for _, entry := range gqlErr.Errors {
+		if strings.Contains(entry.Message, "Could not resolve to a node") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package secrets
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rule is a named pattern for one kind of secret.
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// BuiltinRules detect GitHub tokens, AWS keys, PEM private keys, and JWTs.
+var BuiltinRules = []Rule{
+	{Name: "github-token", Pattern: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{22,255})\b`)},
+	{Name: "aws-access-key-id", Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA)[A-Z0-9]{16}\b`)},
+	{Name: "aws-secret-access-key", Pattern: regexp.MustCompile(`(?i)\baws_?secret_?access_?key["']?\s*[:=]\s*["']?[A-Za-z0-9/+]{40}`)},
+	{Name: "private-key", Pattern: regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY-----(?s:.*?)(?:-----END (?:[A-Z0-9]+ )*PRIVATE KEY-----|\z)`)},
+	{Name: "jwt", Pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{5,}\.eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]{10,}`)},
+}
+
+// Finding is one match in a body. Line and Column are 1-based; Column counts
+// characters. Excerpt shows only the start of the match.
+type Finding struct {
+	Rule    string `json:"rule"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Excerpt string `json:"excerpt"`
+
+	start, end int
+}
+
+// Error refuses a body that contains secrets.
+type Error struct {
+	Findings []Finding `json:"findings"`
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "body contains %d possible secret(s); remove them or pass --redact:", len(e.Findings))
+	for _, finding := range e.Findings {
+		fmt.Fprintf(&b, "\n  %s at line %d, column %d (%s)", finding.Rule, finding.Line, finding.Column, finding.Excerpt)
+	}
+	return b.String()
+}
+
+// Scanner checks bodies before they are posted.
+type Scanner struct {
+	Rules []Rule
+	// Redact replaces findings with a placeholder instead of refusing the body.
+	Redact bool
+}
+
+// New returns a scanner with the built-in rules followed by the given
+// patterns, keyed by rule name.
+func New(patterns map[string]string, redact bool) (*Scanner, error) {
+	rules := append([]Rule(nil), BuiltinRules...)
+	names := make([]string, 0, len(patterns))
+	for name := range patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pattern, err := regexp.Compile(patterns[name])
+		if err != nil {
+			return nil, fmt.Errorf("secret pattern %q: %w", name, err)
+		}
+		rules = append(rules, Rule{Name: name, Pattern: pattern})
+	}
+	return &Scanner{Rules: rules, Redact: redact}, nil
+}
+
+// Scan returns the findings in text ordered by position. Matches overlapping
+// an earlier finding are merged into it.
+func (s *Scanner) Scan(text string) []Finding {
+	var findings []Finding
+	for _, rule := range s.Rules {
+		for _, loc := range rule.Pattern.FindAllStringIndex(text, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			findings = append(findings, newFinding(rule.Name, text, loc[0], loc[1]))
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].start < findings[j].start })
+
+	kept := findings[:0]
+	for _, finding := range findings {
+		if n := len(kept); n > 0 && finding.start < kept[n-1].end {
+			if finding.end > kept[n-1].end {
+				kept[n-1].end = finding.end
+			}
+			continue
+		}
+		kept = append(kept, finding)
+	}
+	return kept
+}
+
+// Check returns body unchanged when it is clean. Otherwise it returns the body
+// with every finding replaced by "[REDACTED:<rule>]" when the scanner redacts,
+// or an *Error listing the findings. A nil scanner accepts every body.
+func (s *Scanner) Check(body string) (string, error) {
+	if s == nil {
+		return body, nil
+	}
+	findings := s.Scan(body)
+	if len(findings) == 0 {
+		return body, nil
+	}
+	if !s.Redact {
+		return "", &Error{Findings: findings}
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, finding := range findings {
+		b.WriteString(body[last:finding.start])
+		b.WriteString("[REDACTED:" + finding.Rule + "]")
+		last = finding.end
+	}
+	b.WriteString(body[last:])
+	return b.String(), nil
+}
+
+func newFinding(rule, text string, start, end int) Finding {
+	before := text[:start]
+	line := strings.Count(before, "\n") + 1
+	column := utf8.RuneCountInString(before[strings.LastIndex(before, "\n")+1:]) + 1
+	return Finding{Rule: rule, Line: line, Column: column, Excerpt: excerpt(text[start:end]), start: start, end: end}
+}
+
+// excerpt keeps the first four characters of a match so the finding can be
+// located without repeating the secret.
+func excerpt(match string) string {
+	const keep = 4
+	if i := strings.IndexByte(match, '\n'); i >= 0 {
+		match = match[:i]
+	}
+	if utf8.RuneCountInString(match) <= keep {
+		return "…"
+	}
+	runes := []rune(match)
+	return string(runes[:keep]) + "…"
+}
@@ -0,0 +1,85 @@
+package secrets
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures are assembled at run time so the test file itself holds no
+// token-shaped strings.
+var (
+	githubToken = "ghp_" + strings.Repeat("a1B2", 9)
+	awsKeyID    = "AKIA" + strings.Repeat("Z7", 8)
+	jwt         = "eyJhbGciOiJIUzI1NiJ9" + ".eyJzdWIiOiIxMjM0In0" + ".c2lnbmF0dXJlLXZhbHVl"
+	privateKey  = "-----BEGIN RSA " + "PRIVATE KEY-----\nMIIEow\n-----END RSA " + "PRIVATE KEY-----"
+)
+
+func TestScanBuiltinRules(t *testing.T) {
+	scanner, err := New(nil, false)
+	require.NoError(t, err)
+
+	body := "Log output:\n  token=" + githubToken + "\nkey " + awsKeyID + "\n" +
+		"aws_secret_access_key = " + strings.Repeat("x/Y+", 10) + "\n" +
+		"Authorization: Bearer " + jwt + "\n" + privateKey + "\n"
+
+	findings := scanner.Scan(body)
+	require.Len(t, findings, 5)
+	got := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		got = append(got, finding.Rule)
+	}
+	assert.Equal(t, []string{"github-token", "aws-access-key-id", "aws-secret-access-key", "jwt", "private-key"}, got)
+	assert.Equal(t, 2, findings[0].Line)
+	assert.Equal(t, 9, findings[0].Column)
+	assert.Equal(t, "ghp_…", findings[0].Excerpt)
+	assert.Equal(t, 6, findings[4].Line)
+
+	assert.Empty(t, scanner.Scan("Consider using ghp_ prefixes in docs; AKIA is an AWS prefix."))
+}
+
+func TestCheckRefusesOrRedacts(t *testing.T) {
+	body := "ci log: " + githubToken + " and internal.corp.example"
+
+	scanner, err := New(map[string]string{"internal-host": `[a-z]+\.corp\.example`}, false)
+	require.NoError(t, err)
+	_, err = scanner.Check(body)
+	var found *Error
+	require.True(t, errors.As(err, &found))
+	require.Len(t, found.Findings, 2)
+	assert.Equal(t, "internal-host", found.Findings[1].Rule)
+	assert.Equal(t, "body contains 2 possible secret(s); remove them or pass --redact:\n"+
+		"  github-token at line 1, column 9 (ghp_…)\n"+
+		"  internal-host at line 1, column 54 (inte…)", err.Error())
+
+	scanner.Redact = true
+	redacted, err := scanner.Check(body)
+	require.NoError(t, err)
+	assert.Equal(t, "ci log: [REDACTED:github-token] and [REDACTED:internal-host]", redacted)
+
+	clean, err := scanner.Check("looks good")
+	require.NoError(t, err)
+	assert.Equal(t, "looks good", clean)
+
+	var disabled *Scanner
+	passed, err := disabled.Check(body)
+	require.NoError(t, err)
+	assert.Equal(t, body, passed)
+}
+
+func TestScanMergesOverlappingMatches(t *testing.T) {
+	scanner, err := New(map[string]string{"token-line": `token=\S+`, "suffix": `[a-zA-Z0-9]{8}\?debug`}, true)
+	require.NoError(t, err)
+
+	redacted, err := scanner.Check("token=" + githubToken + " " + githubToken + "?debug")
+	require.NoError(t, err)
+	assert.Equal(t, "[REDACTED:token-line] [REDACTED:github-token]", redacted)
+}
+
+func TestNewRejectsInvalidPattern(t *testing.T) {
+	_, err := New(map[string]string{"broken": "("}, false)
+	require.ErrorContains(t, err, `secret pattern "broken"`)
+}
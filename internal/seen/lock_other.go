@@ -0,0 +1,16 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package seen
+
+import (
+	"errors"
+	"os"
+)
+
+func tryLockFile(f *os.File) (bool, error) {
+	return false, errors.New("file locking is not supported on this platform")
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
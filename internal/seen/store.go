@@ -0,0 +1,200 @@
+package seen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+const (
+	defaultLockTimeout = 10 * time.Second
+	lockRetryInterval  = 20 * time.Millisecond
+)
+
+// Markers maps review thread node IDs to the latest comment timestamp the
+// viewer has already processed.
+type Markers map[string]time.Time
+
+// IsNew reports whether activity at the given time has not been seen yet.
+// Threads without a marker are always new.
+func (m Markers) IsNew(threadID string, activity time.Time) bool {
+	seenAt, ok := m[threadID]
+	return !ok || activity.After(seenAt)
+}
+
+// Store persists read markers on disk, one JSON file per pull request.
+type Store struct {
+	Dir string
+	// LockTimeout bounds how long Mark waits for a concurrent writer.
+	LockTimeout time.Duration
+}
+
+type markerFile struct {
+	Threads map[string]time.Time `json:"threads"`
+}
+
+// NewStore constructs a Store rooted at dir.
+func NewStore(dir string) *Store {
+	return &Store{Dir: dir}
+}
+
+// DefaultStore returns a Store in the user config directory.
+func DefaultStore() (*Store, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("locate config directory: %w", err)
+	}
+	return NewStore(filepath.Join(base, "gh-pr-review", "seen")), nil
+}
+
+// Load returns the markers recorded for the pull request. A pull request that
+// has never been marked yields an empty, non-nil set.
+func (s *Store) Load(pr resolver.Identity) (Markers, error) {
+	path, err := s.path(pr)
+	if err != nil {
+		return nil, err
+	}
+	return readMarkers(path)
+}
+
+// Mark merges the provided markers into the stored set, keeping the later
+// timestamp per thread. The read-merge-write cycle runs under a lock file and
+// the result is written via rename, so concurrent invocations neither lose
+// updates nor observe partial files.
+func (s *Store) Mark(pr resolver.Identity, markers Markers) error {
+	if len(markers) == 0 {
+		return nil
+	}
+	path, err := s.path(pr)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create read marker directory: %w", err)
+	}
+
+	unlock, err := s.lock(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	current, err := readMarkers(path)
+	if err != nil {
+		return err
+	}
+	for threadID, at := range markers {
+		if existing, ok := current[threadID]; !ok || at.After(existing) {
+			current[threadID] = at.UTC()
+		}
+	}
+	return writeMarkers(path, current)
+}
+
+func (s *Store) path(pr resolver.Identity) (string, error) {
+	if strings.TrimSpace(s.Dir) == "" {
+		return "", errors.New("read marker directory is not configured")
+	}
+	if pr.Host == "" || pr.Owner == "" || pr.Repo == "" || pr.Number <= 0 {
+		return "", errors.New("read markers require a fully resolved pull request")
+	}
+	return filepath.Join(
+		s.Dir,
+		strings.ToLower(pr.Host),
+		strings.ToLower(pr.Owner),
+		strings.ToLower(pr.Repo),
+		strconv.Itoa(pr.Number)+".json",
+	), nil
+}
+
+// lock takes an exclusive OS advisory lock on the lock file, retrying until
+// the timeout. The operating system drops the lock when its holder exits, so
+// a crashed process never leaves a stale lock behind, and the file itself is
+// kept so that every process locks the same inode.
+func (s *Store) lock(path string) (func(), error) {
+	timeout := s.LockTimeout
+	if timeout <= 0 {
+		timeout = defaultLockTimeout
+	}
+	deadline := time.Now().Add(timeout)
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("lock read markers: %w", err)
+	}
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("lock read markers: %w", err)
+		}
+		if locked {
+			return func() {
+				_ = unlockFile(f)
+				_ = f.Close()
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			_ = f.Close()
+			return nil, fmt.Errorf("timed out waiting for read marker lock %s", path)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+func readMarkers(path string) (Markers, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Markers{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read markers: %w", err)
+	}
+
+	var file markerFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("decode read markers %s: %w", path, err)
+	}
+	markers := make(Markers, len(file.Threads))
+	for threadID, at := range file.Threads {
+		markers[threadID] = at
+	}
+	return markers, nil
+}
+
+func writeMarkers(path string, markers Markers) error {
+	data, err := json.MarshalIndent(markerFile{Threads: markers}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode read markers: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write read markers: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write read markers: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write read markers: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write read markers: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("write read markers: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package seen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPR = resolver.Identity{Host: "github.com", Owner: "Octo", Repo: "Demo", Number: 7}
+
+func TestLoadMissingReturnsEmpty(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	markers, err := store.Load(testPR)
+	require.NoError(t, err)
+	assert.NotNil(t, markers)
+	assert.Empty(t, markers)
+	assert.True(t, markers.IsNew("PRRT_1", time.Now()))
+}
+
+func TestMarkKeepsLatestTimestamp(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+	early := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	late := early.Add(time.Hour)
+
+	require.NoError(t, store.Mark(testPR, Markers{"PRRT_1": late, "PRRT_2": early}))
+	require.NoError(t, store.Mark(testPR, Markers{"PRRT_1": early}))
+
+	markers, err := store.Load(testPR)
+	require.NoError(t, err)
+	assert.Equal(t, late, markers["PRRT_1"])
+	assert.Equal(t, early, markers["PRRT_2"])
+	assert.False(t, markers.IsNew("PRRT_1", late))
+	assert.True(t, markers.IsNew("PRRT_1", late.Add(time.Second)))
+
+	_, err = os.Stat(filepath.Join(dir, "github.com", "octo", "demo", "7.json"))
+	require.NoError(t, err)
+}
+
+func TestMarkConcurrentWritersDoNotLoseUpdates(t *testing.T) {
+	store := NewStore(t.TempDir())
+	at := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, store.Mark(testPR, Markers{fmt.Sprintf("PRRT_%d", i): at}))
+		}(i)
+	}
+	wg.Wait()
+
+	markers, err := store.Load(testPR)
+	require.NoError(t, err)
+	assert.Len(t, markers, 20)
+}
+
+func TestMarkWaitsForLockHolder(t *testing.T) {
+	dir := t.TempDir()
+	store := &Store{Dir: dir, LockTimeout: 50 * time.Millisecond}
+	lockPath := filepath.Join(dir, "github.com", "octo", "demo", "7.json.lock")
+	require.NoError(t, os.MkdirAll(filepath.Dir(lockPath), 0o700))
+
+	unlock, err := store.lock(lockPath)
+	require.NoError(t, err)
+	err = store.Mark(testPR, Markers{"PRRT_1": time.Now()})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out waiting for read marker lock")
+
+	unlock()
+	require.NoError(t, store.Mark(testPR, Markers{"PRRT_1": time.Now()}))
+}
+
+func TestMarkIgnoresLockFileLeftByCrashedProcess(t *testing.T) {
+	dir := t.TempDir()
+	store := &Store{Dir: dir, LockTimeout: 50 * time.Millisecond}
+	lockPath := filepath.Join(dir, "github.com", "octo", "demo", "7.json.lock")
+	require.NoError(t, os.MkdirAll(filepath.Dir(lockPath), 0o700))
+	// The file outlives its holder, but the lock on it does not.
+	require.NoError(t, os.WriteFile(lockPath, nil, 0o600))
+
+	require.NoError(t, store.Mark(testPR, Markers{"PRRT_1": time.Now()}))
+}
@@ -0,0 +1,160 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+// Session records the pending review a viewer is building on a pull request.
+type Session struct {
+	ReviewID  string    `json:"review_id"`
+	Viewer    string    `json:"viewer"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// Store persists sessions on disk, one JSON file per pull request and viewer.
+// Each file has a single writer per viewer, so writes are plain atomic renames.
+type Store struct {
+	Dir string
+}
+
+// NewStore constructs a Store rooted at dir.
+func NewStore(dir string) *Store {
+	return &Store{Dir: dir}
+}
+
+// DefaultStore returns a Store in the user config directory.
+func DefaultStore() (*Store, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("locate config directory: %w", err)
+	}
+	return NewStore(filepath.Join(base, "gh-pr-review", "sessions")), nil
+}
+
+// Load returns the viewer's session for the pull request, or nil when none is recorded.
+func (s *Store) Load(pr resolver.Identity, viewer string) (*Session, error) {
+	path, err := s.path(pr, viewer)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read review session: %w", err)
+	}
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("decode review session %s: %w", path, err)
+	}
+	if strings.TrimSpace(session.ReviewID) == "" {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+// Save records the session as the viewer's active pending review, replacing any previous one.
+func (s *Store) Save(pr resolver.Identity, session Session) error {
+	path, err := s.path(pr, session.Viewer)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create review session directory: %w", err)
+	}
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode review session: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write review session: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write review session: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write review session: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("write review session: %w", err)
+	}
+	return nil
+}
+
+// Clear removes the viewer's session. Clearing a missing session is not an error.
+func (s *Store) Clear(pr resolver.Identity, viewer string) error {
+	path, err := s.path(pr, viewer)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("clear review session: %w", err)
+	}
+	return nil
+}
+
+// Empty reports whether no viewer has a session for the pull request, so
+// callers can skip resolving the viewer login.
+func (s *Store) Empty(pr resolver.Identity) (bool, error) {
+	dir, err := s.dir(pr)
+	if err != nil {
+		return false, err
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("read review sessions: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (s *Store) dir(pr resolver.Identity) (string, error) {
+	if strings.TrimSpace(s.Dir) == "" {
+		return "", errors.New("review session directory is not configured")
+	}
+	if pr.Host == "" || pr.Owner == "" || pr.Repo == "" || pr.Number <= 0 {
+		return "", errors.New("review sessions require a fully resolved pull request")
+	}
+	return filepath.Join(
+		s.Dir,
+		strings.ToLower(pr.Host),
+		strings.ToLower(pr.Owner),
+		strings.ToLower(pr.Repo),
+		strconv.Itoa(pr.Number),
+	), nil
+}
+
+func (s *Store) path(pr resolver.Identity, viewer string) (string, error) {
+	login := strings.ToLower(strings.TrimSpace(viewer))
+	if login == "" || strings.ContainsAny(login, `/\.`) {
+		return "", fmt.Errorf("invalid viewer login %q for review session", viewer)
+	}
+	dir, err := s.dir(pr)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, login+".json"), nil
+}
@@ -0,0 +1,57 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPR = resolver.Identity{Host: "github.com", Owner: "Octo", Repo: "Demo", Number: 7}
+
+func TestSaveLoadClear(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+	startedAt := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	empty, err := store.Empty(testPR)
+	require.NoError(t, err)
+	assert.True(t, empty)
+	loaded, err := store.Load(testPR, "octocat")
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	require.NoError(t, store.Save(testPR, Session{ReviewID: "PRR_1", Viewer: "OctoCat", StartedAt: startedAt}))
+	_, err = os.Stat(filepath.Join(dir, "github.com", "octo", "demo", "7", "octocat.json"))
+	require.NoError(t, err)
+
+	empty, err = store.Empty(testPR)
+	require.NoError(t, err)
+	assert.False(t, empty)
+	loaded, err = store.Load(testPR, "octocat")
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, Session{ReviewID: "PRR_1", Viewer: "OctoCat", StartedAt: startedAt}, *loaded)
+
+	other, err := store.Load(testPR, "hubot")
+	require.NoError(t, err)
+	assert.Nil(t, other)
+
+	require.NoError(t, store.Clear(testPR, "octocat"))
+	require.NoError(t, store.Clear(testPR, "octocat"))
+	empty, err = store.Empty(testPR)
+	require.NoError(t, err)
+	assert.True(t, empty)
+}
+
+func TestRejectsUnsafeViewer(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	err := store.Save(testPR, Session{ReviewID: "PRR_1", Viewer: "../evil"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid viewer login")
+}
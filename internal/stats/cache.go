@@ -0,0 +1,121 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/report"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+// cacheVersion invalidates entries written with an older layout.
+const cacheVersion = 1
+
+// Cache persists the review models of merged pull requests on disk, one JSON
+// file per pull request, keyed by the pull request's updatedAt timestamp.
+type Cache struct {
+	Dir string
+}
+
+type cacheEntry struct {
+	Version   int           `json:"version"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	Models    report.Models `json:"models"`
+}
+
+// NewCache constructs a Cache rooted at dir.
+func NewCache(dir string) *Cache {
+	return &Cache{Dir: dir}
+}
+
+// DefaultCache returns a Cache in the user cache directory.
+func DefaultCache() (*Cache, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("locate cache directory: %w", err)
+	}
+	return NewCache(filepath.Join(base, "gh-pr-review", "stats")), nil
+}
+
+// Load returns the cached models when they were stored for the same
+// updatedAt. Missing, stale, or unreadable entries are reported as misses.
+func (c *Cache) Load(pr resolver.Identity, updatedAt time.Time) (report.Models, bool) {
+	path, err := c.path(pr)
+	if err != nil {
+		return report.Models{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return report.Models{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return report.Models{}, false
+	}
+	if entry.Version != cacheVersion || !entry.UpdatedAt.Equal(updatedAt) {
+		return report.Models{}, false
+	}
+	return entry.Models, true
+}
+
+// Save stores the models for the pull request, replacing any previous entry.
+// The file is written via rename so concurrent readers never observe partial
+// entries.
+func (c *Cache) Save(pr resolver.Identity, updatedAt time.Time, models report.Models) error {
+	path, err := c.path(pr)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create stats cache directory: %w", err)
+	}
+	data, err := json.Marshal(cacheEntry{Version: cacheVersion, UpdatedAt: updatedAt.UTC(), Models: models})
+	if err != nil {
+		return fmt.Errorf("encode stats cache: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write stats cache: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write stats cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write stats cache: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("write stats cache: %w", err)
+	}
+	return nil
+}
+
+func (c *Cache) path(pr resolver.Identity) (string, error) {
+	if strings.TrimSpace(c.Dir) == "" {
+		return "", errors.New("stats cache directory is not configured")
+	}
+	if pr.Owner == "" || pr.Repo == "" || pr.Number <= 0 {
+		return "", errors.New("stats cache requires a fully resolved pull request")
+	}
+	host := pr.Host
+	if host == "" {
+		host = "github.com"
+	}
+	return filepath.Join(
+		c.Dir,
+		strings.ToLower(host),
+		strings.ToLower(pr.Owner),
+		strings.ToLower(pr.Repo),
+		strconv.Itoa(pr.Number)+".json",
+	), nil
+}
@@ -0,0 +1,284 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/report"
+)
+
+// Stats aggregates review metrics over the pull requests in the window.
+type Stats struct {
+	Repo        string `json:"repo"`
+	Since       string `json:"since"`
+	GeneratedAt string `json:"generated_at"`
+	// PullRequests counts the pull requests in the window by state.
+	PullRequests      PullCounts   `json:"pull_requests"`
+	TimeToFirstReview Distribution `json:"time_to_first_review_hours"`
+	TimeToApproval    Distribution `json:"time_to_approval_hours"`
+	// ReviewRounds counts the distinct commits reviewed per reviewed pull
+	// request.
+	ReviewRounds Distribution    `json:"review_rounds"`
+	Threads      ThreadStats     `json:"threads"`
+	Reviewers    []ReviewerStats `json:"reviewers"`
+	Pulls        []PullStats     `json:"pulls"`
+}
+
+// PullCounts counts pull requests by state.
+type PullCounts struct {
+	Total  int `json:"total"`
+	Open   int `json:"open"`
+	Merged int `json:"merged"`
+}
+
+// Distribution summarizes a set of samples; the statistics are null when
+// there are no samples.
+type Distribution struct {
+	Count  int      `json:"count"`
+	Median *float64 `json:"median"`
+	Mean   *float64 `json:"mean"`
+	P90    *float64 `json:"p90"`
+}
+
+// ThreadStats summarizes the review threads of every pull request.
+type ThreadStats struct {
+	Total          int      `json:"total"`
+	Resolved       int      `json:"resolved"`
+	Outdated       int      `json:"outdated"`
+	ResolutionRate *float64 `json:"resolution_rate"`
+	// OutdatedShare is the share of threads whose code changed after the
+	// thread was opened.
+	OutdatedShare *float64 `json:"outdated_share"`
+	// UnresolvedOpen counts unresolved threads on open pull requests.
+	UnresolvedOpen int `json:"unresolved_open"`
+	// MedianUnresolvedAgeHours is the median age of those threads, measured
+	// from their first comment.
+	MedianUnresolvedAgeHours *float64 `json:"median_unresolved_age_hours"`
+}
+
+// ReviewerStats counts the reviews and threads of one reviewer. Activity on a
+// reviewer's own pull requests is not counted.
+type ReviewerStats struct {
+	Login            string   `json:"login"`
+	PullRequests     int      `json:"pull_requests"`
+	Reviews          int      `json:"reviews"`
+	Approvals        int      `json:"approvals"`
+	ChangesRequested int      `json:"changes_requested"`
+	Threads          int      `json:"threads"`
+	ResolvedThreads  int      `json:"resolved_threads"`
+	OutdatedThreads  int      `json:"outdated_threads"`
+	ResolutionRate   *float64 `json:"resolution_rate"`
+}
+
+// PullStats holds the metrics of one pull request.
+type PullStats struct {
+	Number                 int      `json:"number"`
+	Title                  string   `json:"title"`
+	State                  string   `json:"state"`
+	AuthorLogin            string   `json:"author_login"`
+	CreatedAt              string   `json:"created_at"`
+	MergedAt               string   `json:"merged_at,omitempty"`
+	TimeToFirstReviewHours *float64 `json:"time_to_first_review_hours"`
+	TimeToApprovalHours    *float64 `json:"time_to_approval_hours"`
+	ReviewRounds           int      `json:"review_rounds"`
+	Threads                int      `json:"threads"`
+	ResolvedThreads        int      `json:"resolved_threads"`
+	OutdatedThreads        int      `json:"outdated_threads"`
+	UnresolvedThreads      int      `json:"unresolved_threads"`
+}
+
+// Build computes the metrics. models[i] holds the reviews and threads of
+// pulls[i]. Reviews count when submitted by someone other than the author and
+// not pending; a thread belongs to the author of its first comment.
+func Build(repo string, since, now time.Time, pulls []Pull, models []report.Models) Stats {
+	stats := Stats{
+		Repo:        repo,
+		Since:       since.UTC().Format(time.RFC3339),
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		Reviewers:   make([]ReviewerStats, 0),
+		Pulls:       make([]PullStats, 0, len(pulls)),
+	}
+
+	reviewers := make(map[string]*ReviewerStats)
+	reviewer := func(login string) *ReviewerStats {
+		key := strings.ToLower(login)
+		entry, ok := reviewers[key]
+		if !ok {
+			entry = &ReviewerStats{Login: login}
+			reviewers[key] = entry
+		}
+		return entry
+	}
+
+	var firstReview, approval, rounds, unresolvedAges []float64
+	for i, pull := range pulls {
+		stats.PullRequests.Total++
+		switch pull.State {
+		case StateOpen:
+			stats.PullRequests.Open++
+		case StateMerged:
+			stats.PullRequests.Merged++
+		}
+
+		entry := PullStats{
+			Number:      pull.Number,
+			Title:       pull.Title,
+			State:       pull.State,
+			AuthorLogin: pull.AuthorLogin,
+			CreatedAt:   pull.CreatedAt.UTC().Format(time.RFC3339),
+		}
+		if pull.MergedAt != nil {
+			entry.MergedAt = pull.MergedAt.UTC().Format(time.RFC3339)
+		}
+
+		var firstAt, approvedAt *time.Time
+		commits := make(map[string]struct{})
+		reviewedBy := make(map[string]struct{})
+		for _, review := range models[i].Reviews {
+			if review.SubmittedAt == nil || review.State == report.StatePending || isAuthor(review.AuthorLogin, pull) {
+				continue
+			}
+			if firstAt == nil || review.SubmittedAt.Before(*firstAt) {
+				firstAt = review.SubmittedAt
+			}
+			if review.State == report.StateApproved && (approvedAt == nil || review.SubmittedAt.Before(*approvedAt)) {
+				approvedAt = review.SubmittedAt
+			}
+			// Reviews of commits that no longer exist have no commit; count
+			// each of them as its own round.
+			commit := review.CommitOID
+			if commit == "" {
+				commit = "review:" + review.ID
+			}
+			commits[commit] = struct{}{}
+
+			stat := reviewer(review.AuthorLogin)
+			stat.Reviews++
+			switch review.State {
+			case report.StateApproved:
+				stat.Approvals++
+			case report.StateChangesRequested:
+				stat.ChangesRequested++
+			}
+			if _, ok := reviewedBy[strings.ToLower(review.AuthorLogin)]; !ok {
+				reviewedBy[strings.ToLower(review.AuthorLogin)] = struct{}{}
+				stat.PullRequests++
+			}
+		}
+		if firstAt != nil {
+			hours := round(firstAt.Sub(pull.CreatedAt).Hours(), 2)
+			entry.TimeToFirstReviewHours = &hours
+			firstReview = append(firstReview, hours)
+		}
+		if approvedAt != nil {
+			hours := round(approvedAt.Sub(pull.CreatedAt).Hours(), 2)
+			entry.TimeToApprovalHours = &hours
+			approval = append(approval, hours)
+		}
+		entry.ReviewRounds = len(commits)
+		if entry.ReviewRounds > 0 {
+			rounds = append(rounds, float64(entry.ReviewRounds))
+		}
+
+		for _, thread := range models[i].Threads {
+			entry.Threads++
+			switch {
+			case thread.IsResolved:
+				entry.ResolvedThreads++
+			case pull.State == StateOpen && len(thread.Comments) > 0:
+				unresolvedAges = append(unresolvedAges, round(now.Sub(thread.Comments[0].CreatedAt).Hours(), 2))
+			}
+			if thread.IsOutdated {
+				entry.OutdatedThreads++
+			}
+
+			if len(thread.Comments) == 0 || isAuthor(thread.Comments[0].AuthorLogin, pull) {
+				continue
+			}
+			stat := reviewer(thread.Comments[0].AuthorLogin)
+			stat.Threads++
+			if thread.IsResolved {
+				stat.ResolvedThreads++
+			}
+			if thread.IsOutdated {
+				stat.OutdatedThreads++
+			}
+		}
+		entry.UnresolvedThreads = entry.Threads - entry.ResolvedThreads
+
+		stats.Threads.Total += entry.Threads
+		stats.Threads.Resolved += entry.ResolvedThreads
+		stats.Threads.Outdated += entry.OutdatedThreads
+		stats.Pulls = append(stats.Pulls, entry)
+	}
+
+	stats.TimeToFirstReview = distribution(firstReview)
+	stats.TimeToApproval = distribution(approval)
+	stats.ReviewRounds = distribution(rounds)
+	stats.Threads.ResolutionRate = ratio(stats.Threads.Resolved, stats.Threads.Total)
+	stats.Threads.OutdatedShare = ratio(stats.Threads.Outdated, stats.Threads.Total)
+	stats.Threads.UnresolvedOpen = len(unresolvedAges)
+	stats.Threads.MedianUnresolvedAgeHours = distribution(unresolvedAges).Median
+
+	for _, stat := range reviewers {
+		stat.ResolutionRate = ratio(stat.ResolvedThreads, stat.Threads)
+		stats.Reviewers = append(stats.Reviewers, *stat)
+	}
+	sort.Slice(stats.Reviewers, func(i, j int) bool {
+		a, b := stats.Reviewers[i], stats.Reviewers[j]
+		if a.Reviews != b.Reviews {
+			return a.Reviews > b.Reviews
+		}
+		if a.Threads != b.Threads {
+			return a.Threads > b.Threads
+		}
+		return strings.ToLower(a.Login) < strings.ToLower(b.Login)
+	})
+	return stats
+}
+
+func isAuthor(login string, pull Pull) bool {
+	return pull.AuthorLogin != "" && strings.EqualFold(login, pull.AuthorLogin)
+}
+
+// distribution computes the median, mean, and nearest-rank 90th percentile.
+func distribution(samples []float64) Distribution {
+	result := Distribution{Count: len(samples)}
+	if len(samples) == 0 {
+		return result
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+
+	n := len(sorted)
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	sum := 0.0
+	for _, sample := range sorted {
+		sum += sample
+	}
+	mean := round(sum/float64(n), 2)
+	p90 := sorted[int(math.Ceil(0.9*float64(n)))-1]
+
+	median = round(median, 2)
+	result.Median, result.Mean, result.P90 = &median, &mean, &p90
+	return result
+}
+
+// ratio returns part/total rounded to four decimals, or nil when total is 0.
+func ratio(part, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	value := round(float64(part)/float64(total), 4)
+	return &value
+}
+
+func round(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
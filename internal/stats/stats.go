@@ -0,0 +1,235 @@
+// Package stats computes repository-wide review metrics from the review and
+// thread models of recent pull requests.
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/report"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+const pullRequestsQuery = `query RepositoryPullRequests($owner: String!, $name: String!, $first: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequests(first: $first, after: $after, states: [OPEN, MERGED], orderBy: {field: CREATED_AT, direction: DESC}) {
+      nodes {
+        number
+        title
+        state
+        isDraft
+        createdAt
+        updatedAt
+        mergedAt
+        author { login }
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+const pullRequestsPageSize = 50
+
+// DefaultConcurrency bounds the pull requests fetched in parallel.
+const DefaultConcurrency = 4
+
+// Pull request states counted by stats.
+const (
+	StateOpen   = "OPEN"
+	StateMerged = "MERGED"
+)
+
+// Options scopes the pull requests and controls how their models are loaded.
+type Options struct {
+	// Since keeps pull requests created at or after this time.
+	Since time.Time
+	// Concurrency bounds the number of in-flight model fetches (defaults to 4).
+	Concurrency int
+	// Cache, when non-nil, reuses the models of merged pull requests that have
+	// not been updated since they were cached.
+	Cache *Cache
+}
+
+// Pull is the pull request metadata the metrics are computed from.
+type Pull struct {
+	Number      int
+	Title       string
+	State       string
+	IsDraft     bool
+	AuthorLogin string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	MergedAt    *time.Time
+}
+
+// Service lists pull requests and computes their review metrics.
+type Service struct {
+	API ghcli.API
+	// Now is the reference time for the age of unresolved threads.
+	Now func() time.Time
+}
+
+// NewService constructs a stats Service.
+func NewService(api ghcli.API) *Service {
+	return &Service{API: api, Now: time.Now}
+}
+
+// Compute lists the open and merged pull requests created since opts.Since,
+// loads their reviews and threads, and aggregates the metrics.
+func (s *Service) Compute(repo resolver.Identity, opts Options) (Stats, error) {
+	if repo.Owner == "" || repo.Repo == "" {
+		return Stats{}, errors.New("repository must be owner/repo")
+	}
+	pulls, err := s.listPulls(repo, opts.Since)
+	if err != nil {
+		return Stats{}, err
+	}
+	models, err := s.loadModels(repo, pulls, opts)
+	if err != nil {
+		return Stats{}, err
+	}
+	return Build(repo.Owner+"/"+repo.Repo, opts.Since, s.Now(), pulls, models), nil
+}
+
+// listPulls pages through pull requests newest first and stops at the first
+// one created before since.
+func (s *Service) listPulls(repo resolver.Identity, since time.Time) ([]Pull, error) {
+	pulls := make([]Pull, 0)
+	var after interface{}
+	for {
+		variables := map[string]interface{}{
+			"owner": repo.Owner,
+			"name":  repo.Repo,
+			"first": pullRequestsPageSize,
+			"after": after,
+		}
+		var response struct {
+			Repository *struct {
+				PullRequests struct {
+					Nodes []struct {
+						Number    int     `json:"number"`
+						Title     string  `json:"title"`
+						State     string  `json:"state"`
+						IsDraft   bool    `json:"isDraft"`
+						CreatedAt string  `json:"createdAt"`
+						UpdatedAt string  `json:"updatedAt"`
+						MergedAt  *string `json:"mergedAt"`
+						Author    *struct {
+							Login string `json:"login"`
+						} `json:"author"`
+					} `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"pullRequests"`
+			} `json:"repository"`
+		}
+		if err := s.API.GraphQL(pullRequestsQuery, variables, &response); err != nil {
+			return nil, err
+		}
+		if response.Repository == nil {
+			return nil, fmt.Errorf("repository %s/%s not found or inaccessible", repo.Owner, repo.Repo)
+		}
+
+		connection := response.Repository.PullRequests
+		for _, node := range connection.Nodes {
+			createdAt, err := time.Parse(time.RFC3339, node.CreatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("parse createdAt of #%d: %w", node.Number, err)
+			}
+			if createdAt.Before(since) {
+				return pulls, nil
+			}
+			updatedAt, err := time.Parse(time.RFC3339, node.UpdatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("parse updatedAt of #%d: %w", node.Number, err)
+			}
+			pull := Pull{
+				Number:    node.Number,
+				Title:     node.Title,
+				State:     node.State,
+				IsDraft:   node.IsDraft,
+				CreatedAt: createdAt,
+				UpdatedAt: updatedAt,
+			}
+			if node.Author != nil {
+				pull.AuthorLogin = node.Author.Login
+			}
+			if node.MergedAt != nil && *node.MergedAt != "" {
+				mergedAt, err := time.Parse(time.RFC3339, *node.MergedAt)
+				if err != nil {
+					return nil, fmt.Errorf("parse mergedAt of #%d: %w", node.Number, err)
+				}
+				pull.MergedAt = &mergedAt
+			}
+			pulls = append(pulls, pull)
+		}
+
+		if !connection.PageInfo.HasNextPage || connection.PageInfo.EndCursor == "" {
+			return pulls, nil
+		}
+		after = connection.PageInfo.EndCursor
+	}
+}
+
+// loadModels fetches the reviews and threads of every pull request with
+// bounded concurrency. Open pull requests are always fetched fresh; merged
+// ones are served from the cache when their updatedAt is unchanged and stored
+// in it on a best-effort basis.
+func (s *Service) loadModels(repo resolver.Identity, pulls []Pull, opts Options) ([]report.Models, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	reports := report.NewService(s.API)
+
+	models := make([]report.Models, len(pulls))
+	errs := make([]error, len(pulls))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := range pulls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			pull := pulls[i]
+			pr := repo
+			pr.Number = pull.Number
+			cacheable := opts.Cache != nil && pull.State == StateMerged
+			if cacheable {
+				if cached, ok := opts.Cache.Load(pr, pull.UpdatedAt); ok {
+					models[i] = cached
+					return
+				}
+			}
+			fetched, err := reports.Models(pr)
+			if err != nil {
+				errs[i] = fmt.Errorf("fetch #%d: %w", pull.Number, err)
+				return
+			}
+			models[i] = fetched
+			if cacheable {
+				// The cache only saves refetches; a failed write must not
+				// discard metrics that were already fetched.
+				_ = opts.Cache.Save(pr, pull.UpdatedAt, fetched)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return models, nil
+}
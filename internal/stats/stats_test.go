@@ -0,0 +1,207 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+type fakeAPI struct {
+	graphqlFunc func(query string, variables map[string]interface{}, result interface{}) error
+}
+
+func (f *fakeAPI) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+	return errors.New("unexpected REST call")
+}
+
+func (f *fakeAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+	if f.graphqlFunc == nil {
+		return errors.New("unexpected GraphQL call")
+	}
+	return f.graphqlFunc(query, variables, result)
+}
+
+func assign(result interface{}, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+type obj = map[string]interface{}
+
+func review(id, login, state, submittedAt, commit string) obj {
+	return obj{"id": id, "state": state, "submittedAt": submittedAt, "databaseId": 1, "author": obj{"login": login}, "commit": obj{"oid": commit}}
+}
+
+func thread(id, login, createdAt string, resolved, outdated bool) obj {
+	return obj{"id": id, "path": "main.go", "isResolved": resolved, "isOutdated": outdated, "comments": obj{"nodes": []obj{
+		{"id": id + "_C", "databaseId": 1, "body": "nit", "createdAt": createdAt, "author": obj{"login": login}},
+	}}}
+}
+
+// fixture serves two pages of pull requests and the models of #2 and #3;
+// #1 predates the window and must never be fetched.
+func fixture(t *testing.T) (*fakeAPI, func() map[int]int) {
+	var mu sync.Mutex
+	fetches := make(map[int]int)
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		if strings.Contains(query, "RepositoryPullRequests") {
+			if variables["after"] == nil {
+				return assign(result, obj{"repository": obj{"pullRequests": obj{
+					"nodes": []obj{
+						{"number": 3, "title": "Open", "state": "OPEN", "createdAt": "2025-12-05T00:00:00Z", "updatedAt": "2025-12-06T00:00:00Z", "author": obj{"login": "octocat"}},
+						{"number": 2, "title": "Merged", "state": "MERGED", "createdAt": "2025-12-02T00:00:00Z", "updatedAt": "2025-12-04T00:00:00Z", "mergedAt": "2025-12-04T00:00:00Z", "author": obj{"login": "octocat"}},
+					},
+					"pageInfo": obj{"hasNextPage": true, "endCursor": "c1"},
+				}}})
+			}
+			require.Equal(t, "c1", variables["after"])
+			return assign(result, obj{"repository": obj{"pullRequests": obj{
+				"nodes": []obj{
+					{"number": 1, "title": "Old", "state": "MERGED", "createdAt": "2025-11-20T00:00:00Z", "updatedAt": "2025-11-21T00:00:00Z", "author": obj{"login": "octocat"}},
+				},
+				"pageInfo": obj{"hasNextPage": true, "endCursor": "c2"},
+			}}})
+		}
+
+		// Model fetches run on worker goroutines, so report failures as errors.
+		assert.Equal(t, true, variables["includeStatus"])
+		number := variables["number"].(int)
+		mu.Lock()
+		fetches[number]++
+		mu.Unlock()
+
+		var reviews, threads []obj
+		switch number {
+		case 3:
+			reviews = []obj{review("R3", "alice", "COMMENTED", "2025-12-05T02:00:00Z", "a")}
+			threads = []obj{
+				thread("T3a", "alice", "2025-12-05T02:00:00Z", false, false),
+				thread("T3b", "octocat", "2025-12-06T00:00:00Z", false, false),
+			}
+		case 2:
+			reviews = []obj{
+				review("R2a", "alice", "CHANGES_REQUESTED", "2025-12-02T04:00:00Z", "a"),
+				review("R2b", "bob", "APPROVED", "2025-12-03T00:00:00Z", "b"),
+				review("R2c", "alice", "APPROVED", "2025-12-03T06:00:00Z", "b"),
+				review("R2d", "octocat", "COMMENTED", "2025-12-02T05:00:00Z", "a"),
+			}
+			threads = []obj{
+				thread("T2a", "alice", "2025-12-02T04:00:00Z", true, true),
+				thread("T2b", "bob", "2025-12-03T00:00:00Z", true, false),
+			}
+		default:
+			return fmt.Errorf("unexpected fetch of #%d", number)
+		}
+		return assign(result, obj{"repository": obj{"pullRequest": obj{
+			"author":        obj{"login": "octocat"},
+			"reviews":       obj{"nodes": reviews},
+			"reviewThreads": obj{"nodes": threads},
+		}}})
+	}
+	return api, func() map[int]int {
+		mu.Lock()
+		defer mu.Unlock()
+		counts := make(map[int]int, len(fetches))
+		for number, count := range fetches {
+			counts[number] = count
+		}
+		return counts
+	}
+}
+
+func TestComputeAggregatesMetrics(t *testing.T) {
+	api, _ := fixture(t)
+	service := NewService(api)
+	service.Now = func() time.Time { return time.Date(2025, 12, 10, 0, 0, 0, 0, time.UTC) }
+
+	since := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	stats, err := service.Compute(resolver.Identity{Owner: "octo", Repo: "demo", Host: "github.com"}, Options{Since: since, Concurrency: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, "octo/demo", stats.Repo)
+	assert.Equal(t, "2025-12-01T00:00:00Z", stats.Since)
+	assert.Equal(t, PullCounts{Total: 2, Open: 1, Merged: 1}, stats.PullRequests)
+
+	assert.Equal(t, Distribution{Count: 2, Median: ptr(3), Mean: ptr(3), P90: ptr(4)}, stats.TimeToFirstReview)
+	assert.Equal(t, Distribution{Count: 1, Median: ptr(24), Mean: ptr(24), P90: ptr(24)}, stats.TimeToApproval)
+	assert.Equal(t, Distribution{Count: 2, Median: ptr(1.5), Mean: ptr(1.5), P90: ptr(2)}, stats.ReviewRounds)
+
+	assert.Equal(t, ThreadStats{
+		Total:                    4,
+		Resolved:                 2,
+		Outdated:                 1,
+		ResolutionRate:           ptr(0.5),
+		OutdatedShare:            ptr(0.25),
+		UnresolvedOpen:           2,
+		MedianUnresolvedAgeHours: ptr(107),
+	}, stats.Threads)
+
+	assert.Equal(t, []ReviewerStats{
+		{Login: "alice", PullRequests: 2, Reviews: 3, Approvals: 1, ChangesRequested: 1, Threads: 2, ResolvedThreads: 1, OutdatedThreads: 1, ResolutionRate: ptr(0.5)},
+		{Login: "bob", PullRequests: 1, Reviews: 1, Approvals: 1, Threads: 1, ResolvedThreads: 1, ResolutionRate: ptr(1)},
+	}, stats.Reviewers)
+
+	require.Len(t, stats.Pulls, 2)
+	assert.Equal(t, PullStats{
+		Number: 3, Title: "Open", State: "OPEN", AuthorLogin: "octocat", CreatedAt: "2025-12-05T00:00:00Z",
+		TimeToFirstReviewHours: ptr(2), ReviewRounds: 1, Threads: 2, UnresolvedThreads: 2,
+	}, stats.Pulls[0])
+	assert.Equal(t, PullStats{
+		Number: 2, Title: "Merged", State: "MERGED", AuthorLogin: "octocat", CreatedAt: "2025-12-02T00:00:00Z", MergedAt: "2025-12-04T00:00:00Z",
+		TimeToFirstReviewHours: ptr(4), TimeToApprovalHours: ptr(24), ReviewRounds: 2, Threads: 2, ResolvedThreads: 2, OutdatedThreads: 1,
+	}, stats.Pulls[1])
+}
+
+func TestComputeCachesMergedPulls(t *testing.T) {
+	api, fetches := fixture(t)
+	service := NewService(api)
+	cache := NewCache(t.TempDir())
+	opts := Options{Since: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), Cache: cache}
+	repo := resolver.Identity{Owner: "octo", Repo: "demo", Host: "github.com"}
+
+	first, err := service.Compute(repo, opts)
+	require.NoError(t, err)
+	second, err := service.Compute(repo, opts)
+	require.NoError(t, err)
+
+	// Open pull requests are always refetched; merged ones come from the cache.
+	assert.Equal(t, map[int]int{3: 2, 2: 1}, fetches())
+	assert.Equal(t, first.Reviewers, second.Reviewers)
+	assert.Equal(t, first.Pulls[1], second.Pulls[1])
+
+	_, ok := cache.Load(resolver.Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 2}, time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok, "entries for a different updatedAt are stale")
+}
+
+func TestComputeIgnoresCacheWriteFailures(t *testing.T) {
+	api, _ := fixture(t)
+	// A regular file where the cache directory should be makes every write fail.
+	dir := filepath.Join(t.TempDir(), "cache")
+	require.NoError(t, os.WriteFile(dir, nil, 0o600))
+
+	stats, err := NewService(api).Compute(resolver.Identity{Owner: "octo", Repo: "demo", Host: "github.com"}, Options{
+		Since: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+		Cache: NewCache(dir),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, PullCounts{Total: 2, Open: 1, Merged: 1}, stats.PullRequests)
+}
+
+func ptr(value float64) *float64 {
+	return &value
+}
@@ -0,0 +1,121 @@
+package templates
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Template is a named comment body from the config.
+type Template struct {
+	Name string `json:"name"`
+	Body string `json:"body"`
+}
+
+// Library holds the templates available to a command.
+type Library map[string]string
+
+// List returns the templates sorted by name.
+func (l Library) List() []Template {
+	list := make([]Template, 0, len(l))
+	for name, body := range l {
+		list = append(list, Template{Name: name, Body: body})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Get returns the named template.
+func (l Library) Get(name string) (Template, error) {
+	body, ok := l[name]
+	if !ok {
+		if len(l) == 0 {
+			return Template{}, fmt.Errorf("unknown template %q: no templates are configured", name)
+		}
+		names := make([]string, 0, len(l))
+		for _, t := range l.List() {
+			names = append(names, t.Name)
+		}
+		return Template{}, fmt.Errorf("unknown template %q (defined: %s)", name, strings.Join(names, ", "))
+	}
+	return Template{Name: name, Body: body}, nil
+}
+
+// Data is what a template can reference: {{.Path}}, {{.Line}},
+// {{.StartLine}}, {{.Repo}}, {{.Number}}, {{.Author}}, {{.Participants}}, and
+// {{.Vars.key}} for --var values. Author and Participants are only looked up
+// when a template uses them.
+type Data struct {
+	Path      string
+	Line      int
+	StartLine int
+	Repo      string
+	Number    int
+	Vars      map[string]string
+
+	AuthorFunc       func() (string, error)
+	ParticipantsFunc func() ([]string, error)
+}
+
+// Author is the login of the pull request author.
+func (d Data) Author() (string, error) {
+	if d.AuthorFunc == nil {
+		return "", nil
+	}
+	return d.AuthorFunc()
+}
+
+// Participants are the logins that commented on the thread, in order of
+// their first comment.
+func (d Data) Participants() ([]string, error) {
+	if d.ParticipantsFunc == nil {
+		return []string{}, nil
+	}
+	return d.ParticipantsFunc()
+}
+
+var funcs = template.FuncMap{
+	"join": strings.Join,
+	"mention": func(logins []string) string {
+		mentions := make([]string, 0, len(logins))
+		for _, login := range logins {
+			mentions = append(mentions, "@"+login)
+		}
+		return strings.Join(mentions, " ")
+	},
+}
+
+// Render executes the template. Referencing a --var that was not given, or
+// rendering blank text, is an error.
+func (t Template) Render(data Data) (string, error) {
+	parsed, err := template.New(t.Name).Funcs(funcs).Option("missingkey=error").Parse(t.Body)
+	if err != nil {
+		return "", fmt.Errorf("parse template %q: %w", t.Name, err)
+	}
+	if data.Vars == nil {
+		data.Vars = map[string]string{}
+	}
+	var out strings.Builder
+	if err := parsed.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("render template %q: %w", t.Name, err)
+	}
+	if strings.TrimSpace(out.String()) == "" {
+		return "", fmt.Errorf("template %q rendered an empty body", t.Name)
+	}
+	return out.String(), nil
+}
+
+// ParseVars parses --var key=value pairs.
+func ParseVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var %q: expected key=value", pair)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
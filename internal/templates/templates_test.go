@@ -0,0 +1,66 @@
+package templates
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	lookups := 0
+	data := Data{
+		Path: "internal/service.go",
+		Line: 42,
+		Vars: map[string]string{"wrap": "fmt.Errorf"},
+		AuthorFunc: func() (string, error) {
+			lookups++
+			return "alice", nil
+		},
+		ParticipantsFunc: func() ([]string, error) { return []string{"bob", "carol"}, nil },
+	}
+
+	body, err := Template{Name: "wrap", Body: "{{.Path}}:{{.Line}}: wrap with {{.Vars.wrap}} (cc {{mention .Participants}}, @{{.Author}})"}.Render(data)
+	require.NoError(t, err)
+	assert.Equal(t, "internal/service.go:42: wrap with fmt.Errorf (cc @bob @carol, @alice)", body)
+	assert.Equal(t, 1, lookups)
+
+	_, err = Template{Name: "plain", Body: "Missing tests for {{.Path}}."}.Render(data)
+	require.NoError(t, err)
+	assert.Equal(t, 1, lookups, "author is only looked up when referenced")
+}
+
+func TestRenderErrors(t *testing.T) {
+	_, err := Template{Name: "vars", Body: "{{.Vars.missing}}"}.Render(Data{})
+	require.ErrorContains(t, err, `render template "vars"`)
+	require.ErrorContains(t, err, `map has no entry for key "missing"`)
+
+	_, err = Template{Name: "bad", Body: "{{.Path"}.Render(Data{})
+	require.ErrorContains(t, err, `parse template "bad"`)
+
+	_, err = Template{Name: "blank", Body: "{{if false}}x{{end}}  "}.Render(Data{})
+	require.EqualError(t, err, `template "blank" rendered an empty body`)
+
+	_, err = Template{Name: "author", Body: "{{.Author}}"}.Render(Data{AuthorFunc: func() (string, error) { return "", errors.New("offline") }})
+	require.ErrorContains(t, err, "offline")
+}
+
+func TestLibrary(t *testing.T) {
+	library := Library{"tests": "Please add tests.", "naming": "Consider renaming."}
+	assert.Equal(t, []Template{{Name: "naming", Body: "Consider renaming."}, {Name: "tests", Body: "Please add tests."}}, library.List())
+
+	_, err := library.Get("wrap")
+	require.EqualError(t, err, `unknown template "wrap" (defined: naming, tests)`)
+	_, err = Library{}.Get("wrap")
+	require.EqualError(t, err, `unknown template "wrap": no templates are configured`)
+}
+
+func TestParseVars(t *testing.T) {
+	vars, err := ParseVars([]string{"fn=fmt.Errorf", "note=a=b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"fn": "fmt.Errorf", "note": "a=b"}, vars)
+
+	_, err = ParseVars([]string{"novalue"})
+	require.EqualError(t, err, `invalid --var "novalue": expected key=value`)
+}
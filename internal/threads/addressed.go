@@ -0,0 +1,249 @@
+package threads
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/commits"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+// AddressedOptions selects the baseline after which commits are considered.
+// Without a baseline, each thread is compared against the commits made after
+// its last comment.
+type AddressedOptions struct {
+	// SinceReview uses the commit a review (PRR_…) was submitted against as the baseline.
+	SinceReview string
+	// SinceCommit uses the given pull request commit SHA (or unique prefix) as the baseline.
+	SinceCommit string
+}
+
+// AddressedThread is an unresolved thread whose code was changed by later commits.
+type AddressedThread struct {
+	ThreadID         string            `json:"threadId"`
+	Path             string            `json:"path"`
+	Line             *int              `json:"line,omitempty"`
+	StartLine        *int              `json:"startLine,omitempty"`
+	IsOutdated       bool              `json:"isOutdated"`
+	LastCommentAt    time.Time         `json:"lastCommentAt"`
+	ViewerCanResolve bool              `json:"viewerCanResolve"`
+	Commits          []AddressedCommit `json:"commits"`
+}
+
+// AddressedCommit is a commit that touched a thread's lines.
+type AddressedCommit struct {
+	SHA         string    `json:"sha"`
+	Headline    string    `json:"headline,omitempty"`
+	CommittedAt time.Time `json:"committedAt"`
+}
+
+// Addressed reports unresolved threads whose path and line range were modified
+// by commits pushed after the baseline. Line ranges are tracked backwards from
+// the pull request head through each commit's diff, so edits elsewhere in the
+// file do not count. Outdated threads no longer map onto the head, so any later
+// commit touching their file is reported.
+func (s *Service) Addressed(pr resolver.Identity, opts AddressedOptions) ([]AddressedThread, error) {
+	sinceReview := strings.TrimSpace(opts.SinceReview)
+	sinceCommit := strings.TrimSpace(opts.SinceCommit)
+	if sinceReview != "" && sinceCommit != "" {
+		return nil, errors.New("since review and since commit are mutually exclusive")
+	}
+
+	matched, err := s.selectThreads(pr, ListOptions{OnlyUnresolved: true})
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher := commits.NewFetcher(s.API)
+	history, err := fetcher.PullCommits(pr)
+	if err != nil {
+		return nil, err
+	}
+
+	if sinceReview != "" {
+		sinceCommit, err = s.reviewCommit(pr, sinceReview)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var sinceBaseline []commits.Commit
+	if sinceCommit != "" {
+		var matches []int
+		for i, commit := range history {
+			if strings.HasPrefix(commit.SHA, sinceCommit) {
+				matches = append(matches, i)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("commit %s is not part of pull request %d", sinceCommit, pr.Number)
+		case 1:
+			sinceBaseline = history[matches[0]+1:]
+		default:
+			shas := make([]string, len(matches))
+			for i, index := range matches {
+				shas[i] = history[index].SHA
+			}
+			return nil, fmt.Errorf("commit %s is ambiguous in pull request %d: matches %s", sinceCommit, pr.Number, strings.Join(shas, ", "))
+		}
+	}
+
+	addressed := make([]AddressedThread, 0)
+	for _, entry := range matched {
+		comments := entry.node.Comments.Nodes
+		if len(comments) == 0 {
+			continue
+		}
+		lastComment := comments[0].CreatedAt
+		for _, comment := range comments[1:] {
+			if comment.CreatedAt.After(lastComment) {
+				lastComment = comment.CreatedAt
+			}
+		}
+
+		candidates := sinceBaseline
+		if sinceCommit == "" {
+			candidates = commitsAfter(history, lastComment)
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		touching, err := touchingCommits(fetcher, pr, entry, candidates)
+		if err != nil {
+			return nil, err
+		}
+		if len(touching) == 0 {
+			continue
+		}
+
+		addressed = append(addressed, AddressedThread{
+			ThreadID:         entry.ThreadID,
+			Path:             entry.Path,
+			Line:             entry.Line,
+			StartLine:        entry.node.StartLine,
+			IsOutdated:       entry.IsOutdated,
+			LastCommentAt:    lastComment,
+			ViewerCanResolve: entry.node.ViewerCanResolve,
+			Commits:          touching,
+		})
+	}
+
+	sort.SliceStable(addressed, func(i, j int) bool {
+		if addressed[i].Path == addressed[j].Path {
+			return addressed[i].ThreadID < addressed[j].ThreadID
+		}
+		return addressed[i].Path < addressed[j].Path
+	})
+
+	return addressed, nil
+}
+
+// commitsAfter returns the trailing commits made after the cutoff. The walk
+// stops at the first older commit so the result is a contiguous suffix that can
+// be traced back from the head.
+func commitsAfter(history []commits.Commit, cutoff time.Time) []commits.Commit {
+	start := len(history)
+	for start > 0 && history[start-1].CommittedAt.After(cutoff) {
+		start--
+	}
+	return history[start:]
+}
+
+// touchingCommits walks the candidate commits from newest to oldest, mapping the
+// thread's line range back through each diff and recording commits that change it.
+func touchingCommits(fetcher *commits.Fetcher, pr resolver.Identity, entry matchedThread, candidates []commits.Commit) ([]AddressedCommit, error) {
+	path := entry.Path
+	var lines *commits.LineRange
+	if entry.Line != nil && !entry.IsOutdated {
+		start := *entry.Line
+		if entry.node.StartLine != nil && *entry.node.StartLine <= start {
+			start = *entry.node.StartLine
+		}
+		lines = &commits.LineRange{Start: start, End: *entry.Line}
+	}
+
+	var touching []AddressedCommit
+	for i := len(candidates) - 1; i >= 0; i-- {
+		commit := candidates[i]
+		files, err := fetcher.CommitFiles(pr, commit.SHA)
+		if err != nil {
+			return nil, err
+		}
+
+		var file *commits.File
+		for idx := range files {
+			if files[idx].Filename == path {
+				file = &files[idx]
+				break
+			}
+		}
+		if file == nil {
+			continue
+		}
+
+		touched := true
+		if lines != nil && file.Patch != "" {
+			changes, err := commits.ParseChanges(file.Patch)
+			if err != nil {
+				return nil, fmt.Errorf("parse diff of %s in %s: %w", path, commit.SHA, err)
+			}
+			touched = commits.Touches(changes, *lines)
+			mapped := commits.MapToOld(changes, *lines)
+			lines = &mapped
+		}
+		if touched {
+			touching = append(touching, AddressedCommit{SHA: commit.SHA, Headline: commit.Headline, CommittedAt: commit.CommittedAt})
+		}
+
+		if file.Status == "added" {
+			break
+		}
+		if file.Status == "renamed" && file.PreviousFilename != "" {
+			path = file.PreviousFilename
+		}
+	}
+
+	// Report commits in chronological order.
+	for i, j := 0, len(touching)-1; i < j; i, j = i+1, j-1 {
+		touching[i], touching[j] = touching[j], touching[i]
+	}
+	return touching, nil
+}
+
+func (s *Service) reviewCommit(pr resolver.Identity, reviewID string) (string, error) {
+	variables := map[string]interface{}{"id": reviewID}
+	var resp struct {
+		Node *struct {
+			ID     string `json:"id"`
+			Commit *struct {
+				OID string `json:"oid"`
+			} `json:"commit"`
+		} `json:"node"`
+	}
+	if err := s.API.GraphQL(reviewCommitQuery, variables, &resp); err != nil {
+		return "", err
+	}
+	if resp.Node == nil || resp.Node.ID == "" {
+		return "", fmt.Errorf("review %s not found on %s", reviewID, pr.Host)
+	}
+	if resp.Node.Commit == nil || strings.TrimSpace(resp.Node.Commit.OID) == "" {
+		return "", fmt.Errorf("review %s has no associated commit", reviewID)
+	}
+	return strings.TrimSpace(resp.Node.Commit.OID), nil
+}
+
+const reviewCommitQuery = `
+query ReviewCommit($id: ID!) {
+  node(id: $id) {
+    ... on PullRequestReview {
+      id
+      commit { oid }
+    }
+  }
+}
+`
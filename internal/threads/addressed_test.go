@@ -0,0 +1,136 @@
+package threads
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addressedFake(t *testing.T) *fakeAPI {
+	lastComment := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	thread := func(id, path string, line int, start interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"id":               id,
+			"isResolved":       false,
+			"isOutdated":       false,
+			"path":             path,
+			"line":             line,
+			"startLine":        start,
+			"viewerCanResolve": true,
+			"comments": map[string]interface{}{
+				"nodes": []map[string]interface{}{
+					{"createdAt": lastComment.Add(-time.Hour), "updatedAt": lastComment.Add(-time.Hour)},
+					{"createdAt": lastComment, "updatedAt": lastComment},
+				},
+			},
+		}
+	}
+
+	commit := func(sha string, hour int) map[string]interface{} {
+		return map[string]interface{}{
+			"sha": sha,
+			"commit": map[string]interface{}{
+				"message":   "Commit " + sha + "\n\nDetails",
+				"committer": map[string]interface{}{"date": time.Date(2025, 12, 1, hour, 0, 0, 0, time.UTC)},
+			},
+		}
+	}
+
+	return &fakeAPI{
+		restFunc: restStub(t, "octo", "demo", "octo/demo", 5, "PR_node", func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+			switch path {
+			case "repos/octo/demo/pulls/5/commits":
+				require.Equal(t, "1", params["page"])
+				return assign(result, []map[string]interface{}{
+					commit("c0aaaaaaaa", 9),
+					commit("c1bbbbbbbb", 11),
+					commit("c2cccccccc", 12),
+				})
+			case "repos/octo/demo/commits/c1bbbbbbbb":
+				return assign(result, map[string]interface{}{
+					"sha": "c1bbbbbbbb",
+					"files": []map[string]interface{}{
+						{"filename": "a.go", "status": "modified", "patch": "@@ -6,5 +6,5 @@\n ctx6\n ctx7\n-old8\n+new8\n ctx9\n ctx10"},
+					},
+				})
+			case "repos/octo/demo/commits/c2cccccccc":
+				return assign(result, map[string]interface{}{
+					"sha": "c2cccccccc",
+					"files": []map[string]interface{}{
+						{"filename": "a.go", "status": "modified", "patch": "@@ -1,3 +1,5 @@\n+x\n+y\n line1\n line2\n line3"},
+					},
+				})
+			default:
+				return errors.New("unexpected REST path: " + path)
+			}
+		}),
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			require.Equal(t, listThreadsQuery, query)
+			return assign(result, map[string]interface{}{
+				"node": map[string]interface{}{
+					"reviewThreads": map[string]interface{}{
+						"nodes": []map[string]interface{}{
+							thread("T1", "a.go", 10, 9),
+							thread("T2", "b.go", 5, nil),
+							thread("T3", "a.go", 100, nil),
+						},
+						"pageInfo": map[string]interface{}{"hasNextPage": false},
+					},
+				},
+			})
+		},
+	}
+}
+
+func TestAddressedTracksLinesThroughLaterCommits(t *testing.T) {
+	svc := NewService(addressedFake(t))
+
+	addressed, err := svc.Addressed(resolver.Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 5}, AddressedOptions{})
+	require.NoError(t, err)
+	require.Len(t, addressed, 1)
+
+	thread := addressed[0]
+	assert.Equal(t, "T1", thread.ThreadID)
+	assert.Equal(t, "a.go", thread.Path)
+	require.NotNil(t, thread.StartLine)
+	assert.Equal(t, 9, *thread.StartLine)
+	assert.Equal(t, time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC), thread.LastCommentAt)
+	require.Len(t, thread.Commits, 1)
+	assert.Equal(t, "c1bbbbbbbb", thread.Commits[0].SHA)
+	assert.Equal(t, "Commit c1bbbbbbbb", thread.Commits[0].Headline)
+}
+
+func TestAddressedSinceCommitLimitsCandidates(t *testing.T) {
+	svc := NewService(addressedFake(t))
+
+	addressed, err := svc.Addressed(resolver.Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 5}, AddressedOptions{SinceCommit: "c1bbb"})
+	require.NoError(t, err)
+	assert.Empty(t, addressed)
+	assert.NotNil(t, addressed)
+}
+
+func TestAddressedUnknownCommit(t *testing.T) {
+	svc := NewService(addressedFake(t))
+
+	_, err := svc.Addressed(resolver.Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 5}, AddressedOptions{SinceCommit: "deadbeef"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "commit deadbeef is not part of pull request 5")
+}
+
+func TestAddressedAmbiguousCommit(t *testing.T) {
+	svc := NewService(addressedFake(t))
+
+	_, err := svc.Addressed(resolver.Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 5}, AddressedOptions{SinceCommit: "c"})
+	require.EqualError(t, err, "commit c is ambiguous in pull request 5: matches c0aaaaaaaa, c1bbbbbbbb, c2cccccccc")
+}
+
+func TestAddressedRejectsBothBaselines(t *testing.T) {
+	svc := NewService(&fakeAPI{})
+
+	_, err := svc.Addressed(resolver.Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 5}, AddressedOptions{SinceCommit: "abc", SinceReview: "PRR_1"})
+	require.EqualError(t, err, "since review and since commit are mutually exclusive")
+}
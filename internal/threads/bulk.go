@@ -0,0 +1,142 @@
+package threads
+
+import (
+	"sync"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+const defaultBulkConcurrency = 4
+
+// Bulk operation statuses reported per thread.
+const (
+	BulkStatusPlanned    = "planned"
+	BulkStatusResolved   = "resolved"
+	BulkStatusUnresolved = "unresolved"
+	BulkStatusSkipped    = "skipped"
+	BulkStatusFailed     = "failed"
+)
+
+// BulkOptions selects threads through list filters and controls mutation execution.
+type BulkOptions struct {
+	Filter ListOptions
+	// DryRun reports the planned changes without mutating any thread.
+	DryRun bool
+	// Concurrency bounds the number of in-flight mutations (defaults to 4).
+	Concurrency int
+}
+
+// BulkItem describes the outcome for a single targeted thread.
+type BulkItem struct {
+	ThreadNodeID string `json:"thread_node_id"`
+	Path         string `json:"path"`
+	Line         *int   `json:"line,omitempty"`
+	Status       string `json:"status"`
+	IsResolved   bool   `json:"is_resolved"`
+	Error        string `json:"error,omitempty"`
+}
+
+// BulkResult aggregates per-thread outcomes of a bulk resolve/unresolve.
+type BulkResult struct {
+	DryRun  bool       `json:"dry_run"`
+	Results []BulkItem `json:"results"`
+}
+
+// Failed returns the number of threads whose mutation failed.
+func (r BulkResult) Failed() int {
+	count := 0
+	for _, item := range r.Results {
+		if item.Status == BulkStatusFailed {
+			count++
+		}
+	}
+	return count
+}
+
+// ResolveMatching resolves every unresolved thread that matches the filters.
+func (s *Service) ResolveMatching(pr resolver.Identity, opts BulkOptions) (BulkResult, error) {
+	return s.changeResolutionMatching(pr, opts, true)
+}
+
+// UnresolveMatching reopens every resolved thread that matches the filters.
+func (s *Service) UnresolveMatching(pr resolver.Identity, opts BulkOptions) (BulkResult, error) {
+	return s.changeResolutionMatching(pr, opts, false)
+}
+
+func (s *Service) changeResolutionMatching(pr resolver.Identity, opts BulkOptions, resolve bool) (BulkResult, error) {
+	matched, err := s.selectThreads(pr, opts.Filter)
+	if err != nil {
+		return BulkResult{}, err
+	}
+
+	result := BulkResult{DryRun: opts.DryRun, Results: make([]BulkItem, 0, len(matched))}
+	pending := make([]int, 0, len(matched))
+
+	for _, entry := range matched {
+		// Threads already in the desired state are not targets.
+		if entry.IsResolved == resolve {
+			continue
+		}
+
+		item := BulkItem{
+			ThreadNodeID: entry.ThreadID,
+			Path:         entry.Path,
+			Line:         entry.Line,
+			IsResolved:   entry.IsResolved,
+		}
+
+		switch {
+		case resolve && !entry.node.ViewerCanResolve:
+			item.Status = BulkStatusSkipped
+			item.Error = "viewer cannot resolve this thread"
+		case !resolve && !entry.node.ViewerCanUnresolve:
+			item.Status = BulkStatusSkipped
+			item.Error = "viewer cannot unresolve this thread"
+		case opts.DryRun:
+			item.Status = BulkStatusPlanned
+		default:
+			pending = append(pending, len(result.Results))
+		}
+		result.Results = append(result.Results, item)
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, idx := range pending {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(item *BulkItem) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var (
+				action ActionResult
+				err    error
+			)
+			if resolve {
+				action, err = s.performResolve(item.ThreadNodeID)
+			} else {
+				action, err = s.performUnresolve(item.ThreadNodeID)
+			}
+			if err != nil {
+				item.Status = BulkStatusFailed
+				item.Error = err.Error()
+				return
+			}
+			item.IsResolved = action.IsResolved
+			if action.IsResolved {
+				item.Status = BulkStatusResolved
+			} else {
+				item.Status = BulkStatusUnresolved
+			}
+		}(&result.Results[idx])
+	}
+	wg.Wait()
+
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package threads
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bulkThreadsPayload() map[string]interface{} {
+	return map[string]interface{}{
+		"node": map[string]interface{}{
+			"reviewThreads": map[string]interface{}{
+				"nodes": []map[string]interface{}{
+					{"id": "T_ok", "isResolved": false, "isOutdated": true, "path": "internal/a.go", "viewerCanResolve": true, "comments": map[string]interface{}{"nodes": []interface{}{}}},
+					{"id": "T_fail", "isResolved": false, "isOutdated": true, "path": "internal/b.go", "viewerCanResolve": true, "comments": map[string]interface{}{"nodes": []interface{}{}}},
+					{"id": "T_denied", "isResolved": false, "isOutdated": true, "path": "internal/c.go", "viewerCanResolve": false, "comments": map[string]interface{}{"nodes": []interface{}{}}},
+					{"id": "T_done", "isResolved": true, "isOutdated": true, "path": "internal/d.go", "viewerCanResolve": true, "viewerCanUnresolve": true, "comments": map[string]interface{}{"nodes": []interface{}{}}},
+					{"id": "T_current", "isResolved": false, "isOutdated": false, "path": "internal/e.go", "viewerCanResolve": true, "comments": map[string]interface{}{"nodes": []interface{}{}}},
+				},
+				"pageInfo": map[string]interface{}{"hasNextPage": false},
+			},
+		},
+	}
+}
+
+func TestResolveMatchingMutatesTargets(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		mutated []string
+	)
+	svc := &Service{}
+	svc.API = &fakeAPI{
+		restFunc: restStub(t, "octo", "demo", "octo/demo", 5, "PR_node", nil),
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			switch query {
+			case listThreadsQuery:
+				return assign(result, bulkThreadsPayload())
+			case resolveThreadMutation:
+				id := variables["threadId"].(string)
+				mu.Lock()
+				mutated = append(mutated, id)
+				mu.Unlock()
+				if id == "T_fail" {
+					return errors.New("boom")
+				}
+				return assign(result, map[string]interface{}{
+					"resolveReviewThread": map[string]interface{}{
+						"thread": map[string]interface{}{"id": id, "isResolved": true},
+					},
+				})
+			default:
+				return errors.New("unexpected query")
+			}
+		},
+	}
+
+	outdated := true
+	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
+	res, err := svc.ResolveMatching(identity, BulkOptions{Filter: ListOptions{Outdated: &outdated}, Concurrency: 2})
+	require.NoError(t, err)
+	assert.False(t, res.DryRun)
+	assert.ElementsMatch(t, []string{"T_ok", "T_fail"}, mutated)
+
+	byID := map[string]BulkItem{}
+	for _, item := range res.Results {
+		byID[item.ThreadNodeID] = item
+	}
+	require.Len(t, byID, 3)
+	assert.Equal(t, BulkStatusResolved, byID["T_ok"].Status)
+	assert.True(t, byID["T_ok"].IsResolved)
+	assert.Equal(t, BulkStatusFailed, byID["T_fail"].Status)
+	assert.Equal(t, "boom", byID["T_fail"].Error)
+	assert.Equal(t, BulkStatusSkipped, byID["T_denied"].Status)
+	assert.Equal(t, 1, res.Failed())
+}
+
+func TestResolveMatchingDryRunDoesNotMutate(t *testing.T) {
+	svc := &Service{}
+	svc.API = &fakeAPI{
+		restFunc: restStub(t, "octo", "demo", "octo/demo", 5, "PR_node", nil),
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			require.Equal(t, listThreadsQuery, query, "dry run must not issue mutations")
+			return assign(result, bulkThreadsPayload())
+		},
+	}
+
+	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
+	res, err := svc.ResolveMatching(identity, BulkOptions{Filter: ListOptions{Paths: []string{"internal/*.go"}}, DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, res.DryRun)
+
+	statuses := map[string]string{}
+	for _, item := range res.Results {
+		statuses[item.ThreadNodeID] = item.Status
+	}
+	assert.Equal(t, map[string]string{
+		"T_ok":      BulkStatusPlanned,
+		"T_fail":    BulkStatusPlanned,
+		"T_denied":  BulkStatusSkipped,
+		"T_current": BulkStatusPlanned,
+	}, statuses)
+}
+
+func TestUnresolveMatchingTargetsResolvedThreads(t *testing.T) {
+	svc := &Service{}
+	svc.API = &fakeAPI{
+		restFunc: restStub(t, "octo", "demo", "octo/demo", 5, "PR_node", nil),
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			switch query {
+			case listThreadsQuery:
+				return assign(result, bulkThreadsPayload())
+			case unresolveThreadMutation:
+				require.Equal(t, "T_done", variables["threadId"])
+				return assign(result, map[string]interface{}{
+					"unresolveReviewThread": map[string]interface{}{
+						"thread": map[string]interface{}{"id": "T_done", "isResolved": false},
+					},
+				})
+			default:
+				return errors.New("unexpected query")
+			}
+		},
+	}
+
+	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
+	res, err := svc.UnresolveMatching(identity, BulkOptions{})
+	require.NoError(t, err)
+	require.Len(t, res.Results, 1)
+	assert.Equal(t, BulkStatusUnresolved, res.Results[0].Status)
+	assert.False(t, res.Results[0].IsResolved)
+}
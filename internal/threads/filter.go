@@ -0,0 +1,143 @@
+package threads
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// threadFilter evaluates ListOptions against raw thread nodes. Every configured
+// criterion must match (AND semantics); multiple path globs match if any does.
+type threadFilter struct {
+	opts  ListOptions
+	paths []string
+}
+
+func newThreadFilter(opts ListOptions) (*threadFilter, error) {
+	filter := &threadFilter{opts: opts}
+	for _, raw := range opts.Paths {
+		pattern := strings.TrimSpace(raw)
+		if pattern == "" {
+			continue
+		}
+		if err := validateGlob(pattern); err != nil {
+			return nil, fmt.Errorf("invalid path glob %q: %w", raw, err)
+		}
+		filter.paths = append(filter.paths, pattern)
+	}
+	if opts.Since != nil && opts.Before != nil && !opts.Since.Before(*opts.Before) {
+		return nil, fmt.Errorf("since (%s) must be earlier than before (%s)", opts.Since.Format(time.RFC3339), opts.Before.Format(time.RFC3339))
+	}
+	return filter, nil
+}
+
+// match reports whether the node satisfies the non-viewer filters. updatedAt is
+// the latest comment timestamp, or nil when the thread has no comments.
+func (f *threadFilter) match(node threadNode, updatedAt *time.Time) bool {
+	opts := f.opts
+
+	if opts.OnlyUnresolved && node.IsResolved {
+		return false
+	}
+	if opts.Outdated != nil && node.IsOutdated != *opts.Outdated {
+		return false
+	}
+	if len(f.paths) > 0 && !matchAnyGlob(f.paths, node.Path) {
+		return false
+	}
+	if author := strings.TrimSpace(opts.Author); author != "" {
+		if len(node.Comments.Nodes) == 0 || !strings.EqualFold(node.Comments.Nodes[0].authorLogin(), author) {
+			return false
+		}
+	}
+	if participant := strings.TrimSpace(opts.Participant); participant != "" {
+		found := false
+		for _, comment := range node.Comments.Nodes {
+			if strings.EqualFold(comment.authorLogin(), participant) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if resolvedBy := strings.TrimSpace(opts.ResolvedBy); resolvedBy != "" {
+		if node.ResolvedBy == nil || !strings.EqualFold(node.ResolvedBy.Login, resolvedBy) {
+			return false
+		}
+	}
+	if opts.Since != nil || opts.Before != nil {
+		if updatedAt == nil {
+			return false
+		}
+		if opts.Since != nil && updatedAt.Before(*opts.Since) {
+			return false
+		}
+		if opts.Before != nil && !updatedAt.Before(*opts.Before) {
+			return false
+		}
+	}
+	if opts.LastSeen != nil {
+		if seenAt, ok := opts.LastSeen[node.ID]; ok && (updatedAt == nil || !updatedAt.After(seenAt)) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchAnyGlob(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches slash-separated paths against a glob pattern. Segments use
+// path.Match syntax, and a segment consisting solely of "**" matches zero or
+// more whole path segments.
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		ok, err := path.Match(pattern[0], name[0])
+		if err != nil || !ok {
+			return false
+		}
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+	return len(name) == 0
+}
+
+func validateGlob(pattern string) error {
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == "**" {
+			continue
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package threads
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchGlob(t *testing.T) {
+	cases := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"internal/**/*.go", "internal/threads/service.go", true},
+		{"internal/**/*.go", "internal/a/b/c.go", true},
+		{"internal/**/*.go", "internal/main.go", true},
+		{"internal/**/*.go", "cmd/main.go", false},
+		{"**/*_test.go", "cmd/threads_test.go", true},
+		{"**", "anything/at/all", true},
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/main.go", false},
+		{"docs/*.md", "docs/USAGE.md", true},
+		{"docs/?.md", "docs/USAGE.md", false},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, matchGlob(tc.pattern, tc.name), "%s vs %s", tc.pattern, tc.name)
+	}
+}
+
+func TestNewThreadFilterRejectsInvalidInput(t *testing.T) {
+	_, err := newThreadFilter(ListOptions{Paths: []string{"internal/[.go"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid path glob")
+
+	since := time.Date(2025, 12, 2, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	_, err = newThreadFilter(ListOptions{Since: &since, Before: &before})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be earlier than")
+}
+
+func TestServiceListRichFilters(t *testing.T) {
+	comment := func(login string, ts time.Time) map[string]interface{} {
+		return map[string]interface{}{
+			"viewerDidAuthor": false,
+			"updatedAt":       ts,
+			"databaseId":      1,
+			"author":          map[string]interface{}{"login": login},
+		}
+	}
+	day := func(d int) time.Time { return time.Date(2025, 12, d, 12, 0, 0, 0, time.UTC) }
+
+	svc := &Service{}
+	svc.API = &fakeAPI{
+		restFunc: restStub(t, "octo", "demo", "octo/demo", 5, "PR_node", nil),
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			payload := map[string]interface{}{
+				"node": map[string]interface{}{
+					"reviewThreads": map[string]interface{}{
+						"nodes": []map[string]interface{}{
+							{
+								"id":         "T_match",
+								"isResolved": true,
+								"isOutdated": true,
+								"path":       "internal/threads/service.go",
+								"resolvedBy": map[string]interface{}{"login": "carol"},
+								"comments": map[string]interface{}{"nodes": []map[string]interface{}{
+									comment("alice", day(2)), comment("bob", day(4)),
+								}},
+							},
+							{
+								"id":         "T_wrong_path",
+								"isResolved": true,
+								"isOutdated": true,
+								"path":       "cmd/threads.go",
+								"resolvedBy": map[string]interface{}{"login": "carol"},
+								"comments": map[string]interface{}{"nodes": []map[string]interface{}{
+									comment("alice", day(2)), comment("bob", day(4)),
+								}},
+							},
+							{
+								"id":         "T_wrong_author",
+								"isResolved": true,
+								"isOutdated": true,
+								"path":       "internal/report/builder.go",
+								"resolvedBy": map[string]interface{}{"login": "carol"},
+								"comments": map[string]interface{}{"nodes": []map[string]interface{}{
+									comment("bob", day(2)), comment("alice", day(4)),
+								}},
+							},
+							{
+								"id":         "T_not_outdated",
+								"isResolved": true,
+								"isOutdated": false,
+								"path":       "internal/report/builder.go",
+								"resolvedBy": map[string]interface{}{"login": "carol"},
+								"comments": map[string]interface{}{"nodes": []map[string]interface{}{
+									comment("alice", day(2)), comment("bob", day(4)),
+								}},
+							},
+							{
+								"id":         "T_too_old",
+								"isResolved": true,
+								"isOutdated": true,
+								"path":       "internal/report/builder.go",
+								"resolvedBy": map[string]interface{}{"login": "carol"},
+								"comments": map[string]interface{}{"nodes": []map[string]interface{}{
+									comment("alice", day(1)), comment("bob", day(1)),
+								}},
+							},
+							{
+								"id":         "T_other_resolver",
+								"isResolved": true,
+								"isOutdated": true,
+								"path":       "internal/report/builder.go",
+								"resolvedBy": map[string]interface{}{"login": "dave"},
+								"comments": map[string]interface{}{"nodes": []map[string]interface{}{
+									comment("alice", day(2)), comment("bob", day(4)),
+								}},
+							},
+							{
+								"id":         "T_no_participant",
+								"isResolved": true,
+								"isOutdated": true,
+								"path":       "internal/report/builder.go",
+								"resolvedBy": map[string]interface{}{"login": "carol"},
+								"comments": map[string]interface{}{"nodes": []map[string]interface{}{
+									comment("alice", day(4)),
+								}},
+							},
+						},
+						"pageInfo": map[string]interface{}{"hasNextPage": false},
+					},
+				},
+			}
+			return assign(result, payload)
+		},
+	}
+
+	outdated := true
+	since := day(3)
+	before := day(5)
+	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
+	threads, err := svc.List(identity, ListOptions{
+		Paths:       []string{"internal/**/*.go"},
+		Author:      "ALICE",
+		Participant: "bob",
+		Outdated:    &outdated,
+		Since:       &since,
+		Before:      &before,
+		ResolvedBy:  "carol",
+	})
+	require.NoError(t, err)
+	require.Len(t, threads, 1)
+	assert.Equal(t, "T_match", threads[0].ThreadID)
+}
+
+func TestThreadFilterLastSeen(t *testing.T) {
+	seenAt := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	later := seenAt.Add(time.Minute)
+	filter, err := newThreadFilter(ListOptions{LastSeen: map[string]time.Time{"T_seen": seenAt}})
+	require.NoError(t, err)
+
+	assert.False(t, filter.match(threadNode{ID: "T_seen"}, &seenAt))
+	assert.False(t, filter.match(threadNode{ID: "T_seen"}, nil))
+	assert.True(t, filter.match(threadNode{ID: "T_seen"}, &later))
+	assert.True(t, filter.match(threadNode{ID: "T_unknown"}, &seenAt))
+}
+
+func TestThreadFilterMatchesDeletedAuthorsAsGhost(t *testing.T) {
+	node := threadNode{ID: "T_ghost"}
+	node.Comments.Nodes = []threadNodeComment{{}}
+
+	for _, opts := range []ListOptions{{Author: "ghost"}, {Participant: "Ghost"}} {
+		filter, err := newThreadFilter(opts)
+		require.NoError(t, err)
+		assert.True(t, filter.match(node, nil), "%+v", opts)
+	}
+}
@@ -21,10 +21,26 @@ func NewService(api ghcli.API) *Service {
 	return &Service{API: api}
 }
 
-// ListOptions configures list filtering.
+// ListOptions configures list filtering. All criteria combine with AND semantics.
 type ListOptions struct {
 	OnlyUnresolved bool
 	MineOnly       bool
+	// Paths keeps threads whose path matches any of the globs ("**" spans directories).
+	Paths []string
+	// Author keeps threads started by the given login.
+	Author string
+	// Participant keeps threads where the login authored any comment.
+	Participant string
+	// Outdated, when set, keeps only outdated (true) or current (false) threads.
+	Outdated *bool
+	// Since and Before bound the thread updatedAt timestamp (inclusive / exclusive).
+	Since  *time.Time
+	Before *time.Time
+	// ResolvedBy keeps threads resolved by the given login.
+	ResolvedBy string
+	// LastSeen, when non-nil, keeps threads with comment activity after the
+	// recorded timestamp; threads missing from the map count as new.
+	LastSeen map[string]time.Time
 }
 
 // Thread represents a normalized review thread payload for JSON output.
@@ -54,25 +70,47 @@ type pullContext struct {
 	nodeID   string
 }
 
+// matchedThread pairs a normalized thread with the raw node it was built from.
+type matchedThread struct {
+	Thread
+	node threadNode
+}
+
 // List fetches review threads for the provided pull request, applies filters, and returns sorted results.
 func (s *Service) List(pr resolver.Identity, opts ListOptions) ([]Thread, error) {
+	matched, err := s.selectThreads(pr, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	allThreads := make([]Thread, len(matched))
+	for i, entry := range matched {
+		allThreads[i] = entry.Thread
+	}
+	return allThreads, nil
+}
+
+// selectThreads fetches every review thread, applies the list filters, and sorts
+// matches by most recent activity.
+func (s *Service) selectThreads(pr resolver.Identity, opts ListOptions) ([]matchedThread, error) {
 	ctx, err := s.loadPullContext(pr)
 	if err != nil {
 		return nil, err
 	}
 
+	filter, err := newThreadFilter(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	nodes, err := s.collectThreads(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	allThreads := make([]Thread, 0)
+	allThreads := make([]matchedThread, 0)
 
 	for _, node := range nodes {
-		if opts.OnlyUnresolved && node.IsResolved {
-			continue
-		}
-
 		mine := node.ViewerCanResolve || node.ViewerCanUnresolve
 		var (
 			latest   time.Time
@@ -105,20 +143,27 @@ func (s *Service) List(pr resolver.Identity, opts ListOptions) ([]Thread, error)
 			updatedAt = &ts
 		}
 
+		if !filter.match(node, updatedAt) {
+			continue
+		}
+
 		var linePtr *int
 		if node.Line != nil {
 			value := *node.Line
 			linePtr = &value
 		}
 
-		allThreads = append(allThreads, Thread{
-			ThreadID:   node.ID,
-			IsResolved: node.IsResolved,
-			ResolvedBy: resolvedBy,
-			UpdatedAt:  updatedAt,
-			Path:       node.Path,
-			Line:       linePtr,
-			IsOutdated: node.IsOutdated,
+		allThreads = append(allThreads, matchedThread{
+			Thread: Thread{
+				ThreadID:   node.ID,
+				IsResolved: node.IsResolved,
+				ResolvedBy: resolvedBy,
+				UpdatedAt:  updatedAt,
+				Path:       node.Path,
+				Line:       linePtr,
+				IsOutdated: node.IsOutdated,
+			},
+			node: node,
 		})
 	}
 
@@ -172,20 +217,36 @@ type threadNode struct {
 	IsOutdated         bool   `json:"isOutdated"`
 	Path               string `json:"path"`
 	Line               *int   `json:"line"`
+	StartLine          *int   `json:"startLine"`
 	ViewerCanResolve   bool   `json:"viewerCanResolve"`
 	ViewerCanUnresolve bool   `json:"viewerCanUnresolve"`
 	ResolvedBy         *struct {
 		Login string `json:"login"`
 	} `json:"resolvedBy"`
 	Comments struct {
-		Nodes []struct {
-			ViewerDidAuthor bool      `json:"viewerDidAuthor"`
-			UpdatedAt       time.Time `json:"updatedAt"`
-			DatabaseID      int64     `json:"databaseId"`
-		} `json:"nodes"`
+		Nodes []threadNodeComment `json:"nodes"`
 	} `json:"comments"`
 }
 
+type threadNodeComment struct {
+	ViewerDidAuthor bool      `json:"viewerDidAuthor"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+	DatabaseID      int64     `json:"databaseId"`
+	Author          *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+}
+
+// authorLogin reports deleted accounts as GitHub's ghost login, matching the
+// other thread and report outputs.
+func (c threadNodeComment) authorLogin() string {
+	if c.Author == nil || c.Author.Login == "" {
+		return "ghost"
+	}
+	return c.Author.Login
+}
+
 func (s *Service) fetchThreads(nodeID string, after *string) (*threadsQueryResponse, error) {
 	variables := map[string]interface{}{
 		"id": nodeID,
@@ -362,6 +423,7 @@ query Threads($id: ID!, $after: String) {
           isOutdated
           path
           line
+          startLine
           viewerCanResolve
           viewerCanUnresolve
           resolvedBy { login }
@@ -369,7 +431,9 @@ query Threads($id: ID!, $after: String) {
             nodes {
               databaseId
               viewerDidAuthor
+              createdAt
               updatedAt
+              author { login }
             }
           }
         }
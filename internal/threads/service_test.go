@@ -419,3 +419,116 @@ func assign(dst interface{}, payload interface{}) error {
 	}
 	return json.Unmarshal(data, dst)
 }
+
+func TestShowCollectsAllCommentPages(t *testing.T) {
+	svc := &Service{}
+	calls := 0
+	svc.API = &fakeAPI{
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			require.Equal(t, threadShowQuery, query)
+			require.Equal(t, "PRRT_1", variables["id"])
+			calls++
+
+			thread := map[string]interface{}{
+				"id":                 "PRRT_1",
+				"path":               "internal/service.go",
+				"line":               42,
+				"startLine":          40,
+				"diffSide":           "RIGHT",
+				"startDiffSide":      "RIGHT",
+				"isResolved":         true,
+				"isOutdated":         false,
+				"viewerCanResolve":   false,
+				"viewerCanUnresolve": true,
+				"viewerCanReply":     true,
+				"resolvedBy":         map[string]interface{}{"login": "octocat"},
+			}
+			assert.Equal(t, true, variables["includeReactions"])
+			if calls == 1 {
+				_, hasAfter := variables["after"]
+				require.False(t, hasAfter)
+				thread["comments"] = map[string]interface{}{
+					"nodes": []map[string]interface{}{
+						{
+							"id":         "PRRC_1",
+							"databaseId": 11,
+							"body":       "Please rename",
+							"diffHunk":   "@@ -1,3 +1,4 @@",
+							"createdAt":  "2025-12-01T10:00:00Z",
+							"updatedAt":  "2025-12-01T10:00:00Z",
+							"author":     map[string]interface{}{"login": "alice"},
+							"pullRequestReview": map[string]interface{}{
+								"id":    "PRR_1",
+								"state": "COMMENTED",
+							},
+							"reactionGroups": []map[string]interface{}{
+								{"content": "THUMBS_UP", "viewerHasReacted": true, "users": map[string]interface{}{"totalCount": 2}},
+								{"content": "HEART", "users": map[string]interface{}{"totalCount": 0}},
+							},
+						},
+					},
+					"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "CUR1"},
+				}
+			} else {
+				require.Equal(t, "CUR1", variables["after"])
+				thread["comments"] = map[string]interface{}{
+					"nodes": []map[string]interface{}{
+						{
+							"id":        "PRRC_2",
+							"body":      "Done",
+							"diffHunk":  "@@ -1,3 +1,4 @@",
+							"createdAt": "2025-12-01T11:00:00Z",
+							"updatedAt": "2025-12-01T11:05:00Z",
+							"author":    nil,
+							"replyTo":   map[string]interface{}{"id": "PRRC_1"},
+						},
+					},
+					"pageInfo": map[string]interface{}{"hasNextPage": false, "endCursor": ""},
+				}
+			}
+			return assign(result, map[string]interface{}{"node": thread})
+		},
+	}
+
+	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5, Host: "github.com"}
+	detail, err := svc.Show(identity, ShowOptions{ThreadID: "PRRT_1", IncludeReactions: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	assert.Equal(t, "PRRT_1", detail.ThreadID)
+	require.NotNil(t, detail.StartLine)
+	assert.Equal(t, 40, *detail.StartLine)
+	assert.Equal(t, "RIGHT", detail.StartDiffSide)
+	require.NotNil(t, detail.ResolvedBy)
+	assert.Equal(t, "octocat", *detail.ResolvedBy)
+	require.NotNil(t, detail.DiffHunk)
+	assert.Equal(t, "@@ -1,3 +1,4 @@", *detail.DiffHunk)
+	assert.True(t, detail.ViewerCanReply)
+
+	require.Len(t, detail.Comments, 2)
+	first := detail.Comments[0]
+	assert.Equal(t, "PRRC_1", first.CommentNodeID)
+	require.NotNil(t, first.ReviewID)
+	assert.Equal(t, "PRR_1", *first.ReviewID)
+	assert.Equal(t, []Reaction{{Content: "THUMBS_UP", Count: 2, ViewerHasReacted: true}}, first.Reactions)
+
+	second := detail.Comments[1]
+	assert.Equal(t, "ghost", second.AuthorLogin)
+	require.NotNil(t, second.ReplyToCommentID)
+	assert.Equal(t, "PRRC_1", *second.ReplyToCommentID)
+	assert.Nil(t, second.DatabaseID)
+}
+
+func TestShowThreadNotFound(t *testing.T) {
+	svc := &Service{}
+	svc.API = &fakeAPI{
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			return assign(result, map[string]interface{}{"node": nil})
+		},
+	}
+
+	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5, Host: "github.com"}
+	_, err := svc.Show(identity, ShowOptions{ThreadID: "PRRT_missing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "thread PRRT_missing not found on github.com")
+}
@@ -0,0 +1,276 @@
+package threads
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+)
+
+// ShowOptions identifies the thread to load.
+type ShowOptions struct {
+	ThreadID string
+	// IncludeReactions adds reaction counts to every comment.
+	IncludeReactions bool
+}
+
+// ThreadDetail contains the full conversation and metadata of a single review thread.
+type ThreadDetail struct {
+	ThreadID           string          `json:"threadId"`
+	Path               string          `json:"path"`
+	Line               *int            `json:"line,omitempty"`
+	StartLine          *int            `json:"startLine,omitempty"`
+	OriginalLine       *int            `json:"originalLine,omitempty"`
+	OriginalStartLine  *int            `json:"originalStartLine,omitempty"`
+	DiffSide           string          `json:"diffSide,omitempty"`
+	StartDiffSide      string          `json:"startDiffSide,omitempty"`
+	DiffHunk           *string         `json:"diffHunk,omitempty"`
+	IsResolved         bool            `json:"isResolved"`
+	IsOutdated         bool            `json:"isOutdated"`
+	ResolvedBy         *string         `json:"resolvedBy,omitempty"`
+	ViewerCanResolve   bool            `json:"viewerCanResolve"`
+	ViewerCanUnresolve bool            `json:"viewerCanUnresolve"`
+	ViewerCanReply     bool            `json:"viewerCanReply"`
+	Comments           []ThreadComment `json:"comments"`
+}
+
+// ThreadComment is a single comment within a thread, ordered as returned by GitHub.
+type ThreadComment struct {
+	CommentNodeID    string     `json:"commentNodeId"`
+	DatabaseID       *int       `json:"databaseId,omitempty"`
+	AuthorLogin      string     `json:"authorLogin"`
+	Body             string     `json:"body"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+	ReplyToCommentID *string    `json:"replyToCommentId,omitempty"`
+	ReviewID         *string    `json:"reviewId,omitempty"`
+	ReviewState      *string    `json:"reviewState,omitempty"`
+	Reactions        []Reaction `json:"reactions,omitempty"`
+}
+
+// Reaction summarizes the number of reactions of a given kind on a comment.
+type Reaction struct {
+	Content          string `json:"content"`
+	Count            int    `json:"count"`
+	ViewerHasReacted bool   `json:"viewerHasReacted"`
+}
+
+type threadShowNode struct {
+	ID                 string  `json:"id"`
+	Path               string  `json:"path"`
+	Line               *int    `json:"line"`
+	StartLine          *int    `json:"startLine"`
+	OriginalLine       *int    `json:"originalLine"`
+	OriginalStartLine  *int    `json:"originalStartLine"`
+	DiffSide           string  `json:"diffSide"`
+	StartDiffSide      *string `json:"startDiffSide"`
+	IsResolved         bool    `json:"isResolved"`
+	IsOutdated         bool    `json:"isOutdated"`
+	ViewerCanResolve   bool    `json:"viewerCanResolve"`
+	ViewerCanUnresolve bool    `json:"viewerCanUnresolve"`
+	ViewerCanReply     bool    `json:"viewerCanReply"`
+	ResolvedBy         *struct {
+		Login string `json:"login"`
+	} `json:"resolvedBy"`
+	Comments *struct {
+		Nodes    []threadShowComment `json:"nodes"`
+		PageInfo struct {
+			HasNextPage bool   `json:"hasNextPage"`
+			EndCursor   string `json:"endCursor"`
+		} `json:"pageInfo"`
+	} `json:"comments"`
+}
+
+type threadShowComment struct {
+	ID         string    `json:"id"`
+	DatabaseID *int      `json:"databaseId"`
+	Body       string    `json:"body"`
+	DiffHunk   string    `json:"diffHunk"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	Author     *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	ReplyTo *struct {
+		ID string `json:"id"`
+	} `json:"replyTo"`
+	PullRequestReview *struct {
+		ID    string `json:"id"`
+		State string `json:"state"`
+	} `json:"pullRequestReview"`
+	ReactionGroups []struct {
+		Content          string `json:"content"`
+		ViewerHasReacted bool   `json:"viewerHasReacted"`
+		Users            struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"users"`
+	} `json:"reactionGroups"`
+}
+
+// Show loads a single review thread with every comment, following comment pagination.
+func (s *Service) Show(pr resolver.Identity, opts ShowOptions) (ThreadDetail, error) {
+	threadID := strings.TrimSpace(opts.ThreadID)
+	if threadID == "" {
+		return ThreadDetail{}, errors.New("thread id is required")
+	}
+
+	var (
+		detail   ThreadDetail
+		comments []threadShowComment
+		after    *string
+	)
+
+	for {
+		variables := map[string]interface{}{"id": threadID}
+		if opts.IncludeReactions {
+			variables["includeReactions"] = true
+		}
+		if after != nil {
+			variables["after"] = *after
+		}
+
+		var resp struct {
+			Node *threadShowNode `json:"node"`
+		}
+		if err := s.API.GraphQL(threadShowQuery, variables, &resp); err != nil {
+			return ThreadDetail{}, err
+		}
+		node := resp.Node
+		if node == nil || node.ID == "" || node.Comments == nil {
+			return ThreadDetail{}, fmt.Errorf("thread %s not found on %s", threadID, pr.Host)
+		}
+
+		if after == nil {
+			detail = newThreadDetail(node)
+		}
+		comments = append(comments, node.Comments.Nodes...)
+
+		if !node.Comments.PageInfo.HasNextPage {
+			break
+		}
+		cursor := strings.TrimSpace(node.Comments.PageInfo.EndCursor)
+		if cursor == "" {
+			return ThreadDetail{}, errors.New("thread comment pagination cursor missing")
+		}
+		after = &cursor
+	}
+
+	detail.Comments = make([]ThreadComment, 0, len(comments))
+	for _, comment := range comments {
+		if detail.DiffHunk == nil && strings.TrimSpace(comment.DiffHunk) != "" {
+			hunk := comment.DiffHunk
+			detail.DiffHunk = &hunk
+		}
+		detail.Comments = append(detail.Comments, newThreadComment(comment))
+	}
+
+	return detail, nil
+}
+
+func newThreadDetail(node *threadShowNode) ThreadDetail {
+	detail := ThreadDetail{
+		ThreadID:           node.ID,
+		Path:               node.Path,
+		Line:               node.Line,
+		StartLine:          node.StartLine,
+		OriginalLine:       node.OriginalLine,
+		OriginalStartLine:  node.OriginalStartLine,
+		DiffSide:           node.DiffSide,
+		IsResolved:         node.IsResolved,
+		IsOutdated:         node.IsOutdated,
+		ViewerCanResolve:   node.ViewerCanResolve,
+		ViewerCanUnresolve: node.ViewerCanUnresolve,
+		ViewerCanReply:     node.ViewerCanReply,
+	}
+	if node.StartDiffSide != nil {
+		detail.StartDiffSide = *node.StartDiffSide
+	}
+	if node.ResolvedBy != nil && node.ResolvedBy.Login != "" {
+		login := node.ResolvedBy.Login
+		detail.ResolvedBy = &login
+	}
+	return detail
+}
+
+func newThreadComment(comment threadShowComment) ThreadComment {
+	// Deleted accounts surface as a null author; report GitHub's ghost login instead.
+	login := "ghost"
+	if comment.Author != nil && comment.Author.Login != "" {
+		login = comment.Author.Login
+	}
+
+	result := ThreadComment{
+		CommentNodeID: comment.ID,
+		DatabaseID:    comment.DatabaseID,
+		AuthorLogin:   login,
+		Body:          comment.Body,
+		CreatedAt:     comment.CreatedAt,
+		UpdatedAt:     comment.UpdatedAt,
+	}
+	if comment.ReplyTo != nil && comment.ReplyTo.ID != "" {
+		replyTo := comment.ReplyTo.ID
+		result.ReplyToCommentID = &replyTo
+	}
+	if comment.PullRequestReview != nil {
+		if id := strings.TrimSpace(comment.PullRequestReview.ID); id != "" {
+			result.ReviewID = &id
+		}
+		if state := strings.TrimSpace(comment.PullRequestReview.State); state != "" {
+			result.ReviewState = &state
+		}
+	}
+	for _, group := range comment.ReactionGroups {
+		if group.Users.TotalCount == 0 {
+			continue
+		}
+		result.Reactions = append(result.Reactions, Reaction{Content: group.Content, Count: group.Users.TotalCount, ViewerHasReacted: group.ViewerHasReacted})
+	}
+	return result
+}
+
+const threadShowQuery = `
+query ThreadShow($id: ID!, $after: String, $includeReactions: Boolean = false) {
+  node(id: $id) {
+    ... on PullRequestReviewThread {
+      id
+      path
+      line
+      startLine
+      originalLine
+      originalStartLine
+      diffSide
+      startDiffSide
+      isResolved
+      isOutdated
+      viewerCanResolve
+      viewerCanUnresolve
+      viewerCanReply
+      resolvedBy { login }
+      comments(first: 100, after: $after) {
+        nodes {
+          id
+          databaseId
+          body
+          diffHunk
+          createdAt
+          updatedAt
+          author { login }
+          replyTo { id }
+          pullRequestReview { id state }
+          reactionGroups @include(if: $includeReactions) {
+            content
+            viewerHasReacted
+            users { totalCount }
+          }
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,183 @@
+package watch
+
+import (
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/report"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/threads"
+)
+
+// Event types emitted by the watcher.
+const (
+	EventReview           = "review"
+	EventThread           = "thread"
+	EventReply            = "reply"
+	EventThreadResolved   = "thread_resolved"
+	EventThreadUnresolved = "thread_unresolved"
+	EventHeadCommit       = "head_commit"
+	EventMerged           = "merged"
+	EventClosed           = "closed"
+)
+
+// Event describes one change observed between two snapshots. Only the fields
+// relevant to the event type are populated.
+type Event struct {
+	Type            string    `json:"type"`
+	ObservedAt      time.Time `json:"observed_at"`
+	ReviewID        string    `json:"review_id,omitempty"`
+	ReviewState     string    `json:"review_state,omitempty"`
+	ThreadID        string    `json:"thread_id,omitempty"`
+	CommentNodeID   string    `json:"comment_node_id,omitempty"`
+	Path            string    `json:"path,omitempty"`
+	Line            *int      `json:"line,omitempty"`
+	AuthorLogin     string    `json:"author_login,omitempty"`
+	Body            string    `json:"body,omitempty"`
+	CreatedAt       string    `json:"created_at,omitempty"`
+	ResolvedBy      string    `json:"resolved_by,omitempty"`
+	HeadSHA         string    `json:"head_sha,omitempty"`
+	PreviousHeadSHA string    `json:"previous_head_sha,omitempty"`
+}
+
+// Snapshot captures the pull request state compared between polls.
+type Snapshot struct {
+	Report  report.Report
+	Threads []threads.Thread
+}
+
+// headSHA returns the pull request head commit, or "" when metadata is missing.
+func (s Snapshot) headSHA() string {
+	if s.Report.PullRequest == nil {
+		return ""
+	}
+	return s.Report.PullRequest.HeadSHA
+}
+
+// Diff lists the events that turn prev into next: new or newly submitted
+// reviews, new threads and replies, resolution changes, and head commit moves.
+// Events follow the order of next so output is deterministic.
+func Diff(prev, next Snapshot) []Event {
+	var events []Event
+
+	prevReviews := make(map[string]report.State, len(prev.Report.Reviews))
+	prevComments := make(map[string]map[string]struct{})
+	for _, review := range prev.Report.Reviews {
+		prevReviews[review.ID] = review.State
+		for _, comment := range review.Comments {
+			replies := make(map[string]struct{}, len(comment.ThreadComments))
+			for _, reply := range comment.ThreadComments {
+				if reply.CommentNodeID != nil {
+					replies[*reply.CommentNodeID] = struct{}{}
+				}
+			}
+			prevComments[comment.ThreadID] = replies
+		}
+	}
+
+	for _, review := range next.Report.Reviews {
+		if state, ok := prevReviews[review.ID]; !ok || state != review.State {
+			event := Event{
+				Type:        EventReview,
+				ReviewID:    review.ID,
+				ReviewState: string(review.State),
+				AuthorLogin: review.AuthorLogin,
+			}
+			if review.Body != nil {
+				event.Body = *review.Body
+			}
+			if review.SubmittedAt != nil {
+				event.CreatedAt = *review.SubmittedAt
+			}
+			events = append(events, event)
+		}
+	}
+
+	for _, review := range next.Report.Reviews {
+		for _, comment := range review.Comments {
+			seenReplies, known := prevComments[comment.ThreadID]
+			if !known {
+				event := Event{
+					Type:        EventThread,
+					ReviewID:    review.ID,
+					ThreadID:    comment.ThreadID,
+					Path:        comment.Path,
+					Line:        comment.Line,
+					AuthorLogin: comment.AuthorLogin,
+					Body:        comment.Body,
+					CreatedAt:   comment.CreatedAt,
+				}
+				if comment.CommentNodeID != nil {
+					event.CommentNodeID = *comment.CommentNodeID
+				}
+				events = append(events, event)
+			}
+			for _, reply := range comment.ThreadComments {
+				if reply.CommentNodeID == nil {
+					continue
+				}
+				if _, ok := seenReplies[*reply.CommentNodeID]; ok {
+					continue
+				}
+				events = append(events, Event{
+					Type:          EventReply,
+					ThreadID:      comment.ThreadID,
+					CommentNodeID: *reply.CommentNodeID,
+					Path:          comment.Path,
+					Line:          comment.Line,
+					AuthorLogin:   reply.AuthorLogin,
+					Body:          reply.Body,
+					CreatedAt:     reply.CreatedAt,
+				})
+			}
+		}
+	}
+
+	prevResolved := make(map[string]bool, len(prev.Threads))
+	for _, thread := range prev.Threads {
+		prevResolved[thread.ThreadID] = thread.IsResolved
+	}
+	for _, thread := range next.Threads {
+		wasResolved, ok := prevResolved[thread.ThreadID]
+		if !ok || wasResolved == thread.IsResolved {
+			continue
+		}
+		event := Event{
+			Type:     EventThreadUnresolved,
+			ThreadID: thread.ThreadID,
+			Path:     thread.Path,
+			Line:     thread.Line,
+		}
+		if thread.IsResolved {
+			event.Type = EventThreadResolved
+			if thread.ResolvedBy != nil {
+				event.ResolvedBy = *thread.ResolvedBy
+			}
+		}
+		events = append(events, event)
+	}
+
+	if head := next.headSHA(); head != "" && prev.headSHA() != "" && head != prev.headSHA() {
+		events = append(events, Event{
+			Type:            EventHeadCommit,
+			HeadSHA:         head,
+			PreviousHeadSHA: prev.headSHA(),
+		})
+	}
+
+	return events
+}
+
+// terminalEvent returns the merged/closed event for a pull request that is no
+// longer open, or nil while it remains open.
+func terminalEvent(s Snapshot) *Event {
+	if s.Report.PullRequest == nil {
+		return nil
+	}
+	switch s.Report.PullRequest.State {
+	case "MERGED":
+		return &Event{Type: EventMerged, HeadSHA: s.headSHA()}
+	case "CLOSED":
+		return &Event{Type: EventClosed, HeadSHA: s.headSHA()}
+	default:
+		return nil
+	}
+}
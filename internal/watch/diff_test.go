@@ -0,0 +1,99 @@
+package watch
+
+import (
+	"testing"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/report"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/threads"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(v string) *string { return &v }
+
+func TestDiffDetectsChanges(t *testing.T) {
+	prev := Snapshot{
+		Report: report.Report{
+			PullRequest: &report.ReportPullRequest{State: "OPEN", HeadSHA: "aaa"},
+			Reviews: []report.ReportReview{{
+				ID:    "PRR_1",
+				State: report.StateCommented,
+				Comments: []report.ReportComment{{
+					ThreadID:       "T1",
+					Path:           "a.go",
+					ThreadComments: []report.ThreadReply{{CommentNodeID: strPtr("C2")}},
+				}},
+			}},
+		},
+		Threads: []threads.Thread{{ThreadID: "T1", IsResolved: false}},
+	}
+	next := Snapshot{
+		Report: report.Report{
+			PullRequest: &report.ReportPullRequest{State: "OPEN", HeadSHA: "bbb"},
+			Reviews: []report.ReportReview{
+				{
+					ID:    "PRR_1",
+					State: report.StateCommented,
+					Comments: []report.ReportComment{{
+						ThreadID: "T1",
+						Path:     "a.go",
+						ThreadComments: []report.ThreadReply{
+							{CommentNodeID: strPtr("C2")},
+							{CommentNodeID: strPtr("C3"), AuthorLogin: "bob", Body: "done"},
+						},
+					}},
+				},
+				{
+					ID:          "PRR_2",
+					State:       report.StateApproved,
+					AuthorLogin: "carol",
+					Comments: []report.ReportComment{{
+						ThreadID:       "T2",
+						CommentNodeID:  strPtr("C4"),
+						Path:           "b.go",
+						AuthorLogin:    "carol",
+						Body:           "nit",
+						ThreadComments: []report.ThreadReply{},
+					}},
+				},
+			},
+		},
+		Threads: []threads.Thread{
+			{ThreadID: "T1", IsResolved: true, ResolvedBy: strPtr("alice"), Path: "a.go"},
+			{ThreadID: "T2", Path: "b.go"},
+		},
+	}
+
+	events := Diff(prev, next)
+	require.Len(t, events, 5)
+
+	assert.Equal(t, EventReview, events[0].Type)
+	assert.Equal(t, "PRR_2", events[0].ReviewID)
+	assert.Equal(t, "APPROVED", events[0].ReviewState)
+
+	assert.Equal(t, EventReply, events[1].Type)
+	assert.Equal(t, "C3", events[1].CommentNodeID)
+	assert.Equal(t, "T1", events[1].ThreadID)
+
+	assert.Equal(t, EventThread, events[2].Type)
+	assert.Equal(t, "T2", events[2].ThreadID)
+	assert.Equal(t, "C4", events[2].CommentNodeID)
+
+	assert.Equal(t, EventThreadResolved, events[3].Type)
+	assert.Equal(t, "alice", events[3].ResolvedBy)
+
+	assert.Equal(t, EventHeadCommit, events[4].Type)
+	assert.Equal(t, "bbb", events[4].HeadSHA)
+	assert.Equal(t, "aaa", events[4].PreviousHeadSHA)
+}
+
+func TestDiffIdenticalSnapshotsIsEmpty(t *testing.T) {
+	snap := Snapshot{
+		Report: report.Report{
+			PullRequest: &report.ReportPullRequest{State: "OPEN", HeadSHA: "aaa"},
+			Reviews:     []report.ReportReview{{ID: "PRR_1", State: report.StateApproved}},
+		},
+		Threads: []threads.Thread{{ThreadID: "T1"}},
+	}
+	assert.Empty(t, Diff(snap, snap))
+}
@@ -0,0 +1,172 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/ghcli"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/report"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/threads"
+)
+
+// Options configures the polling loop.
+type Options struct {
+	// Interval is the delay between polls.
+	Interval time.Duration
+	// FullRefreshEvery forces a full snapshot after this many consecutive polls
+	// whose conditional probes were unchanged. Thread resolution is not visible
+	// to the REST probes, so this bounds how late resolution events can be.
+	// Zero disables probing and snapshots on every poll.
+	FullRefreshEvery int
+}
+
+// Watcher polls a pull request and emits the differences between snapshots.
+type Watcher struct {
+	API ghcli.API
+
+	// Now and Sleep are overridable for tests.
+	Now   func() time.Time
+	Sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewWatcher constructs a Watcher using the provided API client.
+func NewWatcher(api ghcli.API) *Watcher {
+	return &Watcher{API: api, Now: time.Now, Sleep: sleepContext}
+}
+
+// Run polls until the pull request is merged or closed, the context is
+// cancelled, or an error occurs. The first snapshot is a silent baseline; emit
+// receives every later change. A merged or closed pull request yields a final
+// event and a nil error.
+func (w *Watcher) Run(ctx context.Context, pr resolver.Identity, opts Options, emit func(Event) error) error {
+	if opts.Interval <= 0 {
+		return errors.New("poll interval must be positive")
+	}
+	if opts.FullRefreshEvery < 0 {
+		return errors.New("full refresh interval must not be negative")
+	}
+
+	probe := newProber(w.API, pr)
+	reports := report.NewService(w.API)
+	threadService := threads.NewService(w.API)
+
+	var prev *Snapshot
+	skipped := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if prev != nil {
+			if err := w.Sleep(ctx, opts.Interval); err != nil {
+				return err
+			}
+			if opts.FullRefreshEvery > 0 && skipped < opts.FullRefreshEvery && !probe.changed() {
+				skipped++
+				continue
+			}
+		} else if opts.FullRefreshEvery > 0 {
+			// Prime the ETags so the next poll can be answered with 304s.
+			probe.changed()
+		}
+		skipped = 0
+
+		next, err := snapshot(reports, threadService, pr)
+		if err != nil {
+			return err
+		}
+
+		observedAt := w.Now().UTC()
+		if prev != nil {
+			for _, event := range Diff(*prev, next) {
+				event.ObservedAt = observedAt
+				if err := emit(event); err != nil {
+					return err
+				}
+			}
+		}
+		if final := terminalEvent(next); final != nil {
+			final.ObservedAt = observedAt
+			return emit(*final)
+		}
+		prev = &next
+	}
+}
+
+// snapshot loads every review and thread; Fetch pages past the first 100 so
+// activity on busy pull requests still produces events.
+func snapshot(reports *report.Service, threadService *threads.Service, pr resolver.Identity) (Snapshot, error) {
+	rep, err := reports.Fetch(pr, report.Options{
+		// Pending reviews are private drafts; they surface once submitted.
+		States:               []report.State{report.StateApproved, report.StateChangesRequested, report.StateCommented, report.StateDismissed},
+		StatesProvided:       true,
+		IncludeCommentNodeID: true,
+		IncludePullRequest:   true,
+	})
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("load review snapshot: %w", err)
+	}
+	list, err := threadService.List(pr, threads.ListOptions{})
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("load thread snapshot: %w", err)
+	}
+	return Snapshot{Report: rep, Threads: list}, nil
+}
+
+// prober issues conditional REST requests for the resources that change when
+// reviews, review comments, or commits are added.
+type prober struct {
+	api   ghcli.ConditionalAPI
+	paths []string
+	etags map[string]string
+}
+
+func newProber(api ghcli.API, pr resolver.Identity) *prober {
+	conditional, _ := api.(ghcli.ConditionalAPI)
+	base := fmt.Sprintf("repos/%s/%s/pulls/%d", pr.Owner, pr.Repo, pr.Number)
+	return &prober{
+		api: conditional,
+		paths: []string{
+			base,
+			base + "/reviews?per_page=100",
+			base + "/comments?sort=updated&direction=desc&per_page=1",
+		},
+		etags: make(map[string]string),
+	}
+}
+
+// changed reports whether any probed resource changed since the last call.
+// Clients without conditional request support, missing ETags, and probe
+// failures all count as changes so the watcher falls back to a full snapshot.
+func (p *prober) changed() bool {
+	if p.api == nil {
+		return true
+	}
+	changed := false
+	for _, path := range p.paths {
+		etag, modified, err := p.api.ConditionalGET(path, p.etags[path], nil)
+		if err != nil || etag == "" {
+			delete(p.etags, path)
+			changed = true
+			continue
+		}
+		if modified {
+			changed = true
+		}
+		p.etags[path] = etag
+	}
+	return changed
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
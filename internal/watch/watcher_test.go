@@ -0,0 +1,201 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/report"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/resolver"
+	"github.com/Agyn-sandbox/gh-pr-review/internal/threads"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAPI struct {
+	restFunc        func(method, path string, params map[string]string, body interface{}, result interface{}) error
+	graphqlFunc     func(query string, variables map[string]interface{}, result interface{}) error
+	conditionalFunc func(path, etag string) (string, bool, error)
+}
+
+func (f *fakeAPI) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+	if f.restFunc == nil {
+		return errors.New("unexpected REST call")
+	}
+	return f.restFunc(method, path, params, body, result)
+}
+
+func (f *fakeAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+	if f.graphqlFunc == nil {
+		return errors.New("unexpected GraphQL call")
+	}
+	return f.graphqlFunc(query, variables, result)
+}
+
+func (f *fakeAPI) ConditionalGET(path, etag string, result interface{}) (string, bool, error) {
+	return f.conditionalFunc(path, etag)
+}
+
+func assign(dst interface{}, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+type pollState struct {
+	state    string
+	head     string
+	resolved bool
+}
+
+func TestWatcherEmitsChangesAndStopsOnMerge(t *testing.T) {
+	states := []pollState{
+		{state: "OPEN", head: "aaa"},
+		{state: "OPEN", head: "bbb", resolved: true},
+		{state: "MERGED", head: "bbb", resolved: true},
+	}
+	snapshots := 0
+	probes := 0
+
+	fake := &fakeAPI{
+		restFunc: func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+			switch path {
+			case "repos/octo/demo":
+				return assign(result, map[string]interface{}{"full_name": "octo/demo"})
+			case "repos/octo/demo/pulls/7":
+				return assign(result, map[string]interface{}{"node_id": "PR_node"})
+			default:
+				return errors.New("unexpected REST path: " + path)
+			}
+		},
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			current := states[snapshots]
+			if strings.Contains(query, "query Report(") {
+				require.Equal(t, true, variables["includePullRequest"])
+				return assign(result, map[string]interface{}{
+					"repository": map[string]interface{}{
+						"pullRequest": map[string]interface{}{
+							"state":      current.state,
+							"headRefOid": current.head,
+							"reviews": map[string]interface{}{
+								"nodes": []map[string]interface{}{{
+									"id": "PRR_1", "state": "COMMENTED", "databaseId": 1,
+									"author": map[string]interface{}{"login": "alice"},
+								}},
+							},
+							"reviewThreads": map[string]interface{}{"nodes": []interface{}{}},
+						},
+					},
+				})
+			}
+			snapshots++
+			return assign(result, map[string]interface{}{
+				"node": map[string]interface{}{
+					"reviewThreads": map[string]interface{}{
+						"nodes": []map[string]interface{}{{
+							"id": "T1", "path": "a.go", "isResolved": current.resolved,
+							"resolvedBy": map[string]interface{}{"login": "alice"},
+							"comments":   map[string]interface{}{"nodes": []interface{}{}},
+						}},
+						"pageInfo": map[string]interface{}{"hasNextPage": false},
+					},
+				},
+			})
+		},
+		conditionalFunc: func(path, etag string) (string, bool, error) {
+			// Probe rounds: 0 primes, 1 is unchanged, later rounds change.
+			round := probes / 3
+			probes++
+			if round == 1 {
+				return etag, false, nil
+			}
+			return "etag-" + string(rune('a'+round)), true, nil
+		},
+	}
+
+	watcher := NewWatcher(fake)
+	sleeps := 0
+	watcher.Sleep = func(ctx context.Context, d time.Duration) error {
+		assert.Equal(t, time.Second, d)
+		sleeps++
+		return nil
+	}
+	observed := time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC)
+	watcher.Now = func() time.Time { return observed }
+
+	var events []Event
+	err := watcher.Run(context.Background(), resolver.Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 7}, Options{Interval: time.Second, FullRefreshEvery: 5}, func(e Event) error {
+		events = append(events, e)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, sleeps)
+	assert.Equal(t, 3, snapshots)
+	require.Len(t, events, 3)
+	assert.Equal(t, EventThreadResolved, events[0].Type)
+	assert.Equal(t, "T1", events[0].ThreadID)
+	assert.Equal(t, EventHeadCommit, events[1].Type)
+	assert.Equal(t, "bbb", events[1].HeadSHA)
+	assert.Equal(t, EventMerged, events[2].Type)
+	assert.Equal(t, observed, events[2].ObservedAt)
+}
+
+func TestSnapshotReadsEveryReviewPage(t *testing.T) {
+	review := func(id string) map[string]interface{} {
+		return map[string]interface{}{"id": id, "state": "COMMENTED", "body": "LGTM", "databaseId": 1, "author": map[string]interface{}{"login": "alice"}}
+	}
+	fake := &fakeAPI{
+		restFunc: func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+			switch path {
+			case "repos/octo/demo":
+				return assign(result, map[string]interface{}{"full_name": "octo/demo"})
+			case "repos/octo/demo/pulls/7":
+				return assign(result, map[string]interface{}{"node_id": "PR_node"})
+			default:
+				return errors.New("unexpected REST path: " + path)
+			}
+		},
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			switch {
+			case strings.Contains(query, "query Report("):
+				return assign(result, map[string]interface{}{"repository": map[string]interface{}{"pullRequest": map[string]interface{}{
+					"state":         "OPEN",
+					"reviews":       map[string]interface{}{"nodes": []map[string]interface{}{review("PRR_1")}, "pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "r1"}},
+					"reviewThreads": map[string]interface{}{"nodes": []interface{}{}},
+				}}})
+			case strings.Contains(query, "query ReportReviews("):
+				assert.Equal(t, "r1", variables["after"])
+				assert.NotNil(t, variables["states"], "later pages keep the state filter")
+				return assign(result, map[string]interface{}{"repository": map[string]interface{}{"pullRequest": map[string]interface{}{
+					"reviews": map[string]interface{}{"nodes": []map[string]interface{}{review("PRR_2")}, "pageInfo": map[string]interface{}{"hasNextPage": false}},
+				}}})
+			}
+			return assign(result, map[string]interface{}{"node": map[string]interface{}{"reviewThreads": map[string]interface{}{
+				"nodes": []interface{}{}, "pageInfo": map[string]interface{}{"hasNextPage": false},
+			}}})
+		},
+	}
+
+	snap, err := snapshot(report.NewService(fake), threads.NewService(fake), resolver.Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 7})
+	require.NoError(t, err)
+	ids := make([]string, len(snap.Report.Reviews))
+	for i, review := range snap.Report.Reviews {
+		ids[i] = review.ID
+	}
+	assert.Equal(t, []string{"PRR_1", "PRR_2"}, ids)
+}
+
+func TestWatcherStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	watcher := NewWatcher(&fakeAPI{})
+	err := watcher.Run(ctx, resolver.Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 7}, Options{Interval: time.Second}, func(Event) error { return nil })
+	require.ErrorIs(t, err, context.Canceled)
+}
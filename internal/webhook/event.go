@@ -0,0 +1,180 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Agyn-sandbox/gh-pr-review/internal/watch"
+)
+
+// Event types that only webhooks can observe, in addition to the watch.Event types.
+const (
+	EventReviewEdited    = "review_edited"
+	EventReviewDismissed = "review_dismissed"
+	EventCommentEdited   = "comment_edited"
+	EventCommentDeleted  = "comment_deleted"
+)
+
+// Event is a normalized webhook delivery. It extends the watch event shape with
+// the repository and pull request the delivery belongs to.
+type Event struct {
+	watch.Event
+	DeliveryID  string `json:"delivery_id,omitempty"`
+	GitHubEvent string `json:"github_event"`
+	Action      string `json:"action"`
+	Repository  string `json:"repository"`
+	PullNumber  int    `json:"pull_request_number"`
+}
+
+type user struct {
+	Login string `json:"login"`
+}
+
+type reviewComment struct {
+	NodeID      string `json:"node_id"`
+	Path        string `json:"path"`
+	Line        *int   `json:"line"`
+	Body        string `json:"body"`
+	CreatedAt   string `json:"created_at"`
+	InReplyToID *int64 `json:"in_reply_to_id"`
+	User        *user  `json:"user"`
+}
+
+type payload struct {
+	Action     string `json:"action"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	PullRequest struct {
+		Number int `json:"number"`
+	} `json:"pull_request"`
+	Sender *user `json:"sender"`
+	Review *struct {
+		NodeID      string  `json:"node_id"`
+		State       string  `json:"state"`
+		Body        *string `json:"body"`
+		SubmittedAt string  `json:"submitted_at"`
+		User        *user   `json:"user"`
+	} `json:"review"`
+	Comment *reviewComment `json:"comment"`
+	Thread  *struct {
+		NodeID   string          `json:"node_id"`
+		Comments []reviewComment `json:"comments"`
+	} `json:"thread"`
+}
+
+// Supported reports whether Parse understands the X-GitHub-Event name.
+func Supported(githubEvent string) bool {
+	switch githubEvent {
+	case "pull_request_review", "pull_request_review_comment", "pull_request_review_thread":
+		return true
+	default:
+		return false
+	}
+}
+
+// Parse normalizes a webhook payload. It returns ok=false when the action
+// carries no review activity (for example a review comment being marked as
+// minimized). Review comment deliveries do not identify the thread node, so
+// thread_id is only set for pull_request_review_thread events.
+func Parse(githubEvent string, body []byte, observedAt time.Time) (Event, bool, error) {
+	if !Supported(githubEvent) {
+		return Event{}, false, fmt.Errorf("unsupported webhook event %q", githubEvent)
+	}
+
+	var raw payload
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return Event{}, false, fmt.Errorf("decode %s payload: %w", githubEvent, err)
+	}
+
+	event := Event{
+		Event:       watch.Event{ObservedAt: observedAt.UTC()},
+		GitHubEvent: githubEvent,
+		Action:      raw.Action,
+		Repository:  raw.Repository.FullName,
+		PullNumber:  raw.PullRequest.Number,
+	}
+
+	switch githubEvent {
+	case "pull_request_review":
+		if raw.Review == nil {
+			return Event{}, false, fmt.Errorf("%s payload missing review", githubEvent)
+		}
+		switch raw.Action {
+		case "submitted":
+			event.Type = watch.EventReview
+		case "edited":
+			event.Type = EventReviewEdited
+		case "dismissed":
+			event.Type = EventReviewDismissed
+		default:
+			return Event{}, false, nil
+		}
+		event.ReviewID = raw.Review.NodeID
+		event.ReviewState = strings.ToUpper(raw.Review.State)
+		event.AuthorLogin = login(raw.Review.User)
+		if raw.Review.Body != nil {
+			event.Body = *raw.Review.Body
+		}
+		event.CreatedAt = raw.Review.SubmittedAt
+
+	case "pull_request_review_comment":
+		if raw.Comment == nil {
+			return Event{}, false, fmt.Errorf("%s payload missing comment", githubEvent)
+		}
+		switch raw.Action {
+		case "created":
+			event.Type = watch.EventThread
+			if raw.Comment.InReplyToID != nil {
+				event.Type = watch.EventReply
+			}
+		case "edited":
+			event.Type = EventCommentEdited
+		case "deleted":
+			event.Type = EventCommentDeleted
+		default:
+			return Event{}, false, nil
+		}
+		applyComment(&event, *raw.Comment)
+
+	case "pull_request_review_thread":
+		if raw.Thread == nil {
+			return Event{}, false, fmt.Errorf("%s payload missing thread", githubEvent)
+		}
+		switch raw.Action {
+		case "resolved":
+			event.Type = watch.EventThreadResolved
+			event.ResolvedBy = login(raw.Sender)
+		case "unresolved":
+			event.Type = watch.EventThreadUnresolved
+		default:
+			return Event{}, false, nil
+		}
+		event.ThreadID = raw.Thread.NodeID
+		if len(raw.Thread.Comments) > 0 {
+			first := raw.Thread.Comments[0]
+			event.Path = first.Path
+			event.Line = first.Line
+		}
+	}
+
+	return event, true, nil
+}
+
+func applyComment(event *Event, comment reviewComment) {
+	event.CommentNodeID = comment.NodeID
+	event.Path = comment.Path
+	event.Line = comment.Line
+	event.AuthorLogin = login(comment.User)
+	event.Body = comment.Body
+	event.CreatedAt = comment.CreatedAt
+}
+
+func login(u *user) string {
+	if u == nil {
+		return ""
+	}
+	return u.Login
+}
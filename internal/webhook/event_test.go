@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	_ "embed"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	//go:embed testdata/pull_request_review.json
+	reviewPayload []byte
+	//go:embed testdata/pull_request_review_comment.json
+	reviewCommentPayload []byte
+	//go:embed testdata/pull_request_review_thread.json
+	reviewThreadPayload []byte
+)
+
+var observedAt = time.Date(2025, 12, 3, 10, 5, 0, 0, time.UTC)
+
+func TestParseReview(t *testing.T) {
+	event, ok, err := Parse("pull_request_review", reviewPayload, observedAt)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	assert.Equal(t, "review", event.Type)
+	assert.Equal(t, "PRR_kwDOAAABbcdEFG12", event.ReviewID)
+	assert.Equal(t, "CHANGES_REQUESTED", event.ReviewState)
+	assert.Equal(t, "octocat", event.AuthorLogin)
+	assert.Equal(t, "Please add tests.", event.Body)
+	assert.Equal(t, "owner/repo", event.Repository)
+	assert.Equal(t, 42, event.PullNumber)
+	assert.Equal(t, observedAt, event.ObservedAt)
+}
+
+func TestParseReviewCommentReply(t *testing.T) {
+	event, ok, err := Parse("pull_request_review_comment", reviewCommentPayload, observedAt)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	assert.Equal(t, "reply", event.Type)
+	assert.Equal(t, "PRRC_kwDOAAABbhi7890", event.CommentNodeID)
+	assert.Equal(t, "internal/service.go", event.Path)
+	require.NotNil(t, event.Line)
+	assert.Equal(t, 42, *event.Line)
+	assert.Equal(t, "hubot", event.AuthorLogin)
+	assert.Empty(t, event.ThreadID)
+}
+
+func TestParseReviewThreadResolved(t *testing.T) {
+	event, ok, err := Parse("pull_request_review_thread", reviewThreadPayload, observedAt)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	assert.Equal(t, "thread_resolved", event.Type)
+	assert.Equal(t, "PRRT_kwDOAAABbFg12345", event.ThreadID)
+	assert.Equal(t, "internal/service.go", event.Path)
+	assert.Equal(t, "octocat", event.ResolvedBy)
+}
+
+func TestParseIgnoresInactiveActions(t *testing.T) {
+	_, ok, err := Parse("pull_request_review_thread", []byte(`{"action":"something_else","thread":{"node_id":"PRRT_1"}}`), observedAt)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = Parse("issues", []byte(`{}`), observedAt)
+	require.Error(t, err)
+}
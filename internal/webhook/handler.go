@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxPayloadBytes matches the largest payload GitHub delivers (25 MB).
+const maxPayloadBytes = 25 << 20
+
+// Handler verifies and normalizes GitHub webhook deliveries, passing each
+// resulting event to Sink.
+type Handler struct {
+	Secret []byte
+	Sink   func(Event) error
+	// Now is overridable for tests.
+	Now func() time.Time
+	// Logf reports deliveries that could not be processed; nil discards them.
+	Logf func(format string, args ...interface{})
+}
+
+// NewHandler constructs a Handler for the given secret and sink.
+func NewHandler(secret []byte, sink func(Event) error) *Handler {
+	return &Handler{Secret: secret, Sink: sink, Now: time.Now}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadBytes))
+	if err != nil {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err := Verify(h.Secret, body, r.Header.Get("X-Hub-Signature-256")); err != nil {
+		h.logf("rejected delivery %s: %v", r.Header.Get("X-GitHub-Delivery"), err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	githubEvent := r.Header.Get("X-GitHub-Event")
+	if githubEvent == "ping" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if !Supported(githubEvent) {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	event, ok, err := Parse(githubEvent, body, h.now())
+	if err != nil {
+		h.logf("malformed delivery %s: %v", r.Header.Get("X-GitHub-Delivery"), err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !ok {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	event.DeliveryID = r.Header.Get("X-GitHub-Delivery")
+
+	if err := h.Sink(event); err != nil {
+		h.logf("delivery %s: %v", event.DeliveryID, err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrBusy) {
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, fmt.Sprintf("process event: %v", err), status)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ErrBusy signals that a sink cannot accept more events right now.
+var ErrBusy = errors.New("event queue is full")
+
+func (h *Handler) now() time.Time {
+	if h.Now == nil {
+		return time.Now()
+	}
+	return h.Now()
+}
+
+func (h *Handler) logf(format string, args ...interface{}) {
+	if h.Logf != nil {
+		h.Logf(format, args...)
+	}
+}
@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = []byte("s3cret")
+
+func deliver(t *testing.T, h http.Handler, githubEvent string, body []byte, signature string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("X-GitHub-Event", githubEvent)
+	req.Header.Set("X-GitHub-Delivery", "delivery-1")
+	req.Header.Set("X-Hub-Signature-256", signature)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"zen":"hi"}`)
+	require.NoError(t, Verify(testSecret, body, Sign(testSecret, body)))
+	assert.ErrorIs(t, Verify(testSecret, body, Sign([]byte("other"), body)), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(testSecret, body, ""), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(testSecret, body, "sha256=zz"), ErrInvalidSignature)
+}
+
+func TestHandlerDeliversSignedEvents(t *testing.T) {
+	var events []Event
+	h := NewHandler(testSecret, func(e Event) error {
+		events = append(events, e)
+		return nil
+	})
+
+	rec := deliver(t, h, "pull_request_review_thread", reviewThreadPayload, Sign(testSecret, reviewThreadPayload))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	require.Len(t, events, 1)
+	assert.Equal(t, "thread_resolved", events[0].Type)
+	assert.Equal(t, "delivery-1", events[0].DeliveryID)
+
+	rec = deliver(t, h, "ping", []byte(`{}`), Sign(testSecret, []byte(`{}`)))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = deliver(t, h, "push", []byte(`{}`), Sign(testSecret, []byte(`{}`)))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Len(t, events, 1)
+}
+
+func TestHandlerRejectsBadSignature(t *testing.T) {
+	h := NewHandler(testSecret, func(Event) error {
+		t.Fatal("sink must not run for unsigned deliveries")
+		return nil
+	})
+
+	rec := deliver(t, h, "pull_request_review", reviewPayload, Sign([]byte("wrong"), reviewPayload))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+// TestHookHelperProcess is the hook run by TestHookRunnerPassesEventOnStdin.
+// It re-executes the test binary so the hook works with sh and cmd alike.
+func TestHookHelperProcess(t *testing.T) {
+	if os.Getenv("GH_PR_REVIEW_HOOK_HELPER") != "1" {
+		return
+	}
+	payload, _ := io.ReadAll(os.Stdin)
+	fmt.Printf("%s %s", os.Getenv("GH_PR_REVIEW_EVENT_TYPE"), payload)
+	os.Exit(0)
+}
+
+func TestHookRunnerPassesEventOnStdin(t *testing.T) {
+	t.Setenv("GH_PR_REVIEW_HOOK_HELPER", "1")
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	runner := NewHookRunner(`"`+os.Args[0]+`" -test.run=TestHookHelperProcess$`, 5*time.Second, 4, stdout, stderr)
+
+	event, ok, err := Parse("pull_request_review_comment", reviewCommentPayload, observedAt)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, runner.Enqueue(event))
+	runner.Close()
+
+	assert.Empty(t, stderr.String())
+	prefix, payload, found := strings.Cut(stdout.String(), " ")
+	require.True(t, found)
+	assert.Equal(t, "reply", prefix)
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(payload), &decoded))
+	assert.Equal(t, "PRRC_kwDOAAABbhi7890", decoded["comment_node_id"])
+	assert.Equal(t, "owner/repo", decoded["repository"])
+}
@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// HookRunner runs a shell command (sh, or cmd on Windows) for every event, one at a time and in
+// arrival order, so webhook responses are not delayed by slow hooks. The
+// event JSON is passed on stdin and key fields are exported as environment
+// variables.
+type HookRunner struct {
+	Command string
+	Timeout time.Duration
+	Stdout  io.Writer
+	Stderr  io.Writer
+
+	queue chan Event
+	wg    sync.WaitGroup
+	once  sync.Once
+}
+
+// NewHookRunner constructs a runner with a bounded queue and starts its worker.
+func NewHookRunner(command string, timeout time.Duration, queueSize int, stdout, stderr io.Writer) *HookRunner {
+	if queueSize <= 0 {
+		queueSize = 1
+	}
+	r := &HookRunner{
+		Command: command,
+		Timeout: timeout,
+		Stdout:  stdout,
+		Stderr:  stderr,
+		queue:   make(chan Event, queueSize),
+	}
+	r.wg.Add(1)
+	go r.work()
+	return r
+}
+
+// Enqueue schedules the hook for event, returning ErrBusy when the queue is full.
+func (r *HookRunner) Enqueue(event Event) error {
+	select {
+	case r.queue <- event:
+		return nil
+	default:
+		return ErrBusy
+	}
+}
+
+// Close stops accepting events and waits for queued hooks to finish.
+func (r *HookRunner) Close() {
+	r.once.Do(func() { close(r.queue) })
+	r.wg.Wait()
+}
+
+func (r *HookRunner) work() {
+	defer r.wg.Done()
+	for event := range r.queue {
+		if err := r.run(event); err != nil {
+			fmt.Fprintf(r.Stderr, "hook for delivery %s (%s): %v\n", event.DeliveryID, event.Type, err)
+		}
+	}
+}
+
+func (r *HookRunner) run(event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+
+	ctx := context.Background()
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	cmd := shellCommand(ctx, r.Command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = r.Stdout
+	cmd.Stderr = r.Stderr
+	cmd.Env = append(os.Environ(),
+		"GH_PR_REVIEW_EVENT_TYPE="+event.Type,
+		"GH_PR_REVIEW_GITHUB_EVENT="+event.GitHubEvent,
+		"GH_PR_REVIEW_DELIVERY_ID="+event.DeliveryID,
+		"GH_PR_REVIEW_REPOSITORY="+event.Repository,
+		"GH_PR_REVIEW_PR_NUMBER="+strconv.Itoa(event.PullNumber),
+		"GH_PR_REVIEW_THREAD_ID="+event.ThreadID,
+		"GH_PR_REVIEW_COMMENT_NODE_ID="+event.CommentNodeID,
+	)
+	return cmd.Run()
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package webhook
+
+import (
+	"context"
+	"os/exec"
+)
+
+// shellCommand runs command with sh so hooks can use pipes and variables.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
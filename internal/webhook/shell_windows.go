@@ -0,0 +1,18 @@
+//go:build windows
+
+package webhook
+
+import (
+	"context"
+	"os/exec"
+	"syscall"
+)
+
+// shellCommand runs command with cmd.exe. The command line is passed verbatim
+// because cmd does not parse arguments the way exec quotes them; /S strips
+// only the outer quotes added here.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "cmd")
+	cmd.SysProcAttr = &syscall.SysProcAttr{CmdLine: `cmd /S /C "` + command + `"`}
+	return cmd
+}
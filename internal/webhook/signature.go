@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+const signaturePrefix = "sha256="
+
+// ErrInvalidSignature reports a missing or mismatched X-Hub-Signature-256 header.
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Sign returns the X-Hub-Signature-256 header value for body.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the X-Hub-Signature-256 header against the HMAC-SHA256 of body.
+func Verify(secret, body []byte, header string) error {
+	if !strings.HasPrefix(header, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(header, signaturePrefix))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}